@@ -0,0 +1,35 @@
+package main
+
+import (
+	"bytes"
+	"database/sql"
+	"strings"
+
+	"github.com/emersion/go-ical"
+	"github.com/emersion/go-webdav/caldav"
+)
+
+// encodeObjectData serializes the iCalendar data of a calendar object so that
+// it can be stored in the cache as-is.
+func encodeObjectData(data *ical.Calendar) (string, error) {
+	var buf bytes.Buffer
+	err := ical.NewEncoder(&buf).Encode(data)
+	if err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// decodeCachedObject reconstructs a calendar object from a row of the cache.
+func decodeCachedObject(path string, etag sql.NullString, ics string) (obj caldav.CalendarObject, err error) {
+	data, err := ical.NewDecoder(strings.NewReader(ics)).Decode()
+	if err != nil {
+		return
+	}
+	obj = caldav.CalendarObject{
+		Path: path,
+		ETag: etag.String,
+		Data: data,
+	}
+	return
+}
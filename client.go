@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/LQR471814/nu_plugin_caldav/internal/dav"
 	"github.com/ainvaltin/nu-plugin"
 	"github.com/emersion/go-webdav"
 	"github.com/emersion/go-webdav/caldav"
@@ -52,8 +53,8 @@ func getEnvBool(ctx context.Context, call *nu.ExecCommand, env string) (val bool
 	return
 }
 
-func getClient(ctx context.Context, call *nu.ExecCommand) (client *caldav.Client, err error) {
-	url, err := getEnvString(ctx, call, "NU_PLUGIN_CALDAV_URL")
+func getHTTPClient(ctx context.Context, call *nu.ExecCommand) (client webdav.HTTPClient, url string, err error) {
+	url, err = getEnvString(ctx, call, "NU_PLUGIN_CALDAV_URL")
 	if err != nil {
 		return
 	}
@@ -81,12 +82,30 @@ func getClient(ctx context.Context, call *nu.ExecCommand) (client *caldav.Client
 		Transport: transport,
 		Timeout:   10 * time.Second,
 	}
-	webdavHttp := webdav.HTTPClient(httpClient)
+	client = httpClient
 	if username != "" && password != "" {
-		webdavHttp = webdav.HTTPClientWithBasicAuth(httpClient, username, password)
+		client = webdav.HTTPClientWithBasicAuth(httpClient, username, password)
 	}
-	client, err = caldav.NewClient(webdavHttp, url)
+	return
+}
 
+func getClient(ctx context.Context, call *nu.ExecCommand) (client *caldav.Client, err error) {
+	httpClient, url, err := getHTTPClient(ctx, call)
+	if err != nil {
+		return
+	}
+	client, err = caldav.NewClient(httpClient, url)
+	return
+}
+
+// getDavClient returns a client for the requests not supported by
+// caldav.Client.
+func getDavClient(ctx context.Context, call *nu.ExecCommand) (client *dav.Client, err error) {
+	httpClient, url, err := getHTTPClient(ctx, call)
+	if err != nil {
+		return
+	}
+	client, err = dav.NewClient(httpClient, url)
 	return
 }
 
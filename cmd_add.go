@@ -0,0 +1,191 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"github.com/LQR471814/nu_plugin_caldav/internal/db"
+	"github.com/LQR471814/nu_plugin_caldav/internal/dto"
+	"github.com/LQR471814/nu_plugin_caldav/internal/events"
+	"github.com/LQR471814/nu_plugin_caldav/internal/nuconv"
+	"github.com/LQR471814/nu_plugin_caldav/internal/quickadd"
+	"github.com/ainvaltin/nu-plugin"
+	"github.com/ainvaltin/nu-plugin/syntaxshape"
+	"github.com/ainvaltin/nu-plugin/types"
+	"github.com/google/uuid"
+)
+
+// defaultCalendarEnv is the calendar events are added to when --calendar is
+// not given.
+const defaultCalendarEnv = "NU_PLUGIN_CALDAV_DEFAULT_CALENDAR"
+
+var addCmd = &nu.Command{
+	Signature: nu.PluginSignature{
+		Name:        "caldav add",
+		Category:    "Network",
+		Desc:        "Adds an event described by a phrase like \"Lunch with Sam tomorrow 12:30 for 1h @Cafe #social\" to a calendar.",
+		SearchTerms: []string{"caldav", "add", "quick", "create", "event", "natural"},
+		Named: []nu.Flag{
+			{
+				Long:  "calendar",
+				Short: 'c',
+				Desc:  "The `path` of the calendar to add the event to, defaults to $env." + defaultCalendarEnv + ".",
+				Shape: syntaxshape.String(),
+			},
+			{
+				Long:    "dry-run",
+				Short:   'n',
+				Default: &falseNu,
+				Desc:    "Return the parsed event without saving it.",
+			},
+			{
+				Long:    "offline",
+				Short:   'o',
+				Default: &falseNu,
+				Desc:    "Queue the write in the cache instead of sending it to the server, it can be sent later with `caldav push`.",
+			},
+			{
+				Long:  "timezone",
+				Short: 't',
+				Shape: syntaxshape.String(),
+				Desc:  "The timezone (ex. America/New_York) the dates and times of the phrase are in, defaults to the system timezone.",
+			},
+		},
+		RequiredPositional: []nu.PositionalArg{
+			{
+				Name: "phrase",
+				Desc: "The event: its summary with when it takes place (ex. tomorrow 12:30, every monday 9am, nov 3 for 2 days), " +
+					"@location, #categories and the duration (ex. for 1h) mixed in.",
+				Shape: syntaxshape.String(),
+			},
+		},
+		InputOutputTypes: []nu.InOutTypes{
+			{
+				In:  types.Nothing(),
+				Out: types.Record(nuconv.EventObjectType),
+			},
+		},
+	},
+	OnRun: addCmdExec,
+}
+
+func init() {
+	commands = append(commands, addCmd)
+}
+
+// addCalendarPath returns the calendar to add events to.
+func addCalendarPath(ctx context.Context, call *nu.ExecCommand) (calendarPath string, err error) {
+	flag, err := stringFlag(call, "calendar")
+	if err != nil {
+		return
+	}
+	if flag != nil {
+		return *flag, nil
+	}
+	variable, err := call.GetEnvVar(ctx, defaultCalendarEnv)
+	if err != nil {
+		return
+	}
+	if variable == nil {
+		err = fmt.Errorf("must specify --calendar or set %s", defaultCalendarEnv)
+		return
+	}
+	return tryCast[string](*variable)
+}
+
+func addCmdExec(ctx context.Context, call *nu.ExecCommand) (err error) {
+	defer func() {
+		res := recover()
+		if res != nil {
+			err = fmt.Errorf("Panic: %v\n%s", res, string(debug.Stack()))
+		}
+	}()
+
+	currentTime := time.Now()
+
+	phrase, err := tryCast[string](call.Positional[0])
+	if err != nil {
+		return
+	}
+	loc, err := timezoneFlag(call)
+	if err != nil {
+		return
+	}
+	dryRun := false
+	v, ok := call.FlagValue("dry-run")
+	if ok {
+		dryRun = v.Value.(bool)
+	}
+	offline := false
+	v, ok = call.FlagValue("offline")
+	if ok {
+		offline = v.Value.(bool)
+	}
+
+	event, err := quickadd.Parse(phrase, currentTime, loc)
+	if err != nil {
+		return fmt.Errorf("parse %q: %w", phrase, err)
+	}
+	uid, err := uuid.NewRandom()
+	if err != nil {
+		return
+	}
+	uidStr := uid.String()
+	event.Uid = &uidStr
+	replica := dto.EventObject{Main: event}
+
+	if !dryRun {
+		var calendarPath string
+		calendarPath, err = addCalendarPath(ctx, call)
+		if err != nil {
+			return
+		}
+		if isSubscriptionURL(calendarPath) {
+			return fmt.Errorf("cannot add events to the subscription %q, subscriptions are read-only", calendarPath)
+		}
+
+		subctx := saveEventCtx{
+			ctx:          ctx,
+			calendarPath: calendarPath,
+		}
+		if offline {
+			subctx.driver, subctx.qry, err = db.Open(ctx)
+			if err != nil {
+				return
+			}
+			defer subctx.driver.Close()
+		} else {
+			subctx.client, err = getClient(ctx, call)
+			if err != nil {
+				return
+			}
+		}
+
+		obj := events.EventObject{}
+		obj.Main = newEvent(event.Kind, loc)
+		err = event.Apply(obj.Main)
+		if err != nil {
+			return fmt.Errorf("apply main event: %w", err)
+		}
+		err = putEventObjects(subctx, []events.EventObject{obj}, 1, currentTime)
+		if err != nil {
+			return
+		}
+		var objectPath string
+		objectPath, err = resolveObjectPath(calendarPath, obj)
+		if err != nil {
+			return
+		}
+		replica.ObjectPath = &objectPath
+		replica.CalendarPath = &calendarPath
+	}
+
+	out, err := nuconv.EventObjectToNu(replica)
+	if err != nil {
+		return
+	}
+	err = call.ReturnValue(ctx, out)
+	return
+}
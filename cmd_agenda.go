@@ -0,0 +1,195 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/LQR471814/nu_plugin_caldav/internal/dto"
+	"github.com/LQR471814/nu_plugin_caldav/internal/nuconv"
+	"github.com/ainvaltin/nu-plugin"
+	"github.com/ainvaltin/nu-plugin/syntaxshape"
+	"github.com/ainvaltin/nu-plugin/types"
+	"github.com/emersion/go-ical"
+)
+
+var agendaCmd = &nu.Command{
+	Signature: nu.PluginSignature{
+		Name:        "caldav agenda",
+		Desc:        "Lists events grouped by day, with their times and locations.",
+		SearchTerms: []string{"caldav", "agenda", "schedule", "day"},
+		Category:    "Viewers",
+		Named: []nu.Flag{
+			{
+				Long:  "start",
+				Short: 's',
+				Desc:  "Only list events that end after this time.",
+				Shape: syntaxshape.DateTime(),
+			},
+			{
+				Long:  "end",
+				Short: 'e',
+				Desc:  "Only list events that start before this time.",
+				Shape: syntaxshape.DateTime(),
+			},
+			displayTimezoneFlag,
+		},
+		InputOutputTypes: []nu.InOutTypes{
+			{
+				// TODO: fix typing later
+				// since some fields may be omitted and nushell does not yet
+				// support optional typing
+				In:  types.Any(),
+				Out: nuconv.AgendaType,
+			},
+		},
+	},
+	OnRun: agendaCmdExec,
+}
+
+func init() {
+	commands = append(commands, agendaCmd)
+}
+
+// agendaTime returns the time range of an occurrence on a day, the ends
+// outside of the day are shown as "...".
+func agendaTime(occ dto.Occurrence, day time.Time, loc *time.Location) string {
+	if occ.AllDay {
+		return "all day"
+	}
+	next := day.AddDate(0, 0, 1)
+	from, to := "...", "..."
+	if !occ.Start.Before(day) {
+		from = occ.Start.In(loc).Format("15:04")
+	}
+	switch {
+	case occ.End.Before(next):
+		to = occ.End.In(loc).Format("15:04")
+	case occ.End.Equal(next):
+		// the event ends with the day rather than continuing on the next
+		to = "24:00"
+	}
+	if from == "..." && to == "..." {
+		return "all day"
+	}
+	return from + "-" + to
+}
+
+// buildAgenda lists the occurrences of a source within [start, end] under
+// each day (in loc) they take place on, days without events are omitted.
+// Problems with the events are returned as warnings.
+func buildAgenda(src *heapSource, start, end time.Time, loc *time.Location) (out dto.Agenda, warnings []string) {
+	days := map[time.Time]*dto.AgendaDay{}
+	for {
+		warnings = append(warnings, src.warnings()...)
+		occ, ok := src.peekOccurrence()
+		if !ok || !occ.Start.Before(end) {
+			break
+		}
+		src.pop()
+		// events that end at the start of the window are not in it, unless
+		// they are instantaneous
+		if occ.End.Before(start) || (occ.End.Equal(start) && occ.End.After(occ.Start)) {
+			continue
+		}
+
+		from := occ.Start
+		if from.Before(start) {
+			from = start
+		}
+		to := occ.End
+		if to.After(end) {
+			to = end
+		}
+		// instantaneous events are listed on the day they take place on
+		first := periodStart(from, false, loc)
+		for day := first; day.Equal(first) || day.Before(to); day = day.AddDate(0, 0, 1) {
+			agendaDay, ok := days[day]
+			if !ok {
+				agendaDay = &dto.AgendaDay{
+					Date:    day,
+					Weekday: day.Weekday().String(),
+				}
+				days[day] = agendaDay
+			}
+			var calendar *string
+			if name := calendarName(occ); name != "" {
+				calendar = &name
+			}
+			agendaDay.Events = append(agendaDay.Events, dto.AgendaEntry{
+				Time:       agendaTime(occ, day, loc),
+				AllDay:     occ.AllDay,
+				Summary:    occ.Event.Summary,
+				Location:   occ.Event.Location,
+				Calendar:   calendar,
+				Start:      occ.Start.In(loc),
+				End:        occ.End.In(loc),
+				ObjectPath: occ.ObjectPath,
+			})
+		}
+	}
+	warnings = append(warnings, src.warnings()...)
+
+	for _, agendaDay := range days {
+		// all-day events first, then in order of their start
+		slices.SortStableFunc(agendaDay.Events, func(a, b dto.AgendaEntry) int {
+			switch {
+			case a.AllDay && !b.AllDay:
+				return -1
+			case !a.AllDay && b.AllDay:
+				return 1
+			}
+			return a.Start.Compare(b.Start)
+		})
+		out = append(out, *agendaDay)
+	}
+	slices.SortFunc(out, func(a, b dto.AgendaDay) int {
+		return a.Date.Compare(b.Date)
+	})
+	return
+}
+
+func agendaCmdExec(ctx context.Context, call *nu.ExecCommand) (err error) {
+	start, ok := call.Named["start"].Value.(time.Time)
+	if !ok {
+		err = fmt.Errorf("must specify -start")
+		return
+	}
+	end, ok := call.Named["end"].Value.(time.Time)
+	if !ok {
+		err = fmt.Errorf("must specify -end")
+		return
+	}
+	if end.Before(start) {
+		err = fmt.Errorf("end %v cannot be before start %v", end, start)
+		return
+	}
+
+	loc, err := timezoneFlag(call)
+	if err != nil {
+		return
+	}
+
+	objects, err := recvListInput(call, nuconv.EventObjectFromNu)
+	if err != nil {
+		return
+	}
+	objects = filterKinds(objects, ical.CompEvent)
+	for i, obj := range objects {
+		objects[i] = anchorObject(obj, loc)
+	}
+
+	agenda, warnings := buildAgenda(newHeapSource(objects, start, end), start, end, loc)
+	for _, w := range warnings {
+		slog.Warn("agenda", "warning", w)
+	}
+
+	out, err := nuconv.AgendaToNu(agenda)
+	if err != nil {
+		return
+	}
+	err = call.ReturnValue(ctx, out)
+	return
+}
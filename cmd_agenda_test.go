@@ -0,0 +1,83 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/LQR471814/nu_plugin_caldav/internal/dto"
+	"github.com/LQR471814/nu_plugin_caldav/internal/events"
+)
+
+func agendaObjects(ny *time.Location) []dto.EventObject {
+	at := func(days, h int) time.Time {
+		return time.Date(2025, 10, 20+days, h, 0, 0, 0, ny)
+	}
+	object := func(summary string, s, e time.Time, allDay bool) dto.EventObject {
+		return dto.EventObject{Main: dto.Event{
+			Summary: &summary,
+			Start:   events.Datetime{Stamp: s, AllDay: allDay},
+			End:     events.Datetime{Stamp: e, AllDay: allDay},
+		}}
+	}
+	return []dto.EventObject{
+		object("Standup", at(0, 9), at(0, 10), false),
+		object("Holiday", at(0, 0), at(1, 0), true),
+		object("Flight", at(1, 22), at(2, 6), false),
+		object("Next week", at(7, 9), at(7, 10), false),
+	}
+}
+
+func TestBuildAgenda(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Date(2025, 10, 20, 0, 0, 0, 0, ny)
+	end := start.AddDate(0, 0, 7)
+
+	// the agenda is displayed in another timezone than the events'
+	la, err := time.LoadLocation("America/Los_Angeles")
+	if err != nil {
+		t.Fatal(err)
+	}
+	objects := agendaObjects(ny)
+	for i, obj := range objects {
+		objects[i] = anchorObject(obj, la)
+	}
+	agenda, warnings := buildAgenda(newHeapSource(objects, start, end), start, end, la)
+	if len(warnings) > 0 {
+		t.Fatal(warnings)
+	}
+
+	var got []string
+	for _, day := range agenda {
+		for _, entry := range day.Events {
+			got = append(got, day.Date.Format("Mon")+" "+entry.Time+" "+*entry.Summary)
+		}
+	}
+	expected := []string{
+		"Mon all day Holiday",
+		"Mon 06:00-07:00 Standup",
+		"Tue 19:00-... Flight",
+		"Wed ...-03:00 Flight",
+	}
+	if strings.Join(got, "\n") != strings.Join(expected, "\n") {
+		t.Fatalf("unexpected agenda:\n%s", strings.Join(got, "\n"))
+	}
+}
+
+func TestAgendaTimeEndsAtMidnight(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	day := time.Date(2025, 10, 20, 0, 0, 0, 0, ny)
+	occ := dto.Occurrence{
+		Start: day.Add(22 * time.Hour),
+		End:   day.AddDate(0, 0, 1),
+	}
+	if got := agendaTime(occ, day, ny); got != "22:00-24:00" {
+		t.Fatalf("unexpected time %q", got)
+	}
+}
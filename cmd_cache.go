@@ -0,0 +1,179 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"time"
+
+	"github.com/LQR471814/nu_plugin_caldav/internal/db"
+	"github.com/LQR471814/nu_plugin_caldav/internal/dto"
+	"github.com/LQR471814/nu_plugin_caldav/internal/nuconv"
+	"github.com/ainvaltin/nu-plugin"
+	"github.com/ainvaltin/nu-plugin/types"
+)
+
+var cacheStatusCmd = &nu.Command{
+	Signature: nu.PluginSignature{
+		Name:        "caldav cache status",
+		Category:    "Misc",
+		Desc:        "Shows the location, size, and contents of the cache.",
+		SearchTerms: []string{"caldav", "cache", "status", "inspect"},
+		InputOutputTypes: []nu.InOutTypes{
+			{
+				In:  types.Nothing(),
+				Out: types.Record(nuconv.CacheStatusType),
+			},
+		},
+	},
+	OnRun: cacheStatusCmdExec,
+}
+
+var cacheCalendarsCmd = &nu.Command{
+	Signature: nu.PluginSignature{
+		Name:        "caldav cache calendars",
+		Category:    "Misc",
+		Desc:        "Lists the calendars in the cache with their sync state.",
+		SearchTerms: []string{"caldav", "cache", "calendars", "inspect", "sync"},
+		InputOutputTypes: []nu.InOutTypes{
+			{
+				In:  types.Nothing(),
+				Out: nuconv.CachedCalendarListType,
+			},
+		},
+	},
+	OnRun: cacheCalendarsCmdExec,
+}
+
+func init() {
+	commands = append(commands, cacheStatusCmd, cacheCalendarsCmd)
+}
+
+// cacheSize returns the size of the cache file and its sqlite sidecar files.
+func cacheSize(file string) (size int64, err error) {
+	for _, suffix := range []string{"", "-wal", "-shm"} {
+		var info os.FileInfo
+		info, err = os.Stat(file + suffix)
+		if errors.Is(err, fs.ErrNotExist) {
+			err = nil
+			continue
+		}
+		if err != nil {
+			return
+		}
+		size += info.Size()
+	}
+	return
+}
+
+// cacheExists reports whether the cache file exists, the inspection commands
+// do not open (and thereby create) a cache that does not exist yet.
+func cacheExists() (bool, error) {
+	_, err := os.Stat(db.File())
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func cacheStatusCmdExec(ctx context.Context, call *nu.ExecCommand) (err error) {
+	status := dto.CacheStatus{Path: db.File()}
+
+	exists, err := cacheExists()
+	if err != nil {
+		return
+	}
+	if exists {
+		status, err = readCacheStatus(ctx)
+		if err != nil {
+			return
+		}
+	}
+
+	out, err := nuconv.CacheStatusToNu(status)
+	if err != nil {
+		return
+	}
+	err = call.ReturnValue(ctx, out)
+	return
+}
+
+func readCacheStatus(ctx context.Context) (status dto.CacheStatus, err error) {
+	driver, qry, err := db.Open(ctx)
+	if err != nil {
+		return
+	}
+	defer driver.Close()
+
+	counts, err := qry.ReadCacheCounts(ctx)
+	if err != nil {
+		return
+	}
+	file := db.File()
+	size, err := cacheSize(file)
+	if err != nil {
+		return
+	}
+
+	status = dto.CacheStatus{
+		Path:              file,
+		Size:              size,
+		Calendars:         counts.Calendars,
+		Objects:           counts.Objects,
+		PendingOperations: counts.PendingOperations,
+	}
+	return
+}
+
+func cacheCalendarsCmdExec(ctx context.Context, call *nu.ExecCommand) (err error) {
+	calendars := dto.CachedCalendarList{}
+
+	exists, err := cacheExists()
+	if err != nil {
+		return
+	}
+	if exists {
+		calendars, err = readCachedCalendars(ctx)
+		if err != nil {
+			return
+		}
+	}
+
+	out, err := nuconv.CachedCalendarListToNu(calendars)
+	if err != nil {
+		return
+	}
+	err = call.ReturnValue(ctx, out)
+	return
+}
+
+func readCachedCalendars(ctx context.Context) (calendars dto.CachedCalendarList, err error) {
+	driver, qry, err := db.Open(ctx)
+	if err != nil {
+		return
+	}
+	defer driver.Close()
+
+	rows, err := qry.ReadCachedCalendars(ctx)
+	if err != nil {
+		return
+	}
+
+	calendars = make(dto.CachedCalendarList, len(rows))
+	for i, row := range rows {
+		calendars[i] = dto.CachedCalendar{
+			Path:          row.Path,
+			Objects:       row.ObjectCount,
+			ParseFailures: row.FailureCount,
+		}
+		if row.SyncToken.Valid {
+			calendars[i].SyncToken = &row.SyncToken.String
+		}
+		if row.LastSync.Valid {
+			lastSync := time.Unix(row.LastSync.Int64, 0)
+			calendars[i].LastSync = &lastSync
+		}
+	}
+	return
+}
@@ -0,0 +1,220 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/LQR471814/nu_plugin_caldav/internal/dto"
+	"github.com/LQR471814/nu_plugin_caldav/internal/nuconv"
+	"github.com/ainvaltin/nu-plugin"
+	"github.com/ainvaltin/nu-plugin/syntaxshape"
+	"github.com/ainvaltin/nu-plugin/types"
+	"github.com/emersion/go-ical"
+)
+
+var conflictsCmd = &nu.Command{
+	Signature: nu.PluginSignature{
+		Name:        "caldav conflicts",
+		Desc:        "Finds the spans of time in which busy events overlap.",
+		SearchTerms: []string{"caldav", "conflicts", "overlap", "double-booked"},
+		Category:    "Viewers",
+		Named: []nu.Flag{
+			{
+				Long:  "start",
+				Short: 's',
+				Desc:  "Only find conflicts after this time.",
+				Shape: syntaxshape.DateTime(),
+			},
+			{
+				Long:  "end",
+				Short: 'e',
+				Desc:  "Only find conflicts before this time.",
+				Shape: syntaxshape.DateTime(),
+			},
+			floatingTimezoneFlag,
+		},
+		InputOutputTypes: []nu.InOutTypes{
+			{
+				// TODO: fix typing later
+				// since some fields may be omitted and nushell does not yet
+				// support optional typing
+				In:  types.Any(),
+				Out: nuconv.ConflictListType,
+			},
+		},
+	},
+	OnRun: conflictsCmdExec,
+}
+
+func init() {
+	commands = append(commands, conflictsCmd)
+}
+
+// calendarName returns the name of the calendar of an occurrence, or its path
+// if it has no name.
+func calendarName(occ dto.Occurrence) string {
+	switch {
+	case occ.CalendarName != nil && *occ.CalendarName != "":
+		return *occ.CalendarName
+	case occ.CalendarPath != nil:
+		return *occ.CalendarPath
+	}
+	return ""
+}
+
+// conflictCalendars returns the calendars of the occurrences of a conflict,
+// in order of appearance.
+func conflictCalendars(occurrences []dto.Occurrence) (out []string) {
+	for _, occ := range occurrences {
+		name := calendarName(occ)
+		if name != "" && !slices.Contains(out, name) {
+			out = append(out, name)
+		}
+	}
+	return
+}
+
+// activeOccurrence is an occurrence that has started but not ended yet,
+// involved is set once it is part of the current conflict.
+type activeOccurrence struct {
+	dto.Occurrence
+	involved bool
+}
+
+// findConflicts returns the maximal spans of time within [start, end] in
+// which at least two busy occurrences of a source overlap, with all the
+// occurrences involved. Problems with the events are returned as warnings.
+func findConflicts(src *heapSource, start, end time.Time) (out []dto.Conflict, warnings []string) {
+	// active are the occurrences that have started but not ended yet
+	var active []activeOccurrence
+	var current *dto.Conflict
+	// lastEnd is the time the last occurrence was removed from active at,
+	// the current conflict is only closed once no occurrence that starts at
+	// lastEnd can keep it going
+	var lastEnd time.Time
+
+	settle := func() {
+		if current == nil || len(active) >= 2 {
+			return
+		}
+		current.End = lastEnd
+		current.Duration = lastEnd.Sub(current.Start)
+		current.Calendars = conflictCalendars(current.Occurrences)
+		out = append(out, *current)
+		current = nil
+		for i := range active {
+			active[i].involved = false
+		}
+	}
+	// endBefore removes the active occurrences that end before (or at) t
+	endBefore := func(t time.Time) {
+		for len(active) > 0 {
+			first := 0
+			for i, occ := range active {
+				if occ.End.Before(active[first].End) {
+					first = i
+				}
+			}
+			at := active[first].End
+			if at.After(t) {
+				return
+			}
+			if at.After(lastEnd) {
+				settle()
+			}
+			active = slices.Delete(active, first, first+1)
+			lastEnd = at
+		}
+	}
+
+	for {
+		warnings = append(warnings, src.warnings()...)
+		occ, ok := src.peekOccurrence()
+		if !ok || !occ.Start.Before(end) {
+			break
+		}
+		src.pop()
+		// instantaneous events cannot overlap anything
+		if !isBusy(occ.Event) || !occ.End.After(start) || !occ.End.After(occ.Start) {
+			continue
+		}
+		if occ.Start.Before(start) {
+			occ.Start = start
+		}
+		if occ.End.After(end) {
+			occ.End = end
+		}
+
+		endBefore(occ.Start)
+		// the occurrences that ended before the start may have closed the
+		// current conflict
+		if occ.Start.After(lastEnd) {
+			settle()
+		}
+		active = append(active, activeOccurrence{Occurrence: occ})
+		if len(active) < 2 {
+			// an occurrence that starts as the current conflict ends is only
+			// involved in it if another one overlaps it
+			continue
+		}
+		if current == nil {
+			current = &dto.Conflict{Start: occ.Start}
+		}
+		for i := range active {
+			if !active[i].involved {
+				active[i].involved = true
+				current.Occurrences = append(current.Occurrences, active[i].Occurrence)
+			}
+		}
+	}
+	warnings = append(warnings, src.warnings()...)
+	endBefore(end)
+	settle()
+	return
+}
+
+func conflictsCmdExec(ctx context.Context, call *nu.ExecCommand) (err error) {
+	start, ok := call.Named["start"].Value.(time.Time)
+	if !ok {
+		err = fmt.Errorf("must specify -start")
+		return
+	}
+	end, ok := call.Named["end"].Value.(time.Time)
+	if !ok {
+		err = fmt.Errorf("must specify -end")
+		return
+	}
+	if end.Before(start) {
+		err = fmt.Errorf("end %v cannot be before start %v", end, start)
+		return
+	}
+
+	floating, err := timezoneFlag(call)
+	if err != nil {
+		return
+	}
+
+	objects, err := recvListInput(call, nuconv.EventObjectFromNu)
+	if err != nil {
+		return
+	}
+	objects = filterKinds(objects, ical.CompEvent)
+	for i, obj := range objects {
+		objects[i] = anchorObject(obj, floating)
+	}
+
+	conflicts, warnings := findConflicts(newHeapSource(objects, start, end), start, end)
+	for _, w := range warnings {
+		slog.Warn("conflicts", "warning", w)
+	}
+
+	out, err := nuconv.ConflictListToNu(conflicts)
+	if err != nil {
+		return
+	}
+	err = call.ReturnValue(ctx, out)
+	return
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/LQR471814/nu_plugin_caldav/internal/dto"
+	"github.com/LQR471814/nu_plugin_caldav/internal/events"
+	"github.com/teambition/rrule-go"
+)
+
+func TestFindConflicts(t *testing.T) {
+	start := time.Date(2025, 10, 20, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 7)
+	at := func(days, h int) time.Time {
+		return start.Add(time.Duration(days*24+h) * time.Hour)
+	}
+	work, home := "Work", "Home"
+	transparent := events.EVENT_TRANSPARENCY_TRANSPARENT
+	cancelled := events.EVENT_STATUS_CANCELLED
+	object := func(calendar *string, s, e time.Time) dto.EventObject {
+		return dto.EventObject{
+			CalendarName: calendar,
+			Main: dto.Event{
+				Start: events.Datetime{Stamp: s},
+				End:   events.Datetime{Stamp: e},
+			},
+		}
+	}
+
+	standup := object(&work, at(0, 9), at(0, 10))
+	rule, err := rrule.NewRRule(rrule.ROption{
+		Freq:    rrule.DAILY,
+		Count:   5,
+		Dtstart: at(0, 9),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	standup.Main.RecurrenceRule = dto.RRule{RRule: rule}
+
+	free := object(&home, at(0, 9), at(0, 10))
+	free.Main.Transparency = &transparent
+	dropped := object(&home, at(1, 9), at(1, 10))
+	dropped.Main.Status = &cancelled
+
+	objects := []dto.EventObject{
+		standup,
+		free,
+		dropped,
+		// overlaps the standup on tuesday, then the dentist
+		object(&home, at(2, 9), at(2, 11)),
+		object(&home, at(2, 10), at(2, 12)),
+		// touches the standup on wednesday
+		object(&work, at(3, 10), at(3, 11)),
+		// invalid
+		object(&work, at(4, 12), at(4, 11)),
+	}
+
+	conflicts, warnings := findConflicts(newHeapSource(objects, start, end), start, end)
+	if len(warnings) != 1 {
+		t.Fatalf("expected a warning for the invalid event, got %v", warnings)
+	}
+	if len(conflicts) != 1 {
+		t.Fatalf("expected 1 conflict, got %+v", conflicts)
+	}
+	c := conflicts[0]
+	if !c.Start.Equal(at(2, 9)) || !c.End.Equal(at(2, 11)) || c.Duration != 2*time.Hour {
+		t.Fatalf("unexpected conflict span %v - %v (%v)", c.Start, c.End, c.Duration)
+	}
+	if len(c.Occurrences) != 3 || !slices.Equal(c.Calendars, []string{work, home}) &&
+		!slices.Equal(c.Calendars, []string{home, work}) {
+		t.Fatalf("unexpected conflict events %+v on calendars %v", c.Occurrences, c.Calendars)
+	}
+}
+
+func TestFindConflictsSeparatesOverlaps(t *testing.T) {
+	start := time.Date(2025, 10, 20, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 1)
+	at := func(h int) time.Time {
+		return start.Add(time.Duration(h) * time.Hour)
+	}
+	object := func(summary string, s, e time.Time) dto.EventObject {
+		return dto.EventObject{Main: dto.Event{
+			Summary: &summary,
+			Start:   events.Datetime{Stamp: s},
+			End:     events.Datetime{Stamp: e},
+		}}
+	}
+
+	objects := []dto.EventObject{
+		object("Workshop", at(9), at(17)),
+		object("Call", at(10), at(11)),
+		object("Review", at(13), at(14)),
+		// touches the review, so it continues its conflict
+		object("Sync", at(14), at(15)),
+	}
+	conflicts, warnings := findConflicts(newHeapSource(objects, start, end), start, end)
+	if len(warnings) != 0 {
+		t.Fatalf("unexpected warnings %v", warnings)
+	}
+	if len(conflicts) != 2 {
+		t.Fatalf("expected 2 conflicts, got %+v", conflicts)
+	}
+	for i, expected := range []struct {
+		start, end time.Time
+		events     int
+	}{
+		{at(10), at(11), 2},
+		{at(13), at(15), 3},
+	} {
+		c := conflicts[i]
+		if !c.Start.Equal(expected.start) || !c.End.Equal(expected.end) || len(c.Occurrences) != expected.events {
+			t.Errorf("conflict %d: unexpected %v - %v with %d events", i, c.Start, c.End, len(c.Occurrences))
+		}
+	}
+
+	objects = []dto.EventObject{
+		object("Standup", at(9), at(10)),
+		object("Interview", at(9), at(10)),
+		// touches the conflict without overlapping anything
+		object("Lunch", at(10), at(11)),
+	}
+	conflicts, _ = findConflicts(newHeapSource(objects, start, end), start, end)
+	if len(conflicts) != 1 || len(conflicts[0].Occurrences) != 2 || !conflicts[0].End.Equal(at(10)) {
+		t.Fatalf("expected a single conflict of the standup and the interview, got %+v", conflicts)
+	}
+}
@@ -0,0 +1,200 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/LQR471814/nu_plugin_caldav/internal/dav"
+	"github.com/LQR471814/nu_plugin_caldav/internal/db"
+	"github.com/LQR471814/nu_plugin_caldav/internal/events"
+	"github.com/ainvaltin/nu-plugin"
+	"github.com/ainvaltin/nu-plugin/syntaxshape"
+	"github.com/ainvaltin/nu-plugin/types"
+	"github.com/google/uuid"
+)
+
+// calendarPropertyFlags are the flags that set the properties of a calendar.
+var calendarPropertyFlags = []nu.Flag{
+	{
+		Long:  "description",
+		Short: 'd',
+		Shape: syntaxshape.String(),
+		Desc:  "The description of the calendar.",
+	},
+	{
+		Long:  "color",
+		Short: 'c',
+		Shape: syntaxshape.String(),
+		Desc:  "The color of the calendar in the form #RRGGBB.",
+	},
+	{
+		Long:  "timezone",
+		Short: 't',
+		Shape: syntaxshape.String(),
+		Desc:  "The IANA name of the timezone (ex. America/New_York) used for floating times in the calendar.",
+	},
+}
+
+var createCalendarCmd = &nu.Command{
+	Signature: nu.PluginSignature{
+		Name:        "caldav create calendar",
+		Category:    "Network",
+		Desc:        "Creates a new calendar under a homeset, returning its path.",
+		SearchTerms: []string{"caldav", "create", "calendar", "mkcalendar", "new"},
+		Named: append([]nu.Flag{
+			{
+				Long:  "components",
+				Short: 'm',
+				Shape: syntaxshape.List(syntaxshape.String()),
+				Desc:  "The components that can be stored in the calendar (ex. [VEVENT VTODO]), defaults to what the server allows.",
+			},
+		}, calendarPropertyFlags...),
+		RequiredPositional: []nu.PositionalArg{
+			{
+				Name:  "homeset",
+				Desc:  "The string returned by `caldav query homeset`.",
+				Shape: syntaxshape.String(),
+			},
+			{
+				Name:  "name",
+				Desc:  "The display name of the calendar.",
+				Shape: syntaxshape.String(),
+			},
+		},
+		InputOutputTypes: []nu.InOutTypes{
+			{
+				In:  types.Nothing(),
+				Out: types.String(),
+			},
+		},
+	},
+	OnRun: createCalendarCmdExec,
+}
+
+func init() {
+	commands = append(commands, createCalendarCmd)
+}
+
+// encodeTimezone returns an iCalendar object with the VTIMEZONE of the given
+// IANA timezone.
+func encodeTimezone(name string, now time.Time) (ics string, err error) {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return
+	}
+	from := time.Date(now.Year(), 1, 1, 0, 0, 0, 0, time.UTC)
+	cal := events.NewCalendar()
+	cal.Children = append(cal.Children, events.NewTimezone(loc, from, from.AddDate(10, 0, 0)))
+	return encodeObjectData(cal)
+}
+
+// calendarOptionsFromFlags reads the flags in calendarPropertyFlags.
+func calendarOptionsFromFlags(call *nu.ExecCommand, now time.Time) (opts dav.CalendarOptions, err error) {
+	opts.Description, err = stringFlag(call, "description")
+	if err != nil {
+		return
+	}
+	opts.Color, err = stringFlag(call, "color")
+	if err != nil {
+		return
+	}
+	timezone, err := stringFlag(call, "timezone")
+	if err != nil || timezone == nil {
+		return
+	}
+	ics, err := encodeTimezone(*timezone, now)
+	if err != nil {
+		return
+	}
+	opts.Timezone = &ics
+	return
+}
+
+// putCachedCalendarProperties updates the properties of a calendar in the
+// cache, unset options leave the cached property unchanged.
+func putCachedCalendarProperties(ctx context.Context, qry *db.Queries, calendarPath string, opts dav.CalendarOptions) (err error) {
+	props, err := qry.ReadCalendarProperties(ctx, calendarPath)
+	if err != nil {
+		return
+	}
+	if opts.DisplayName != nil {
+		props.DisplayName = sql.NullString{String: *opts.DisplayName, Valid: true}
+	}
+	if opts.Color != nil {
+		props.Color = sql.NullString{String: *opts.Color, Valid: true}
+	}
+	err = qry.PutCalendarProperties(ctx, db.PutCalendarPropertiesParams{
+		DisplayName: props.DisplayName,
+		Color:       props.Color,
+		Path:        calendarPath,
+	})
+	return
+}
+
+func createCalendarCmdExec(ctx context.Context, call *nu.ExecCommand) (err error) {
+	homeset, err := tryCast[string](call.Positional[0])
+	if err != nil {
+		return
+	}
+	name, err := tryCast[string](call.Positional[1])
+	if err != nil {
+		return
+	}
+	opts, err := calendarOptionsFromFlags(call, time.Now())
+	if err != nil {
+		return
+	}
+	opts.DisplayName = &name
+	v, ok := call.FlagValue("components")
+	if ok {
+		var list []nu.Value
+		list, err = tryCast[[]nu.Value](v)
+		if err != nil {
+			return
+		}
+		for _, c := range list {
+			var comp string
+			comp, err = tryCast[string](c)
+			if err != nil {
+				return
+			}
+			opts.SupportedComponents = append(opts.SupportedComponents, strings.ToUpper(comp))
+		}
+	}
+
+	client, err := getDavClient(ctx, call)
+	if err != nil {
+		return
+	}
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return
+	}
+	calendarPath := path.Join(homeset, id.String()) + "/"
+	err = client.MakeCalendar(ctx, calendarPath, opts)
+	if err != nil {
+		return
+	}
+
+	// the calendar is added to the cache without a sync token, so the first
+	// query performs a full sync
+	driver, qry, err := db.Open(ctx)
+	if err != nil {
+		return
+	}
+	defer driver.Close()
+	err = qry.PutCalendar(ctx, db.PutCalendarParams{Path: calendarPath})
+	if err != nil {
+		return
+	}
+	err = putCachedCalendarProperties(ctx, qry, calendarPath, opts)
+	if err != nil {
+		return
+	}
+
+	err = call.ReturnValue(ctx, nu.ToValue(calendarPath))
+	return
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+
+	"github.com/LQR471814/nu_plugin_caldav/internal/dav"
+	"github.com/ainvaltin/nu-plugin"
+	"github.com/ainvaltin/nu-plugin/syntaxshape"
+	"github.com/ainvaltin/nu-plugin/types"
+)
+
+var deleteCalendarCmd = &nu.Command{
+	Signature: nu.PluginSignature{
+		Name:        "caldav delete calendar",
+		Category:    "Network",
+		Desc:        "Deletes a calendar and all of its events.",
+		SearchTerms: []string{"caldav", "delete", "calendar", "remove"},
+		RequiredPositional: []nu.PositionalArg{
+			{
+				Name:  "calendar_path",
+				Desc:  "The `path` attribute of the calendar record returned by `caldav query calendars`.",
+				Shape: syntaxshape.String(),
+			},
+		},
+		InputOutputTypes: []nu.InOutTypes{
+			{
+				In:  types.Nothing(),
+				Out: types.Nothing(),
+			},
+		},
+	},
+	OnRun: deleteCalendarCmdExec,
+}
+
+func init() {
+	commands = append(commands, deleteCalendarCmd)
+}
+
+func deleteCalendarCmdExec(ctx context.Context, call *nu.ExecCommand) (err error) {
+	calendarPath, err := tryCast[string](call.Positional[0])
+	if err != nil {
+		return
+	}
+	client, err := getDavClient(ctx, call)
+	if err != nil {
+		return
+	}
+	err = client.Delete(ctx, calendarPath, dav.Precondition{})
+	if err != nil {
+		return
+	}
+	// pending writes to the calendar can no longer be pushed
+	err = purgeCalendar(ctx, calendarPath, false)
+	return
+}
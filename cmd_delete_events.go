@@ -6,6 +6,7 @@ import (
 	"runtime/debug"
 	"time"
 
+	"github.com/LQR471814/nu_plugin_caldav/internal/db"
 	"github.com/ainvaltin/nu-plugin"
 	"github.com/ainvaltin/nu-plugin/types"
 	"github.com/emersion/go-webdav/caldav"
@@ -24,6 +25,12 @@ var deleteEventsCmd = &nu.Command{
 				Default: &defaultParallelism,
 				Desc:    "Controls the amount of requests that can be made in parallel.",
 			},
+			{
+				Long:    "offline",
+				Short:   'o',
+				Default: &falseNu,
+				Desc:    "Queue the deletions in the cache instead of sending them to the server, they can be sent later with `caldav push`.",
+			},
 		},
 		InputOutputTypes: []nu.InOutTypes{
 			{
@@ -60,20 +67,35 @@ func deleteEventsCmdExec(ctx context.Context, call *nu.ExecCommand) (err error)
 	}()
 
 	// parse flags
-	client, err := getClient(ctx, call)
-	if err != nil {
-		return
-	}
 	parallel := 1
 	v, ok := call.FlagValue("parallel")
 	if ok {
 		parallel = v.Value.(int)
 	}
+	offline := false
+	v, ok = call.FlagValue("offline")
+	if ok {
+		offline = v.Value.(bool)
+	}
 
 	inputs, err := recvListInput(call, func(v nu.Value) (string, error) { return tryCast[string](v) })
 	if err != nil {
 		return
 	}
+	for _, objpath := range inputs {
+		if isSubscriptionURL(objpath) {
+			return fmt.Errorf("cannot delete %q, subscriptions are read-only", objpath)
+		}
+	}
+
+	if offline {
+		return queueDeleteObjects(ctx, inputs, time.Now())
+	}
+
+	client, err := getClient(ctx, call)
+	if err != nil {
+		return
+	}
 
 	jobs := make([]job, len(inputs))
 	for i, objpath := range inputs {
@@ -90,3 +112,28 @@ func deleteEventsCmdExec(ctx context.Context, call *nu.ExecCommand) (err error)
 
 	return
 }
+
+// queueDeleteObjects records the deletion of the given objects in the cache
+// to be pushed later.
+func queueDeleteObjects(ctx context.Context, objpaths []string, now time.Time) (err error) {
+	driver, qry, err := db.Open(ctx)
+	if err != nil {
+		return
+	}
+	defer driver.Close()
+
+	tx, err := driver.BeginTx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer tx.Rollback()
+	txqry := qry.WithTx(tx)
+
+	for _, objpath := range objpaths {
+		err = queueDelete(ctx, txqry, objpath, now)
+		if err != nil {
+			return
+		}
+	}
+	return tx.Commit()
+}
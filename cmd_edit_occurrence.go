@@ -0,0 +1,246 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"runtime/debug"
+	"time"
+
+	"github.com/LQR471814/nu_plugin_caldav/internal/db"
+	"github.com/LQR471814/nu_plugin_caldav/internal/dto"
+	"github.com/LQR471814/nu_plugin_caldav/internal/events"
+	"github.com/LQR471814/nu_plugin_caldav/internal/nuconv"
+	"github.com/ainvaltin/nu-plugin"
+	"github.com/ainvaltin/nu-plugin/syntaxshape"
+	"github.com/ainvaltin/nu-plugin/types"
+	"github.com/google/uuid"
+)
+
+var editOccurrenceCmd = &nu.Command{
+	Signature: nu.PluginSignature{
+		Name:        "caldav edit occurrence",
+		Category:    "Network",
+		Desc:        "Edits (or deletes) a single occurrence of a recurring event, or the occurrence and all the following ones.",
+		SearchTerms: []string{"caldav", "edit", "occurrence", "recurrence", "override"},
+		Named: []nu.Flag{
+			{
+				Long:    "this-and-future",
+				Short:   'f',
+				Default: &falseNu,
+				Desc:    "Apply the change to the occurrence and all the following ones by ending the series before the occurrence and creating a new series from it.",
+			},
+			{
+				Long:    "delete",
+				Short:   'd',
+				Default: &falseNu,
+				Desc:    "Delete the occurrence (or the occurrence and all the following ones) instead of editing it.",
+			},
+			{
+				Long:    "offline",
+				Short:   'o',
+				Default: &falseNu,
+				Desc:    "Queue the write in the cache instead of sending it to the server, it can be sent later with `caldav push`.",
+			},
+		},
+		RequiredPositional: []nu.PositionalArg{
+			{
+				Name:  "object_path",
+				Desc:  "The `object_path` of the recurring event object.",
+				Shape: syntaxshape.String(),
+			},
+			{
+				Name:  "recurrence_id",
+				Desc:  "The `recurrence_id` of the occurrence as returned by `caldav expand`.",
+				Shape: syntaxshape.DateTime(),
+			},
+		},
+		InputOutputTypes: []nu.InOutTypes{
+			{
+				// the edited event, ex. the `event` of a `caldav expand` row
+				In:  types.Any(),
+				Out: types.String(),
+			},
+			{
+				// with --delete
+				In:  types.Nothing(),
+				Out: types.String(),
+			},
+		},
+	},
+	OnRun: editOccurrenceCmdExec,
+}
+
+func init() {
+	commands = append(commands, editOccurrenceCmd)
+}
+
+// occurrenceEdit returns the fields of an edited occurrence to apply to an
+// override or a new series, the recurrence of the main event it was expanded
+// from is not copied over.
+func occurrenceEdit(edit dto.Event, uid string) dto.Event {
+	edit.Uid = &uid
+	edit.RecurrenceRule = dto.RRule{}
+	edit.ExtraRecurrenceRules = nil
+	edit.ExceptionRules = nil
+	edit.RecurrenceDates = nil
+	edit.RecurrencePeriods = nil
+	edit.RecurrenceExceptionDates = nil
+	edit.RecurrenceInstance = nil
+	edit.ThisAndFuture = false
+	return edit
+}
+
+// editOccurrence applies an edit to an occurrence of an event object (or
+// deletes it if edit is nil), it returns the new series if the object is
+// split.
+func editOccurrence(obj *events.EventObject, rid time.Time, edit *dto.Event, thisAndFuture bool) (next *events.EventObject, err error) {
+	uid, err := obj.Main.GetUID()
+	if err != nil {
+		return
+	}
+
+	if !thisAndFuture {
+		if edit == nil {
+			err = obj.Exclude(rid)
+			return
+		}
+		var ov *events.Event
+		ov, err = obj.Override(rid)
+		if err != nil {
+			return
+		}
+		err = occurrenceEdit(*edit, uid).Apply(*ov)
+		return
+	}
+
+	newUID, err := uuid.NewRandom()
+	if err != nil {
+		return
+	}
+	split, err := obj.Split(rid, newUID.String())
+	if err != nil {
+		return
+	}
+	if edit == nil {
+		// the following occurrences are dropped with the new series
+		return
+	}
+	from, err := split.Main.GetStart()
+	if err != nil {
+		return
+	}
+	err = occurrenceEdit(*edit, newUID.String()).Apply(split.Main)
+	if err != nil {
+		return
+	}
+	to, err := split.Main.GetStart()
+	if err != nil {
+		return
+	}
+	err = split.ShiftInstances(to.Stamp.Sub(from.Stamp))
+	if err != nil {
+		return
+	}
+	next = &split
+	return
+}
+
+func editOccurrenceCmdExec(ctx context.Context, call *nu.ExecCommand) (err error) {
+	defer func() {
+		res := recover()
+		if res != nil {
+			err = fmt.Errorf("Panic: %v\n%s", res, string(debug.Stack()))
+		}
+	}()
+
+	currentTime := time.Now()
+
+	objpath, err := tryCast[string](call.Positional[0])
+	if err != nil {
+		return
+	}
+	if isSubscriptionURL(objpath) {
+		return fmt.Errorf("cannot edit %q, subscriptions are read-only", objpath)
+	}
+	rid, err := tryCast[time.Time](call.Positional[1])
+	if err != nil {
+		return
+	}
+	thisAndFuture := false
+	v, ok := call.FlagValue("this-and-future")
+	if ok {
+		thisAndFuture = v.Value.(bool)
+	}
+	del := false
+	v, ok = call.FlagValue("delete")
+	if ok {
+		del = v.Value.(bool)
+	}
+	offline := false
+	v, ok = call.FlagValue("offline")
+	if ok {
+		offline = v.Value.(bool)
+	}
+
+	var edit *dto.Event
+	if !del {
+		input, ok := call.Input.(nu.Value)
+		if !ok {
+			return fmt.Errorf("expected the edited event as input, got %T (use --delete to delete the occurrence)", call.Input)
+		}
+		var e dto.Event
+		e, err = nuconv.EventFromNu(input)
+		if err != nil {
+			return
+		}
+		edit = &e
+	}
+
+	subctx := saveEventCtx{
+		ctx:          ctx,
+		calendarPath: path.Dir(objpath) + "/",
+	}
+	if offline {
+		subctx.driver, subctx.qry, err = db.Open(ctx)
+		if err != nil {
+			return
+		}
+		defer subctx.driver.Close()
+	} else {
+		subctx.client, err = getClient(ctx, call)
+		if err != nil {
+			return
+		}
+	}
+
+	objects, err := subctx.fetchObjects([]string{objpath})
+	if err != nil {
+		return
+	}
+	if len(objects) == 0 {
+		return fmt.Errorf("event object %q not found", objpath)
+	}
+	obj := readEventObject(objects[0])
+
+	next, err := editOccurrence(&obj, rid, edit, thisAndFuture)
+	if err != nil {
+		return fmt.Errorf("edit occurrence %v of %q: %w", rid, objpath, err)
+	}
+	putObjects := []events.EventObject{obj}
+	resultPath := objpath
+	if next != nil {
+		putObjects = append(putObjects, *next)
+		resultPath, err = resolveObjectPath(subctx.calendarPath, *next)
+		if err != nil {
+			return
+		}
+	}
+
+	err = putEventObjects(subctx, putObjects, 1, currentTime)
+	if err != nil {
+		return
+	}
+	err = call.ReturnValue(ctx, nu.ToValue(resultPath))
+	return
+}
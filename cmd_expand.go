@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/LQR471814/nu_plugin_caldav/internal/dto"
+	"github.com/LQR471814/nu_plugin_caldav/internal/nuconv"
+	"github.com/ainvaltin/nu-plugin"
+	"github.com/ainvaltin/nu-plugin/syntaxshape"
+	"github.com/ainvaltin/nu-plugin/types"
+)
+
+var expandCmd = &nu.Command{
+	Signature: nu.PluginSignature{
+		Name:        "caldav expand",
+		Desc:        "Expands event objects into one row per occurrence.",
+		SearchTerms: []string{"caldav", "expand", "occurrence", "recurrence"},
+		Category:    "Viewers",
+		Named: []nu.Flag{
+			{
+				Long:  "start",
+				Short: 's',
+				Desc:  "Only include occurrences that end after this time.",
+				Shape: syntaxshape.DateTime(),
+			},
+			{
+				Long:  "end",
+				Short: 'e',
+				Desc:  "Only include occurrences that start before this time.",
+				Shape: syntaxshape.DateTime(),
+			},
+			todosFlag,
+			journalsFlag,
+			floatingTimezoneFlag,
+		},
+		InputOutputTypes: []nu.InOutTypes{
+			{
+				// TODO: fix typing later
+				// since some fields may be omitted and nushell does not yet
+				// support optional typing
+				In:  types.Any(),
+				Out: nuconv.OccurrenceListType,
+			},
+		},
+	},
+	OnRun: expandCmdExec,
+}
+
+func init() {
+	commands = append(commands, expandCmd)
+}
+
+func expandCmdExec(ctx context.Context, call *nu.ExecCommand) (err error) {
+	start, ok := call.Named["start"].Value.(time.Time)
+	if !ok {
+		err = fmt.Errorf("must specify -start")
+		return
+	}
+	end, ok := call.Named["end"].Value.(time.Time)
+	if !ok {
+		err = fmt.Errorf("must specify -end")
+		return
+	}
+
+	floating, err := timezoneFlag(call)
+	if err != nil {
+		return
+	}
+
+	objects, err := recvListInput(call, nuconv.EventObjectFromNu)
+	if err != nil {
+		return
+	}
+
+	objects = filterKinds(objects, kindsFlags(call)...)
+	var occurrences dto.OccurrenceList
+	for i, obj := range objects {
+		obj = anchorObject(obj, floating)
+		var expanded []dto.Occurrence
+		expanded, err = expandObject(obj, start, end)
+		if err != nil {
+			err = fmt.Errorf("expand event object %d: %w", i, err)
+			return
+		}
+		occurrences = append(occurrences, expanded...)
+	}
+	slices.SortStableFunc(occurrences, func(a, b dto.Occurrence) int {
+		return a.Start.Compare(b.Start)
+	})
+
+	out, err := nuconv.OccurrenceListToNu(occurrences)
+	if err != nil {
+		return
+	}
+	err = call.ReturnValue(ctx, out)
+	return
+}
@@ -0,0 +1,379 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/LQR471814/nu_plugin_caldav/internal/dto"
+	"github.com/LQR471814/nu_plugin_caldav/internal/events"
+	"github.com/LQR471814/nu_plugin_caldav/internal/nuconv"
+	"github.com/ainvaltin/nu-plugin"
+	"github.com/ainvaltin/nu-plugin/syntaxshape"
+	"github.com/ainvaltin/nu-plugin/types"
+	"github.com/emersion/go-ical"
+)
+
+var freeCmd = &nu.Command{
+	Signature: nu.PluginSignature{
+		Name:        "caldav free",
+		Desc:        "Finds the free slots between the events of one or more calendars.",
+		SearchTerms: []string{"caldav", "free", "busy", "availability", "slot", "gap"},
+		Category:    "Viewers",
+		Named: []nu.Flag{
+			{
+				Long:  "start",
+				Short: 's',
+				Desc:  "Only find free slots after this time.",
+				Shape: syntaxshape.DateTime(),
+			},
+			{
+				Long:  "end",
+				Short: 'e',
+				Desc:  "Only find free slots before this time.",
+				Shape: syntaxshape.DateTime(),
+			},
+			{
+				Long:  "min-duration",
+				Short: 'm',
+				Desc:  "Only return free slots lasting at least this long.",
+				Shape: syntaxshape.Duration(),
+			},
+			{
+				Long:  "buffer",
+				Short: 'b',
+				Desc:  "Time to keep free before and after each busy event.",
+				Shape: syntaxshape.Duration(),
+			},
+			{
+				Long:  "working-hours",
+				Short: 'w',
+				Desc:  "Only find free slots within these hours, a record of weekdays to time ranges (ex. {mon: \"09:00-17:00\", fri: [\"09:00-12:00\" \"13:00-15:00\"]}) in the --timezone, days that are not listed are skipped.",
+				Shape: syntaxshape.Any(),
+			},
+			floatingTimezoneFlag,
+		},
+		InputOutputTypes: []nu.InOutTypes{
+			{
+				// TODO: fix typing later
+				// since some fields may be omitted and nushell does not yet
+				// support optional typing
+				In:  types.Any(),
+				Out: nuconv.FreeSlotListType,
+			},
+		},
+	},
+	OnRun: freeCmdExec,
+}
+
+func init() {
+	commands = append(commands, freeCmd)
+}
+
+// isBusy reports whether an event blocks time, transparent and cancelled
+// events do not, nor do todos and journals.
+func isBusy(e dto.Event) bool {
+	if e.Kind != "" && e.Kind != ical.CompEvent {
+		return false
+	}
+	if e.Transparency != nil && *e.Transparency == events.EVENT_TRANSPARENCY_TRANSPARENT {
+		return false
+	}
+	if e.Status != nil && *e.Status == events.EVENT_STATUS_CANCELLED {
+		return false
+	}
+	return true
+}
+
+// span is a half-open range of time.
+type span struct {
+	start time.Time
+	end   time.Time
+}
+
+// clockRange is a range of the time of day, as offsets from midnight.
+type clockRange struct {
+	from time.Duration
+	to   time.Duration
+}
+
+// workingHours are the clock ranges of each weekday, a nil workingHours does
+// not restrict the time of day.
+type workingHours map[time.Weekday][]clockRange
+
+var weekdayNames = map[string]time.Weekday{
+	"sun": time.Sunday, "sunday": time.Sunday,
+	"mon": time.Monday, "monday": time.Monday,
+	"tue": time.Tuesday, "tuesday": time.Tuesday,
+	"wed": time.Wednesday, "wednesday": time.Wednesday,
+	"thu": time.Thursday, "thursday": time.Thursday,
+	"fri": time.Friday, "friday": time.Friday,
+	"sat": time.Saturday, "saturday": time.Saturday,
+}
+
+// parseClock parses a time of day formatted as HH:MM, 24:00 is the end of the
+// day.
+func parseClock(s string) (time.Duration, error) {
+	var h, m int
+	_, err := fmt.Sscanf(s, "%d:%d", &h, &m)
+	if err != nil || h < 0 || m < 0 || m > 59 || h > 24 || (h == 24 && m > 0) {
+		return 0, fmt.Errorf("invalid time of day %q, expected HH:MM", s)
+	}
+	return time.Duration(h)*time.Hour + time.Duration(m)*time.Minute, nil
+}
+
+// parseClockRange parses a range of the time of day formatted as HH:MM-HH:MM.
+func parseClockRange(s string) (r clockRange, err error) {
+	from, to, ok := strings.Cut(strings.TrimSpace(s), "-")
+	if !ok {
+		err = fmt.Errorf("invalid time range %q, expected HH:MM-HH:MM", s)
+		return
+	}
+	r.from, err = parseClock(strings.TrimSpace(from))
+	if err != nil {
+		return
+	}
+	r.to, err = parseClock(strings.TrimSpace(to))
+	if err != nil {
+		return
+	}
+	if r.to <= r.from {
+		err = fmt.Errorf("invalid time range %q, it must end after it starts", s)
+	}
+	return
+}
+
+// parseWorkingHours parses the value of the --working-hours flag.
+func parseWorkingHours(v nu.Value) (hours workingHours, err error) {
+	rec, ok := v.Value.(nu.Record)
+	if !ok {
+		err = fmt.Errorf("expected a record of weekdays to time ranges, got %T", v.Value)
+		return
+	}
+	hours = workingHours{}
+	for name, ranges := range rec {
+		day, ok := weekdayNames[strings.ToLower(name)]
+		if !ok {
+			err = fmt.Errorf("unknown weekday %q", name)
+			return
+		}
+		var list []nu.Value
+		switch typed := ranges.Value.(type) {
+		case string:
+			list = []nu.Value{ranges}
+		case []nu.Value:
+			list = typed
+		default:
+			err = fmt.Errorf("%s: expected a time range or a list of time ranges, got %T", name, ranges.Value)
+			return
+		}
+		for _, item := range list {
+			var s string
+			s, err = tryCast[string](item)
+			if err != nil {
+				err = fmt.Errorf("%s: %w", name, err)
+				return
+			}
+			var r clockRange
+			r, err = parseClockRange(s)
+			if err != nil {
+				err = fmt.Errorf("%s: %w", name, err)
+				return
+			}
+			hours[day] = append(hours[day], r)
+		}
+	}
+	return
+}
+
+// windows returns the spans within [start, end] that are in the working
+// hours, in order.
+func (hours workingHours) windows(start, end time.Time, loc *time.Location) (out []span) {
+	if hours == nil {
+		return []span{{start, end}}
+	}
+	s := start.In(loc)
+	day := time.Date(s.Year(), s.Month(), s.Day(), 0, 0, 0, 0, loc)
+	for ; day.Before(end); day = day.AddDate(0, 0, 1) {
+		for _, r := range hours[day.Weekday()] {
+			from := clockTime(day, r.from, loc)
+			to := clockTime(day, r.to, loc)
+			if from.Before(start) {
+				from = start
+			}
+			if to.After(end) {
+				to = end
+			}
+			if from.Before(to) {
+				out = append(out, span{from, to})
+			}
+		}
+	}
+	// ranges that touch across midnight form a single window
+	return mergeSpans(out)
+}
+
+// clockTime returns the time at an offset from the midnight of a day, as a
+// wall clock time so that working hours do not move on DST transitions.
+func clockTime(day time.Time, offset time.Duration, loc *time.Location) time.Time {
+	return time.Date(
+		day.Year(), day.Month(), day.Day(),
+		int(offset/time.Hour), int(offset%time.Hour/time.Minute), 0, 0,
+		loc,
+	)
+}
+
+// mergeSpans sorts spans and merges the ones that overlap or touch.
+func mergeSpans(spans []span) (out []span) {
+	slices.SortFunc(spans, func(a, b span) int {
+		return a.start.Compare(b.start)
+	})
+	for _, s := range spans {
+		if len(out) > 0 && !s.start.After(out[len(out)-1].end) {
+			if s.end.After(out[len(out)-1].end) {
+				out[len(out)-1].end = s.end
+			}
+			continue
+		}
+		out = append(out, s)
+	}
+	return
+}
+
+// freeOptions are the constraints on free slots.
+type freeOptions struct {
+	minDuration time.Duration
+	buffer      time.Duration
+	hours       workingHours
+	loc         *time.Location
+}
+
+// busySpans returns the merged spans of the busy events of a source that
+// overlap [start, end], widened by the buffer. Problems with the events are
+// returned as warnings.
+func busySpans(src eventSource, start, end time.Time, buffer time.Duration) (out []span, warnings []string) {
+	for {
+		warnings = append(warnings, src.warnings()...)
+		e, ok := src.peek()
+		if !ok {
+			return
+		}
+		src.pop()
+		s := e.Start.Stamp.Add(-buffer)
+		if !s.Before(end) {
+			// the source is ordered by start, so are the following events
+			warnings = append(warnings, src.warnings()...)
+			return
+		}
+		if !isBusy(e) {
+			continue
+		}
+		b := span{start: s, end: e.End.Stamp.Add(buffer)}
+		if !b.end.After(start) {
+			continue
+		}
+		if len(out) > 0 && !b.start.After(out[len(out)-1].end) {
+			if b.end.After(out[len(out)-1].end) {
+				out[len(out)-1].end = b.end
+			}
+			continue
+		}
+		out = append(out, b)
+	}
+}
+
+// findFreeSlots returns the free slots between the busy events of a source,
+// in order of their start.
+func findFreeSlots(src eventSource, start, end time.Time, opts freeOptions) (slots []dto.FreeSlot, warnings []string) {
+	busy, warnings := busySpans(src, start, end, opts.buffer)
+	b := 0
+	for _, w := range opts.hours.windows(start, end, opts.loc) {
+		t := w.start
+		for t.Before(w.end) {
+			// skip busy spans that end before t
+			for b < len(busy) && !busy[b].end.After(t) {
+				b++
+			}
+			slotEnd := w.end
+			if b < len(busy) && busy[b].start.Before(slotEnd) {
+				slotEnd = busy[b].start
+			}
+			if slotEnd.After(t) && slotEnd.Sub(t) >= opts.minDuration {
+				slots = append(slots, dto.FreeSlot{
+					Start:    t,
+					End:      slotEnd,
+					Duration: slotEnd.Sub(t),
+				})
+			}
+			if b >= len(busy) || !busy[b].start.Before(w.end) {
+				break
+			}
+			t = busy[b].end
+		}
+	}
+	return
+}
+
+func freeCmdExec(ctx context.Context, call *nu.ExecCommand) (err error) {
+	start, ok := call.Named["start"].Value.(time.Time)
+	if !ok {
+		err = fmt.Errorf("must specify -start")
+		return
+	}
+	end, ok := call.Named["end"].Value.(time.Time)
+	if !ok {
+		err = fmt.Errorf("must specify -end")
+		return
+	}
+	if end.Before(start) {
+		err = fmt.Errorf("end %v cannot be before start %v", end, start)
+		return
+	}
+
+	floating, err := timezoneFlag(call)
+	if err != nil {
+		return
+	}
+	opts := freeOptions{loc: floating}
+	opts.minDuration, err = durationFlag(call, "min-duration", 0)
+	if err != nil {
+		return
+	}
+	opts.buffer, err = durationFlag(call, "buffer", 0)
+	if err != nil {
+		return
+	}
+	if v, ok := call.FlagValue("working-hours"); ok {
+		opts.hours, err = parseWorkingHours(v)
+		if err != nil {
+			err = fmt.Errorf("--working-hours: %w", err)
+			return
+		}
+	}
+
+	objects, err := recvListInput(call, nuconv.EventObjectFromNu)
+	if err != nil {
+		return
+	}
+	objects = filterKinds(objects, ical.CompEvent)
+	for i, obj := range objects {
+		objects[i] = anchorObject(obj, floating)
+	}
+
+	// the buffer of events just outside of the window can still overlap it
+	src := newHeapSource(objects, start.Add(-opts.buffer), end.Add(opts.buffer))
+	slots, warnings := findFreeSlots(src, start, end, opts)
+	for _, w := range warnings {
+		slog.Warn("free slots", "warning", w)
+	}
+
+	out, err := nuconv.FreeSlotListToNu(slots)
+	if err != nil {
+		return
+	}
+	err = call.ReturnValue(ctx, out)
+	return
+}
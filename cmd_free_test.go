@@ -0,0 +1,93 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/LQR471814/nu_plugin_caldav/internal/dto"
+	"github.com/LQR471814/nu_plugin_caldav/internal/events"
+	"github.com/ainvaltin/nu-plugin"
+)
+
+func TestFindFreeSlots(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// a monday
+	day := time.Date(2025, 10, 20, 0, 0, 0, 0, ny)
+	at := func(days, h, m int) time.Time {
+		return time.Date(2025, 10, 20+days, h, m, 0, 0, ny)
+	}
+	transparent := events.EVENT_TRANSPARENCY_TRANSPARENT
+	cancelled := events.EVENT_STATUS_CANCELLED
+	event := func(start, end time.Time) dto.Event {
+		return dto.Event{
+			Start: events.Datetime{Stamp: start},
+			End:   events.Datetime{Stamp: end},
+		}
+	}
+	eventList := []dto.Event{
+		// started the day before and still running
+		event(at(-1, 22, 0), at(0, 9, 30)),
+		event(at(0, 11, 0), at(0, 12, 0)),
+		event(at(0, 11, 30), at(0, 12, 15)),
+		event(at(0, 12, 0), at(0, 11, 0)),
+		event(at(0, 13, 0), at(0, 14, 0)),
+		event(at(0, 15, 0), at(0, 16, 0)),
+		event(at(1, 10, 0), at(1, 11, 0)),
+	}
+	eventList[4].Transparency = &transparent
+	eventList[5].Status = &cancelled
+	src := &sliceSource{events: eventList}
+
+	hours, err := parseWorkingHours(nu.ToValue(nu.Record{
+		"mon": nu.ToValue("09:00-17:00"),
+		"Tuesday": nu.ToValue([]nu.Value{
+			nu.ToValue("09:00-10:30"),
+			nu.ToValue("13:00-14:00"),
+		}),
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	slots, warnings := findFreeSlots(src, day, day.AddDate(0, 0, 7), freeOptions{
+		minDuration: 45 * time.Minute,
+		buffer:      15 * time.Minute,
+		hours:       hours,
+		loc:         ny,
+	})
+	if len(warnings) != 1 {
+		t.Fatalf("expected a warning for the invalid event, got %v", warnings)
+	}
+
+	expected := []span{
+		{at(0, 9, 45), at(0, 10, 45)},
+		{at(0, 12, 30), at(0, 17, 0)},
+		{at(1, 9, 0), at(1, 9, 45)},
+		{at(1, 13, 0), at(1, 14, 0)},
+	}
+	if len(slots) != len(expected) {
+		t.Fatalf("expected %d slots, got %+v", len(expected), slots)
+	}
+	for i, s := range expected {
+		if !slots[i].Start.Equal(s.start) || !slots[i].End.Equal(s.end) ||
+			slots[i].Duration != s.end.Sub(s.start) {
+			t.Fatalf("slot %d: expected %v - %v, got %+v", i, s.start, s.end, slots[i])
+		}
+	}
+}
+
+func TestParseWorkingHoursErrors(t *testing.T) {
+	for _, rec := range []nu.Record{
+		{"someday": nu.ToValue("09:00-17:00")},
+		{"mon": nu.ToValue("17:00-09:00")},
+		{"mon": nu.ToValue("9am-5pm")},
+		{"mon": nu.ToValue("09:00-25:00")},
+		{"mon": nu.ToValue(9)},
+	} {
+		if _, err := parseWorkingHours(nu.ToValue(rec)); err == nil {
+			t.Fatalf("expected an error for %v", rec)
+		}
+	}
+}
@@ -0,0 +1,254 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/LQR471814/nu_plugin_caldav/internal/dto"
+	"github.com/LQR471814/nu_plugin_caldav/internal/nuconv"
+	"github.com/ainvaltin/nu-plugin"
+	"github.com/ainvaltin/nu-plugin/syntaxshape"
+	"github.com/ainvaltin/nu-plugin/types"
+	"github.com/emersion/go-ical"
+)
+
+var gridCmd = &nu.Command{
+	Signature: nu.PluginSignature{
+		Name:        "caldav grid",
+		Desc:        "Renders the events of a week (or month) as a text calendar grid.",
+		SearchTerms: []string{"caldav", "grid", "calendar", "week", "month"},
+		Category:    "Viewers",
+		Named: []nu.Flag{
+			{
+				Long:    "week",
+				Short:   'w',
+				Default: &falseNu,
+				Desc:    "Render the week (starting on monday) of --date, this is the default.",
+			},
+			{
+				Long:    "month",
+				Short:   'm',
+				Default: &falseNu,
+				Desc:    "Render the month of --date.",
+			},
+			{
+				Long:  "date",
+				Short: 'd',
+				Desc:  "A time in the week (or month) to render, defaults to now.",
+				Shape: syntaxshape.DateTime(),
+			},
+			displayTimezoneFlag,
+		},
+		InputOutputTypes: []nu.InOutTypes{
+			{
+				// TODO: fix typing later
+				// since some fields may be omitted and nushell does not yet
+				// support optional typing
+				In:  types.Any(),
+				Out: types.String(),
+			},
+		},
+	},
+	OnRun: gridCmdExec,
+}
+
+func init() {
+	commands = append(commands, gridCmd)
+}
+
+const (
+	weekCellWidth  = 20
+	monthCellWidth = 14
+)
+
+// fitCell truncates (or pads) a line to the width of a cell.
+func fitCell(s string, width int) string {
+	if utf8.RuneCountInString(s) > width {
+		return string([]rune(s)[:width-1]) + "…"
+	}
+	return s + strings.Repeat(" ", width-utf8.RuneCountInString(s))
+}
+
+// gridLabel returns the line of an agenda entry in a grid cell.
+func gridLabel(entry dto.AgendaEntry) string {
+	summary := "(no summary)"
+	if entry.Summary != nil {
+		summary = *entry.Summary
+	}
+	if entry.Time == "all day" {
+		return "* " + summary
+	}
+	from, _, _ := strings.Cut(entry.Time, "-")
+	return from + " " + summary
+}
+
+// gridCell is the content of a cell of a grid.
+type gridCell struct {
+	header string
+	lines  []string
+}
+
+// renderGrid renders rows of cells as a text table.
+func renderGrid(rows [][]gridCell, width int) string {
+	var sb strings.Builder
+	border := func(columns int) {
+		for range columns {
+			sb.WriteString("+")
+			sb.WriteString(strings.Repeat("-", width+2))
+		}
+		sb.WriteString("+\n")
+	}
+	line := func(cells []string) {
+		for _, cell := range cells {
+			sb.WriteString("| ")
+			sb.WriteString(fitCell(cell, width))
+			sb.WriteString(" ")
+		}
+		sb.WriteString("|\n")
+	}
+
+	for i, row := range rows {
+		if i == 0 {
+			border(len(row))
+		}
+		headers := make([]string, len(row))
+		height := 0
+		for j, cell := range row {
+			headers[j] = cell.header
+			height = max(height, len(cell.lines))
+		}
+		line(headers)
+		if height > 0 {
+			border(len(row))
+		}
+		for k := range height {
+			cells := make([]string, len(row))
+			for j, cell := range row {
+				if k < len(cell.lines) {
+					cells[j] = cell.lines[k]
+				}
+			}
+			line(cells)
+		}
+		border(len(row))
+	}
+	return sb.String()
+}
+
+// gridDays returns the first day of the grid of a week (or month) and its
+// number of days, which is always a multiple of 7 as rows are weeks.
+func gridDays(date time.Time, month bool, loc *time.Location) (first time.Time, days int) {
+	if !month {
+		return periodStart(date, true, loc), 7
+	}
+	date = date.In(loc)
+	monthStart := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, loc)
+	monthEnd := monthStart.AddDate(0, 1, 0)
+	first = periodStart(monthStart, true, loc)
+	for day := first; day.Before(monthEnd); day = day.AddDate(0, 0, 7) {
+		days += 7
+	}
+	return
+}
+
+// buildGrid renders the week (or month) of a date with the days of an agenda.
+func buildGrid(agenda dto.Agenda, date time.Time, month bool, loc *time.Location) string {
+	byDay := map[string]dto.AgendaDay{}
+	for _, day := range agenda {
+		byDay[day.Date.Format(time.DateOnly)] = day
+	}
+
+	date = date.In(loc)
+	first, days := gridDays(date, month, loc)
+	width := weekCellWidth
+	var rows [][]gridCell
+	if month {
+		width = monthCellWidth
+		// the weekday names are the header of the first row
+		var names []gridCell
+		for i := range 7 {
+			names = append(names, gridCell{header: first.AddDate(0, 0, i).Format("Monday")})
+		}
+		rows = append(rows, names)
+	}
+
+	var row []gridCell
+	for i := range days {
+		day := first.AddDate(0, 0, i)
+		cell := gridCell{header: day.Format("Mon Jan 2")}
+		inMonth := !month || day.Month() == date.Month()
+		if month {
+			cell.header = ""
+			if inMonth {
+				cell.header = fmt.Sprint(day.Day())
+			}
+		}
+		if inMonth {
+			for _, entry := range byDay[day.Format(time.DateOnly)].Events {
+				cell.lines = append(cell.lines, gridLabel(entry))
+			}
+		}
+		row = append(row, cell)
+		if len(row) == 7 {
+			rows = append(rows, row)
+			row = nil
+		}
+	}
+
+	out := renderGrid(rows, width)
+	if month {
+		out = date.Format("January 2006") + "\n" + out
+	}
+	return out
+}
+
+func gridCmdExec(ctx context.Context, call *nu.ExecCommand) (err error) {
+	date := time.Now()
+	if v, ok := call.FlagValue("date"); ok {
+		date, err = tryCast[time.Time](v)
+		if err != nil {
+			return
+		}
+	}
+	week := false
+	v, ok := call.FlagValue("week")
+	if ok {
+		week = v.Value.(bool)
+	}
+	month := false
+	v, ok = call.FlagValue("month")
+	if ok {
+		month = v.Value.(bool)
+	}
+	if week && month {
+		return fmt.Errorf("cannot specify both --week and --month")
+	}
+
+	loc, err := timezoneFlag(call)
+	if err != nil {
+		return
+	}
+
+	objects, err := recvListInput(call, nuconv.EventObjectFromNu)
+	if err != nil {
+		return
+	}
+	objects = filterKinds(objects, ical.CompEvent)
+	for i, obj := range objects {
+		objects[i] = anchorObject(obj, loc)
+	}
+
+	first, days := gridDays(date, month, loc)
+	last := first.AddDate(0, 0, days)
+	agenda, warnings := buildAgenda(newHeapSource(objects, first, last), first, last, loc)
+	for _, w := range warnings {
+		slog.Warn("grid", "warning", w)
+	}
+
+	err = call.ReturnValue(ctx, nu.ToValue(buildGrid(agenda, date, month, loc)))
+	return
+}
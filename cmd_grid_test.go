@@ -0,0 +1,38 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBuildGrid(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	date := time.Date(2025, 10, 22, 12, 0, 0, 0, ny)
+
+	for _, month := range []bool{false, true} {
+		first, days := gridDays(date, month, ny)
+		last := first.AddDate(0, 0, days)
+		agenda, _ := buildAgenda(newHeapSource(agendaObjects(ny), first, last), first, last, ny)
+		grid := buildGrid(agenda, date, month, ny)
+
+		for _, s := range []string{"* Holiday", "09:00 Standup", "22:00 Flight", "... Flight"} {
+			if !strings.Contains(grid, s) {
+				t.Fatalf("expected %q in the grid:\n%s", s, grid)
+			}
+		}
+		if strings.Contains(grid, "Next we") != month {
+			t.Fatalf("unexpected events of the next week in the grid:\n%s", grid)
+		}
+		lines := strings.Split(strings.TrimSpace(grid), "\n")
+		width := len([]rune(lines[len(lines)-1]))
+		for _, line := range lines {
+			if len([]rune(line)) != width && !strings.HasPrefix(line, "October") {
+				t.Fatalf("misaligned grid:\n%s", grid)
+			}
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/LQR471814/nu_plugin_caldav/internal/db"
 	"github.com/ainvaltin/nu-plugin"
+	"github.com/ainvaltin/nu-plugin/syntaxshape"
 	"github.com/ainvaltin/nu-plugin/types"
 )
 
@@ -14,6 +15,14 @@ var purgeCacheCmd = &nu.Command{
 		Category:    "Misc",
 		Desc:        "Completely clear cached events, calendars, and plugin state.",
 		SearchTerms: []string{"caldav", "cache", "clear", "purge"},
+		Named: []nu.Flag{
+			{
+				Long:  "calendar",
+				Short: 'c',
+				Shape: syntaxshape.String(),
+				Desc:  "Only clear the cached events and sync token of the given calendar, writes made with --offline are kept.",
+			},
+		},
 		InputOutputTypes: []nu.InOutTypes{
 			{
 				In:  types.Nothing(),
@@ -28,6 +37,61 @@ func init() {
 	commands = append(commands, purgeCacheCmd)
 }
 
+// purgeCalendar removes a single calendar from the cache, the next query of
+// the calendar will perform a full sync. Pending operations on the calendar
+// are removed as well unless keepPending is set.
+func purgeCalendar(ctx context.Context, calendarPath string, keepPending bool) (err error) {
+	driver, qry, err := db.Open(ctx)
+	if err != nil {
+		return
+	}
+	defer driver.Close()
+
+	tx, err := driver.BeginTx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer tx.Rollback()
+	txqry := qry.WithTx(tx)
+
+	err = txqry.DeleteCalendarEvents(ctx, calendarPath)
+	if err != nil {
+		return
+	}
+	err = txqry.DeleteCalendarSyncFailures(ctx, calendarPath)
+	if err != nil {
+		return
+	}
+	err = txqry.DeleteCalendar(ctx, calendarPath)
+	if err != nil {
+		return
+	}
+	// the feed of a subscription must be downloaded again on the next sync
+	err = txqry.PutSubscriptionValidators(ctx, db.PutSubscriptionValidatorsParams{
+		Url: calendarPath,
+	})
+	if err != nil {
+		return
+	}
+	if !keepPending {
+		err = txqry.DeleteCalendarPendingOperations(ctx, calendarPath)
+		if err != nil {
+			return
+		}
+	}
+	err = tx.Commit()
+	return
+}
+
 func purgeCacheCmdExec(ctx context.Context, call *nu.ExecCommand) (err error) {
+	v, ok := call.FlagValue("calendar")
+	if ok {
+		var calendarPath string
+		calendarPath, err = tryCast[string](v)
+		if err != nil {
+			return
+		}
+		return purgeCalendar(ctx, calendarPath, true)
+	}
 	return db.Purge()
 }
@@ -0,0 +1,195 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/LQR471814/nu_plugin_caldav/internal/dav"
+	"github.com/LQR471814/nu_plugin_caldav/internal/db"
+	"github.com/LQR471814/nu_plugin_caldav/internal/dto"
+	"github.com/LQR471814/nu_plugin_caldav/internal/nuconv"
+	"github.com/ainvaltin/nu-plugin"
+	"github.com/ainvaltin/nu-plugin/types"
+)
+
+var pushCmd = &nu.Command{
+	Signature: nu.PluginSignature{
+		Name:        "caldav push",
+		Category:    "Network",
+		Desc:        "Sends the writes made with --offline to the server.",
+		SearchTerms: []string{"caldav", "push", "offline", "sync", "pending"},
+		Named: []nu.Flag{
+			{
+				Long:    "force",
+				Short:   'f',
+				Default: &falseNu,
+				Desc:    "Overwrite changes made on the server since the writes were made instead of reporting them as conflicts.",
+			},
+		},
+		InputOutputTypes: []nu.InOutTypes{
+			{
+				In:  types.Nothing(),
+				Out: nuconv.PushOutcomeListType,
+			},
+		},
+	},
+	OnRun: pushCmdExec,
+}
+
+func init() {
+	commands = append(commands, pushCmd)
+}
+
+const (
+	outcomePushed   = "pushed"
+	outcomeConflict = "conflict"
+	outcomeFailed   = "failed"
+	outcomeSkipped  = "skipped"
+)
+
+// pendingPrecondition returns the precondition that makes sure the object on
+// the server has not changed since the operation was made.
+func pendingPrecondition(op db.PendingOperation) dav.Precondition {
+	if op.Etag.Valid {
+		return dav.Precondition{IfMatch: op.Etag.String}
+	}
+	if op.Kind == pendingPut {
+		return dav.Precondition{IfNoneMatch: true}
+	}
+	return dav.Precondition{}
+}
+
+// pushOperation replays a single pending operation and updates the cache to
+// reflect it, the cached object and the removal of the pending operation are
+// written in a single transaction so they cannot get out of sync.
+func pushOperation(ctx context.Context, client *dav.Client, driver *sql.DB, qry *db.Queries, op db.PendingOperation, force bool) (err error) {
+	var cond dav.Precondition
+	if !force {
+		cond = pendingPrecondition(op)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	var update func(txqry *db.Queries) error
+	switch op.Kind {
+	case pendingPut:
+		var etag string
+		etag, err = client.Put(ctx, op.ObjectPath, []byte(op.Ics.String), cond)
+		if err != nil {
+			return
+		}
+		update = func(txqry *db.Queries) error {
+			return txqry.PutEvent(ctx, db.PutEventParams{
+				Path:         op.ObjectPath,
+				CalendarPath: op.CalendarPath,
+				Etag:         sql.NullString{String: etag, Valid: etag != ""},
+				Ics:          op.Ics.String,
+			})
+		}
+	case pendingDelete:
+		err = client.Delete(ctx, op.ObjectPath, cond)
+		if err != nil {
+			return
+		}
+		update = func(txqry *db.Queries) error {
+			return txqry.DeleteEvents(ctx, []string{op.ObjectPath})
+		}
+	default:
+		err = fmt.Errorf("unknown pending operation kind %q", op.Kind)
+		return
+	}
+
+	tx, err := driver.BeginTx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer tx.Rollback()
+	txqry := qry.WithTx(tx)
+	err = update(txqry)
+	if err != nil {
+		return fmt.Errorf("update cache after push: %w", err)
+	}
+	err = txqry.DeletePendingOperation(ctx, op.ID)
+	if err != nil {
+		return fmt.Errorf("update cache after push: %w", err)
+	}
+	return tx.Commit()
+}
+
+// pushOperations replays the pending operations in order. Conflicts only
+// concern their own object, but the operations after any other failure are
+// skipped (and kept) so that they are not replayed out of order.
+func pushOperations(ctx context.Context, client *dav.Client, driver *sql.DB, qry *db.Queries, ops []db.PendingOperation, force bool) (outcomes dto.PushOutcomeList, err error) {
+	outcomes = make(dto.PushOutcomeList, len(ops))
+	failed := false
+	for i, op := range ops {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		outcomes[i] = dto.PushOutcome{
+			Operation:    op.Kind,
+			CalendarPath: op.CalendarPath,
+			ObjectPath:   op.ObjectPath,
+			QueuedAt:     time.Unix(op.CreatedAt, 0),
+			Outcome:      outcomePushed,
+		}
+		if failed {
+			msg := "not pushed after an earlier failure"
+			outcomes[i].Error = &msg
+			outcomes[i].Outcome = outcomeSkipped
+			continue
+		}
+		pushErr := pushOperation(ctx, client, driver, qry, op, force)
+		if pushErr == nil {
+			continue
+		}
+		msg := pushErr.Error()
+		outcomes[i].Error = &msg
+		outcomes[i].Outcome = outcomeFailed
+		if errors.Is(pushErr, dav.ErrPreconditionFailed) {
+			outcomes[i].Outcome = outcomeConflict
+			continue
+		}
+		failed = true
+	}
+	return
+}
+
+func pushCmdExec(ctx context.Context, call *nu.ExecCommand) (err error) {
+	force := false
+	v, ok := call.FlagValue("force")
+	if ok {
+		force = v.Value.(bool)
+	}
+
+	client, err := getDavClient(ctx, call)
+	if err != nil {
+		return
+	}
+	driver, qry, err := db.Open(ctx)
+	if err != nil {
+		return
+	}
+	defer driver.Close()
+
+	ops, err := qry.ReadPendingOperations(ctx)
+	if err != nil {
+		return
+	}
+
+	outcomes, err := pushOperations(ctx, client, driver, qry, ops, force)
+	if err != nil {
+		return
+	}
+
+	out, err := nuconv.PushOutcomeListToNu(outcomes)
+	if err != nil {
+		return
+	}
+	err = call.ReturnValue(ctx, out)
+	return
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+
+	"github.com/LQR471814/nu_plugin_caldav/internal/dto"
+	"github.com/LQR471814/nu_plugin_caldav/internal/nuconv"
+	"github.com/ainvaltin/nu-plugin"
+	"github.com/ainvaltin/nu-plugin/syntaxshape"
+	"github.com/ainvaltin/nu-plugin/types"
+)
+
+var queryAccessCmd = &nu.Command{
+	Signature: nu.PluginSignature{
+		Name:        "caldav query access",
+		Category:    "Network",
+		Desc:        "Lists who a calendar is shared with and the privileges granted by its ACL.",
+		SearchTerms: caldavKeywordsQuery("access", "acl", "sharing", "sharees", "privileges"),
+		RequiredPositional: []nu.PositionalArg{
+			{
+				Name:  "calendar_path",
+				Desc:  "The `path` attribute of the calendar record returned by `caldav query calendars`.",
+				Shape: syntaxshape.String(),
+			},
+		},
+		InputOutputTypes: []nu.InOutTypes{
+			{
+				In:  types.Nothing(),
+				Out: nuconv.AccessEntryListType,
+			},
+		},
+	},
+	OnRun: queryAccessCmdExec,
+}
+
+func init() {
+	commands = append(commands, queryAccessCmd)
+}
+
+func queryAccessCmdExec(ctx context.Context, call *nu.ExecCommand) (err error) {
+	calendarPath, err := tryCast[string](call.Positional[0])
+	if err != nil {
+		return
+	}
+	client, err := getDavClient(ctx, call)
+	if err != nil {
+		return
+	}
+	sharees, acl, err := client.FindAccess(ctx, calendarPath)
+	if err != nil {
+		return
+	}
+	out, err := nuconv.AccessEntryListToNu(dto.NewAccessEntryList(sharees, acl))
+	if err != nil {
+		return
+	}
+	err = call.ReturnValue(ctx, out)
+	return
+}
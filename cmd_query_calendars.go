@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 
+	"github.com/LQR471814/nu_plugin_caldav/internal/dto"
 	"github.com/LQR471814/nu_plugin_caldav/internal/nuconv"
 	"github.com/ainvaltin/nu-plugin"
 	"github.com/ainvaltin/nu-plugin/syntaxshape"
@@ -37,7 +38,7 @@ func init() {
 }
 
 func queryCalendarsCmdExec(ctx context.Context, call *nu.ExecCommand) (err error) {
-	client, err := getClient(ctx, call)
+	client, err := getDavClient(ctx, call)
 	if err != nil {
 		return
 	}
@@ -49,7 +50,7 @@ func queryCalendarsCmdExec(ctx context.Context, call *nu.ExecCommand) (err error
 	if err != nil {
 		return
 	}
-	out, err := nuconv.CalendarListToNu(calendars)
+	out, err := nuconv.CalendarListToNu(dto.NewCalendarList(calendars))
 	if err != nil {
 		return
 	}
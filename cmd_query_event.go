@@ -1,16 +1,18 @@
 package main
 
 import (
-	"bytes"
 	"context"
 	"database/sql"
-	"encoding/gob"
 	"errors"
 	"fmt"
 	"log/slog"
+	"net/http"
 	"runtime"
+	"slices"
 	"sync"
+	"time"
 
+	"github.com/LQR471814/nu_plugin_caldav/internal/dav"
 	"github.com/LQR471814/nu_plugin_caldav/internal/db"
 	"github.com/LQR471814/nu_plugin_caldav/internal/dto"
 	"github.com/LQR471814/nu_plugin_caldav/internal/nuconv"
@@ -27,7 +29,7 @@ var queryEventsCmd = &nu.Command{
 	Signature: nu.PluginSignature{
 		Name:        "caldav query events",
 		Category:    "Network",
-		Desc:        "Reads raw events objects from a given calendar.",
+		Desc:        "Reads raw events objects from the given calendars.",
 		SearchTerms: caldavKeywordsQuery("events"),
 		Named: []nu.Flag{
 			{
@@ -37,13 +39,31 @@ var queryEventsCmd = &nu.Command{
 				Shape:   syntaxshape.Boolean(),
 				Default: &default_nosync,
 			},
-		},
-		RequiredPositional: []nu.PositionalArg{
 			{
-				Name:  "calendar_path",
-				Desc:  "The `path` attribute of the calendar record returned by `caldav query calendars`.",
-				Shape: syntaxshape.String(),
+				Long:    "offline",
+				Short:   'o',
+				Desc:    "Read events from the cache without syncing, pending writes made with --offline are included.",
+				Shape:   syntaxshape.Boolean(),
+				Default: &falseNu,
+			},
+			{
+				Long:    "all",
+				Short:   'a',
+				Desc:    "Read events from all the calendars under the current user's homeset (or all cached calendars with --offline), cannot be combined with calendar paths.",
+				Default: &falseNu,
 			},
+			{
+				Long:    "parallel",
+				Short:   'p',
+				Default: &defaultParallelism,
+				Desc:    "Controls the amount of calendars that can be synced in parallel.",
+			},
+			floatingTimezoneFlag,
+		},
+		RestPositional: &nu.PositionalArg{
+			Name:  "calendar_paths",
+			Desc:  "The `path` attribute of the calendar records returned by `caldav query calendars`.",
+			Shape: syntaxshape.String(),
 		},
 		InputOutputTypes: []nu.InOutTypes{
 			{
@@ -59,26 +79,155 @@ func init() {
 	commands = append(commands, queryEventsCmd)
 }
 
-func queryEventsCmdExec(ctx context.Context, call *nu.ExecCommand) (err error) {
-	// parse flags
-	calendarPath, err := tryCast[string](call.Positional[0])
+// calendarIdentity identifies the calendar an event object was read from.
+type calendarIdentity struct {
+	path  string
+	name  *string
+	color *string
+}
+
+func newCalendarIdentity(path string, props dav.CalendarProperties) calendarIdentity {
+	id := calendarIdentity{path: path}
+	if props.DisplayName != "" {
+		id.name = &props.DisplayName
+	}
+	if props.Color != "" {
+		id.color = &props.Color
+	}
+	return id
+}
+
+// readCalendarIdentity reads the identity of a calendar as of its last sync.
+func readCalendarIdentity(ctx context.Context, qry *db.Queries, path string) (id calendarIdentity, err error) {
+	id = calendarIdentity{path: path}
+	props, err := qry.ReadCalendarProperties(ctx, path)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = nil
+		return
+	}
+	if err != nil {
+		return
+	}
+	if props.DisplayName.Valid {
+		id.name = &props.DisplayName.String
+	}
+	if props.Color.Valid {
+		id.color = &props.Color.String
+	}
+	return
+}
+
+func (id calendarIdentity) annotate(obj *dto.EventObject) {
+	obj.CalendarPath = &id.path
+	obj.CalendarName = id.name
+	obj.CalendarColor = id.color
+}
+
+// syncableCalendar reports whether a calendar can be synced, it must be able
+// to contain events, todos or journals.
+func syncableCalendar(cal dav.Calendar) bool {
+	// scheduling inboxes and subscriptions cannot be synced
+	if !slices.Contains(cal.ResourceTypes, "calendar") ||
+		slices.Contains(cal.ResourceTypes, "schedule-inbox") {
+		return false
+	}
+	// an empty component set means that all components are supported
+	return len(cal.SupportedComponents) == 0 ||
+		slices.ContainsFunc(cal.SupportedComponents, func(comp string) bool {
+			return comp == ical.CompEvent || comp == ical.CompToDo || comp == ical.CompJournal
+		})
+}
+
+// findEventCalendars finds the calendars that can contain events, todos or
+// journals under the current user's homeset.
+func findEventCalendars(ctx context.Context, client *caldav.Client, davClient *dav.Client) (paths []string, err error) {
+	principal, err := client.FindCurrentUserPrincipal(ctx)
+	if err != nil {
+		return
+	}
+	homeset, err := client.FindCalendarHomeSet(ctx, principal)
 	if err != nil {
 		return
 	}
+	calendars, err := davClient.FindCalendars(ctx, homeset)
+	if err != nil {
+		return
+	}
+	for _, cal := range calendars {
+		if syncableCalendar(cal) {
+			paths = append(paths, cal.Path)
+		}
+	}
+	return
+}
+
+// cachedCalendarPaths returns the paths of all the calendars in the cache.
+func cachedCalendarPaths(ctx context.Context, qry *db.Queries) (paths []string, err error) {
+	calendars, err := qry.ReadCachedCalendars(ctx)
+	if err != nil {
+		return
+	}
+	for _, cal := range calendars {
+		paths = append(paths, cal.Path)
+	}
+	return
+}
+
+type cachedEvent struct {
+	row      db.ReadEventsRow
+	calendar calendarIdentity
+}
+
+func queryEventsCmdExec(ctx context.Context, call *nu.ExecCommand) (err error) {
+	// parse flags
+	calendarPaths := make([]string, len(call.Positional))
+	for i, v := range call.Positional {
+		calendarPaths[i], err = tryCast[string](v)
+		if err != nil {
+			return
+		}
+	}
 	nosync := false
 	v, ok := call.FlagValue("no-sync")
 	if ok {
 		nosync = v.Value.(bool)
 	}
-
-	// execution
-	client, err := getClient(ctx, call)
+	offline := false
+	v, ok = call.FlagValue("offline")
+	if ok {
+		offline = v.Value.(bool)
+	}
+	all := false
+	v, ok = call.FlagValue("all")
+	if ok {
+		all = v.Value.(bool)
+	}
+	if all && len(calendarPaths) > 0 {
+		err = fmt.Errorf("cannot specify both --all and calendar paths")
+		return
+	}
+	parallel := defaultParallelism.Value.(int)
+	v, ok = call.FlagValue("parallel")
+	if ok {
+		parallel = v.Value.(int)
+	}
+	floating, err := timezoneFlag(call)
 	if err != nil {
 		return
 	}
 
-	if nosync {
-		return fetchNoSync(ctx, call, client, calendarPath)
+	// execution
+	var client *caldav.Client
+	var davClient *dav.Client
+	if !offline {
+		client, err = getClient(ctx, call)
+		if err != nil {
+			return
+		}
+		davClient, err = getDavClient(ctx, call)
+		if err != nil {
+			return
+		}
 	}
 
 	driver, qry, err := db.Open(ctx)
@@ -86,20 +235,52 @@ func queryEventsCmdExec(ctx context.Context, call *nu.ExecCommand) (err error) {
 		return
 	}
 	defer driver.Close()
-	m := syncManager{
-		ctx:          ctx,
-		client:       client,
-		driver:       driver,
-		qry:          qry,
-		calendarPath: calendarPath,
-	}
-	var warnings []error
-	warnings, err = m.sync()
-	if err != nil {
-		return
+
+	if all && !offline {
+		calendarPaths, err = findEventCalendars(ctx, client, davClient)
+		if err != nil {
+			return
+		}
+		var subscriptions []db.Subscription
+		subscriptions, err = qry.ReadSubscriptions(ctx)
+		if err != nil {
+			return
+		}
+		for _, sub := range subscriptions {
+			calendarPaths = append(calendarPaths, sub.Url)
+		}
+	}
+
+	if nosync && !offline {
+		if len(calendarPaths) == 0 {
+			return fmt.Errorf("no calendar paths given, pass them as arguments or use --all")
+		}
+		return fetchNoSync(ctx, call, client, davClient, calendarPaths, floating)
+	}
+
+	if all && offline {
+		calendarPaths, err = cachedCalendarPaths(ctx, qry)
+		if err != nil {
+			return
+		}
+	}
+	if len(calendarPaths) == 0 {
+		return fmt.Errorf("no calendar paths given, pass them as arguments or use --all")
+	}
+
+	if !offline {
+		err = syncCalendars(ctx, client, davClient, driver, qry, calendarPaths, parallel)
+		if err != nil {
+			return
+		}
 	}
-	for _, warning := range warnings {
-		warnEventParse(warning)
+
+	calendars := make([]calendarIdentity, len(calendarPaths))
+	for i, p := range calendarPaths {
+		calendars[i], err = readCalendarIdentity(ctx, qry, p)
+		if err != nil {
+			return
+		}
 	}
 
 	output, err := call.ReturnListStream(ctx)
@@ -111,7 +292,7 @@ func queryEventsCmdExec(ctx context.Context, call *nu.ExecCommand) (err error) {
 	workerCount := runtime.NumCPU()
 
 	errs := make(chan error)
-	events := make(chan db.ReadEventsRow, runtime.NumCPU())
+	events := make(chan cachedEvent, runtime.NumCPU())
 	wg := sync.WaitGroup{}
 
 	for range workerCount {
@@ -119,13 +300,17 @@ func queryEventsCmdExec(ctx context.Context, call *nu.ExecCommand) (err error) {
 		go func() { // process events concurrently and send them to output stream
 			defer wg.Done()
 			for e := range events {
-				var obj dto.EventObject
-				decoder := gob.NewDecoder(bytes.NewBuffer(e.Dto))
-				err = decoder.Decode(&obj)
+				cached, err := decodeCachedObject(e.row.Path, e.row.Etag, e.row.Ics)
 				if err != nil {
-					errs <- err
+					warnEventParse(eventParseWarning(e.row.Path, err))
+					continue
+				}
+				obj, err := dto.NewEventObject(cached, floating)
+				if err != nil {
+					warnEventParse(eventParseWarning(e.row.Path, err))
 					continue
 				}
+				e.calendar.annotate(&obj)
 				nuobj, err := nuconv.EventObjectToNu(obj)
 				if err != nil {
 					errs <- err
@@ -137,9 +322,18 @@ func queryEventsCmdExec(ctx context.Context, call *nu.ExecCommand) (err error) {
 	}
 
 	go func() { // pull events in from database and send them to be processed
-		err = qry.ReadEvents(ctx, calendarPath, events)
-		if err != nil {
-			errs <- err
+		for _, cal := range calendars {
+			rows := make(chan db.ReadEventsRow)
+			go func() {
+				err := qry.ReadEvents(ctx, cal.path, rows)
+				if err != nil {
+					errs <- err
+				}
+				close(rows)
+			}()
+			for row := range rows {
+				events <- cachedEvent{row: row, calendar: cal}
+			}
 		}
 		close(events)
 	}()
@@ -168,46 +362,120 @@ func warnEventParse(err error) {
 	slog.Warn("parse event failed", "err", err.Error())
 }
 
-func fetchNoSync(ctx context.Context, call *nu.ExecCommand, client *caldav.Client, calendarPath string) (err error) {
-	objects, err := client.QueryCalendar(ctx, calendarPath, &caldav.CalendarQuery{
-		CompRequest: caldav.CalendarCompRequest{
-			Name:     ical.CompEvent,
-			AllProps: true,
-		},
-	})
-	if err != nil {
-		return
-	}
+func fetchNoSync(ctx context.Context, call *nu.ExecCommand, client *caldav.Client, davClient *dav.Client, calendarPaths []string, floating *time.Location) (err error) {
 	output, err := call.ReturnListStream(ctx)
 	if err != nil {
 		return
 	}
 	defer close(output)
 
-	for _, obj := range objects {
-		dtoObj, err := dto.NewEventObject(obj)
-		if err != nil {
-			warnEventParse(eventParseWarning(obj.Path, err))
-			continue
+	feedClient := newFeedClient()
+	for _, calendarPath := range calendarPaths {
+		var calendar calendarIdentity
+		var objects []caldav.CalendarObject
+		if isSubscriptionURL(calendarPath) {
+			calendar, objects, err = fetchFeedObjects(ctx, feedClient, calendarPath)
+			if err != nil {
+				return
+			}
+		} else {
+			var props dav.CalendarProperties
+			props, err = davClient.FindCalendarProperties(ctx, calendarPath)
+			if err != nil {
+				return
+			}
+			calendar = newCalendarIdentity(calendarPath, props)
+
+			objects, err = client.QueryCalendar(ctx, calendarPath, &caldav.CalendarQuery{
+				CompRequest: caldav.CalendarCompRequest{
+					Name:     ical.CompEvent,
+					AllProps: true,
+				},
+			})
+			if err != nil {
+				return
+			}
 		}
-		nuobj, err := nuconv.EventObjectToNu(dtoObj)
-		if err != nil {
-			return fmt.Errorf("convert event object %q to nu: %w", obj.Path, err)
+
+		for _, obj := range objects {
+			dtoObj, err := dto.NewEventObject(obj, floating)
+			if err != nil {
+				warnEventParse(eventParseWarning(obj.Path, err))
+				continue
+			}
+			calendar.annotate(&dtoObj)
+			nuobj, err := nuconv.EventObjectToNu(dtoObj)
+			if err != nil {
+				return fmt.Errorf("convert event object %q to nu: %w", obj.Path, err)
+			}
+			output <- nuobj
 		}
-		output <- nuobj
 	}
 	return
 }
 
+// syncCalendars syncs the given calendars concurrently, the writes to the
+// cache are serialized as sqlite only allows a single writer.
+func syncCalendars(ctx context.Context, client *caldav.Client, davClient *dav.Client, driver *sql.DB, qry *db.Queries, calendarPaths []string, parallel int) (err error) {
+	writeMu := &sync.Mutex{}
+	sem := make(chan struct{}, max(parallel, 1))
+	errs := make([]error, len(calendarPaths))
+	wg := sync.WaitGroup{}
+	for i, calendarPath := range calendarPaths {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			m := syncManager{
+				ctx:          ctx,
+				client:       client,
+				davClient:    davClient,
+				feedClient:   newFeedClient(),
+				driver:       driver,
+				qry:          qry,
+				writeMu:      writeMu,
+				calendarPath: calendarPath,
+			}
+			err := m.sync()
+			if err != nil {
+				errs[i] = fmt.Errorf("sync calendar %q: %w", calendarPath, err)
+			}
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
 type syncManager struct {
 	ctx          context.Context
 	client       *caldav.Client
+	davClient    *dav.Client
+	feedClient   *http.Client
 	driver       *sql.DB
 	qry          *db.Queries
+	writeMu      *sync.Mutex
 	calendarPath string
 }
 
-func (m syncManager) performSync(txqry *db.Queries, syncToken string) (nextSyncToken string, warnings []error, err error) {
+// syncResult contains the changes made to a calendar on the server since the
+// last sync.
+type syncResult struct {
+	// replace is true if updated contains all the objects of the calendar
+	replace       bool
+	nextSyncToken string
+	deleted       []string
+	updated       []caldav.CalendarObject
+	props         dav.CalendarProperties
+}
+
+func (m syncManager) fetch(syncToken string) (result syncResult, err error) {
+	result.props, err = m.davClient.FindCalendarProperties(m.ctx, m.calendarPath)
+	if err != nil {
+		return
+	}
+
 	resp, err := m.client.SyncCollection(m.ctx, m.calendarPath, &caldav.SyncQuery{
 		SyncToken: syncToken,
 		CompRequest: caldav.CalendarCompRequest{
@@ -218,19 +486,9 @@ func (m syncManager) performSync(txqry *db.Queries, syncToken string) (nextSyncT
 	if err != nil {
 		return
 	}
-	nextSyncToken = resp.SyncToken
+	result.nextSyncToken = resp.SyncToken
+	result.deleted = resp.Deleted
 
-	// sync deletes
-	var deletePaths []string
-	for _, path := range resp.Deleted {
-		deletePaths = append(deletePaths, path)
-	}
-	err = txqry.DeleteEvents(m.ctx, deletePaths)
-	if err != nil {
-		return
-	}
-
-	// sync puts
 	var updatedPaths []string
 	for _, u := range resp.Updated {
 		updatedPaths = append(updatedPaths, u.Path)
@@ -239,77 +497,140 @@ func (m syncManager) performSync(txqry *db.Queries, syncToken string) (nextSyncT
 		return
 	}
 
-	updatedObjects, err := m.client.MultiGetCalendar(m.ctx, m.calendarPath, &caldav.CalendarMultiGet{
+	result.updated, err = m.client.MultiGetCalendar(m.ctx, m.calendarPath, &caldav.CalendarMultiGet{
 		Paths: updatedPaths,
 		CompRequest: caldav.CalendarCompRequest{
 			Name:     ical.CompEvent,
 			AllProps: true,
 		},
 	})
+	return
+}
+
+func (m syncManager) store(txqry *db.Queries, result syncResult) (err error) {
+	if result.replace {
+		err = txqry.DeleteCalendarEvents(m.ctx, m.calendarPath)
+		if err != nil {
+			return
+		}
+		err = txqry.DeleteCalendarSyncFailures(m.ctx, m.calendarPath)
+		if err != nil {
+			return
+		}
+	}
+
+	// sync deletes
+	err = txqry.DeleteEvents(m.ctx, result.deleted)
 	if err != nil {
 		return
 	}
-	var failedParsePaths []string
-	for _, obj := range updatedObjects {
-		buf := bytes.NewBuffer(nil)
-		encoder := gob.NewEncoder(buf)
-		var dtoObj dto.EventObject
-		dtoObj, err = dto.NewEventObject(obj)
-		if err != nil {
-			warnings = append(warnings, eventParseWarning(obj.Path, err))
-			failedParsePaths = append(failedParsePaths, obj.Path)
-			continue
-		}
-		err = encoder.Encode(dtoObj)
+	err = txqry.DeleteSyncFailures(m.ctx, result.deleted)
+	if err != nil {
+		return
+	}
+
+	// sync puts
+	// objects are stored even if they fail to parse, so that they can still be
+	// read by a later version of the plugin, the failures are recorded so that
+	// they can be inspected with `caldav query failures`
+	now := time.Now()
+	var parsedPaths []string
+	for _, obj := range result.updated {
+		var ics string
+		ics, err = encodeObjectData(obj.Data)
 		if err != nil {
+			err = fmt.Errorf("encode object %q: %w", obj.Path, err)
 			return
 		}
 		err = txqry.PutEvent(m.ctx, db.PutEventParams{
 			Path:         obj.Path,
 			CalendarPath: m.calendarPath,
-			Dto:          buf.Bytes(),
+			Etag: sql.NullString{
+				String: obj.ETag,
+				Valid:  obj.ETag != "",
+			},
+			Ics: ics,
 		})
 		if err != nil {
 			return
 		}
-	}
-	if len(failedParsePaths) > 0 {
-		err = txqry.DeleteEvents(m.ctx, failedParsePaths)
+
+		_, parseErr := dto.NewEventObject(obj, time.Local)
+		if parseErr == nil {
+			parsedPaths = append(parsedPaths, obj.Path)
+			continue
+		}
+		err = txqry.PutSyncFailure(m.ctx, db.PutSyncFailureParams{
+			Path:         obj.Path,
+			CalendarPath: m.calendarPath,
+			Error:        parseErr.Error(),
+			FailedAt:     now.Unix(),
+		})
 		if err != nil {
 			return
 		}
 	}
-	return
-}
+	err = txqry.DeleteSyncFailures(m.ctx, parsedPaths)
+	if err != nil {
+		return
+	}
 
-func (m syncManager) sync() (warnings []error, err error) {
-	tx, err := m.driver.BeginTx(m.ctx, nil)
+	err = txqry.PutCalendar(m.ctx, db.PutCalendarParams{
+		Path: m.calendarPath,
+		SyncToken: sql.NullString{
+			String: result.nextSyncToken,
+			Valid:  result.nextSyncToken != "",
+		},
+		LastSync: sql.NullInt64{
+			Int64: now.Unix(),
+			Valid: true,
+		},
+	})
 	if err != nil {
 		return
 	}
-	defer tx.Rollback()
-	txqry := m.qry.WithTx(tx)
+	err = txqry.PutCalendarProperties(m.ctx, db.PutCalendarPropertiesParams{
+		DisplayName: sql.NullString{
+			String: result.props.DisplayName,
+			Valid:  result.props.DisplayName != "",
+		},
+		Color: sql.NullString{
+			String: result.props.Color,
+			Valid:  result.props.Color != "",
+		},
+		Path: m.calendarPath,
+	})
+	return
+}
+
+func (m syncManager) sync() (err error) {
+	if isSubscriptionURL(m.calendarPath) {
+		return m.syncSubscription()
+	}
 
-	syncToken, err := txqry.ReadCalendar(m.ctx, m.calendarPath)
+	syncToken, err := m.qry.ReadCalendar(m.ctx, m.calendarPath)
 	if err != nil && !errors.Is(err, sql.ErrNoRows) {
 		return
 	}
-	var nextSyncToken string
-	nextSyncToken, warnings, err = m.performSync(txqry, syncToken.String)
+	// the requests are made outside of the transaction so that other
+	// calendars can be written to the cache in the meantime
+	result, err := m.fetch(syncToken.String)
 	if err != nil {
 		return
 	}
-	err = txqry.PutCalendar(m.ctx, db.PutCalendarParams{
-		Path: m.calendarPath,
-		SyncToken: sql.NullString{
-			String: nextSyncToken,
-			Valid:  true,
-		},
-	})
+
+	m.writeMu.Lock()
+	defer m.writeMu.Unlock()
+
+	tx, err := m.driver.BeginTx(m.ctx, nil)
 	if err != nil {
 		return
 	}
-
-	tx.Commit()
+	defer tx.Rollback()
+	err = m.store(m.qry.WithTx(tx), result)
+	if err != nil {
+		return
+	}
+	err = tx.Commit()
 	return
 }
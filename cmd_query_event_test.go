@@ -0,0 +1,28 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/LQR471814/nu_plugin_caldav/internal/dav"
+)
+
+func TestSyncableCalendar(t *testing.T) {
+	cases := []struct {
+		cal      dav.Calendar
+		expected bool
+	}{
+		{dav.Calendar{ResourceTypes: []string{"collection", "calendar"}}, true},
+		{dav.Calendar{ResourceTypes: []string{"collection", "calendar"}, SupportedComponents: []string{"VEVENT"}}, true},
+		// task lists
+		{dav.Calendar{ResourceTypes: []string{"collection", "calendar"}, SupportedComponents: []string{"VTODO"}}, true},
+		{dav.Calendar{ResourceTypes: []string{"collection", "calendar"}, SupportedComponents: []string{"VJOURNAL"}}, true},
+		{dav.Calendar{ResourceTypes: []string{"collection", "calendar"}, SupportedComponents: []string{"VAVAILABILITY"}}, false},
+		{dav.Calendar{ResourceTypes: []string{"collection", "calendar", "schedule-inbox"}}, false},
+		{dav.Calendar{ResourceTypes: []string{"collection"}}, false},
+	}
+	for _, c := range cases {
+		if syncableCalendar(c.cal) != c.expected {
+			t.Errorf("%v %v: expected %v", c.cal.ResourceTypes, c.cal.SupportedComponents, c.expected)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/LQR471814/nu_plugin_caldav/internal/db"
+	"github.com/LQR471814/nu_plugin_caldav/internal/dto"
+	"github.com/LQR471814/nu_plugin_caldav/internal/nuconv"
+	"github.com/ainvaltin/nu-plugin"
+	"github.com/ainvaltin/nu-plugin/types"
+)
+
+var queryFailuresCmd = &nu.Command{
+	Signature: nu.PluginSignature{
+		Name:        "caldav query failures",
+		Category:    "Misc",
+		Desc:        "Lists the objects that could not be parsed as events while syncing, these are not returned by `caldav query events`.",
+		SearchTerms: []string{"caldav", "query", "failures", "parse", "errors", "sync"},
+		Named: []nu.Flag{
+			{
+				Long:    "retry",
+				Short:   'r',
+				Default: &falseNu,
+				Desc:    "Parse the objects again (for example after upgrading the plugin), objects that parse successfully are no longer listed.",
+			},
+		},
+		InputOutputTypes: []nu.InOutTypes{
+			{
+				In:  types.Nothing(),
+				Out: nuconv.SyncFailureListType,
+			},
+		},
+	},
+	OnRun: queryFailuresCmdExec,
+}
+
+func init() {
+	commands = append(commands, queryFailuresCmd)
+}
+
+// retrySyncFailure parses a failed object again, removing it from the failures
+// if it succeeds or updating its error if it does not.
+func retrySyncFailure(ctx context.Context, qry *db.Queries, failure db.ReadSyncFailuresRow, now time.Time) (resolved bool, err error) {
+	obj, parseErr := decodeCachedObject(failure.Path, sql.NullString{}, failure.Ics)
+	if parseErr == nil {
+		_, parseErr = dto.NewEventObject(obj, time.Local)
+	}
+	if parseErr == nil {
+		err = qry.DeleteSyncFailures(ctx, []string{failure.Path})
+		resolved = err == nil
+		return
+	}
+	err = qry.PutSyncFailure(ctx, db.PutSyncFailureParams{
+		Path:         failure.Path,
+		CalendarPath: failure.CalendarPath,
+		Error:        parseErr.Error(),
+		FailedAt:     now.Unix(),
+	})
+	return
+}
+
+func queryFailuresCmdExec(ctx context.Context, call *nu.ExecCommand) (err error) {
+	retry := false
+	v, ok := call.FlagValue("retry")
+	if ok {
+		retry = v.Value.(bool)
+	}
+
+	driver, qry, err := db.Open(ctx)
+	if err != nil {
+		return
+	}
+	defer driver.Close()
+
+	if retry {
+		var failures []db.ReadSyncFailuresRow
+		failures, err = qry.ReadSyncFailures(ctx)
+		if err != nil {
+			return
+		}
+		now := time.Now()
+		for _, f := range failures {
+			_, err = retrySyncFailure(ctx, qry, f, now)
+			if err != nil {
+				return
+			}
+		}
+	}
+
+	failures, err := qry.ReadSyncFailures(ctx)
+	if err != nil {
+		return
+	}
+	list := make(dto.SyncFailureList, len(failures))
+	for i, f := range failures {
+		list[i] = dto.SyncFailure{
+			ObjectPath:   f.Path,
+			CalendarPath: f.CalendarPath,
+			Ics:          f.Ics,
+			Error:        f.Error,
+			FailedAt:     time.Unix(f.FailedAt, 0),
+		}
+	}
+
+	out, err := nuconv.SyncFailureListToNu(list)
+	if err != nil {
+		return
+	}
+	err = call.ReturnValue(ctx, out)
+	return
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/LQR471814/nu_plugin_caldav/internal/db"
+)
+
+const validEventIcs = "BEGIN:VCALENDAR\r\n" +
+	"VERSION:2.0\r\n" +
+	"PRODID:-//test//test//EN\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:valid\r\n" +
+	"DTSTAMP:20250101T000000Z\r\n" +
+	"DTSTART:20250101T100000Z\r\n" +
+	"DTEND:20250101T110000Z\r\n" +
+	"END:VEVENT\r\n" +
+	"END:VCALENDAR\r\n"
+
+const invalidEventIcs = "BEGIN:VCALENDAR\r\n" +
+	"VERSION:2.0\r\n" +
+	"PRODID:-//test//test//EN\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:invalid\r\n" +
+	"DTSTAMP:20250101T000000Z\r\n" +
+	"DTSTART:tomorrow\r\n" +
+	"END:VEVENT\r\n" +
+	"END:VCALENDAR\r\n"
+
+func TestRetrySyncFailure(t *testing.T) {
+	ctx := context.Background()
+	_, qry := openTestCache(t)
+	now := time.Now()
+
+	for path, ics := range map[string]string{
+		"/cal/valid":   validEventIcs,
+		"/cal/invalid": invalidEventIcs,
+	} {
+		// failures reference the cached object
+		err := qry.PutEvent(ctx, db.PutEventParams{
+			Path:         path,
+			CalendarPath: "/cal/",
+			Ics:          ics,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = qry.PutSyncFailure(ctx, db.PutSyncFailureParams{
+			Path:         path,
+			CalendarPath: "/cal/",
+			Error:        "failed to parse",
+			FailedAt:     now.Unix(),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	failures, err := qry.ReadSyncFailures(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range failures {
+		resolved, err := retrySyncFailure(ctx, qry, f, now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resolved != (f.Path == "/cal/valid") {
+			t.Fatalf("unexpected retry result for %q: %v", f.Path, resolved)
+		}
+	}
+
+	failures, err = qry.ReadSyncFailures(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(failures) != 1 || failures[0].Path != "/cal/invalid" {
+		t.Fatalf("expected only the invalid object to remain, got %+v", failures)
+	}
+	if failures[0].Error == "failed to parse" {
+		t.Fatal("expected the error to be updated")
+	}
+}
+
+func TestSyncFailureCount(t *testing.T) {
+	ctx := context.Background()
+	_, qry := openTestCache(t)
+
+	err := qry.PutCalendar(ctx, db.PutCalendarParams{Path: "/cal/"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range []string{"/cal/a", "/cal/b"} {
+		err = qry.PutEvent(ctx, db.PutEventParams{
+			Path:         path,
+			CalendarPath: "/cal/",
+			Ics:          invalidEventIcs,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = qry.PutSyncFailure(ctx, db.PutSyncFailureParams{
+			Path:         path,
+			CalendarPath: "/cal/",
+			Error:        "failed to parse",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	// failures of objects that are no longer cached are not counted
+	err = qry.DeleteEvents(ctx, []string{"/cal/b"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	calendars, err := qry.ReadCachedCalendars(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(calendars) != 1 || calendars[0].FailureCount != 1 {
+		t.Fatalf("expected 1 failure, got %+v", calendars)
+	}
+	failures, err := qry.ReadSyncFailures(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(failures) != 1 || failures[0].Ics != invalidEventIcs {
+		t.Fatalf("expected the failure to be read with the cached ics, got %+v", failures)
+	}
+}
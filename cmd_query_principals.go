@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+
+	"github.com/LQR471814/nu_plugin_caldav/internal/dto"
+	"github.com/LQR471814/nu_plugin_caldav/internal/nuconv"
+	"github.com/ainvaltin/nu-plugin"
+	"github.com/ainvaltin/nu-plugin/syntaxshape"
+	"github.com/ainvaltin/nu-plugin/types"
+)
+
+var queryPrincipalsCmd = &nu.Command{
+	Signature: nu.PluginSignature{
+		Name:        "caldav query principals",
+		Category:    "Network",
+		Desc:        "Searches for users and groups by name or email address.",
+		SearchTerms: caldavKeywordsQuery("principals", "users", "people", "directory"),
+		RequiredPositional: []nu.PositionalArg{
+			{
+				Name:  "search",
+				Desc:  "Text contained in the name or email address of the principal.",
+				Shape: syntaxshape.String(),
+			},
+		},
+		InputOutputTypes: []nu.InOutTypes{
+			{
+				In:  types.Nothing(),
+				Out: nuconv.PrincipalListType,
+			},
+		},
+	},
+	OnRun: queryPrincipalsCmdExec,
+}
+
+func init() {
+	commands = append(commands, queryPrincipalsCmd)
+}
+
+func queryPrincipalsCmdExec(ctx context.Context, call *nu.ExecCommand) (err error) {
+	search, err := tryCast[string](call.Positional[0])
+	if err != nil {
+		return
+	}
+	client, err := getClient(ctx, call)
+	if err != nil {
+		return
+	}
+	davClient, err := getDavClient(ctx, call)
+	if err != nil {
+		return
+	}
+	// the search is made against the principal collections of the current
+	// user's principal
+	principal, err := client.FindCurrentUserPrincipal(ctx)
+	if err != nil {
+		return
+	}
+	principals, err := davClient.SearchPrincipals(ctx, principal, search)
+	if err != nil {
+		return
+	}
+	out, err := nuconv.PrincipalListToNu(dto.NewPrincipalList(principals))
+	if err != nil {
+		return
+	}
+	err = call.ReturnValue(ctx, out)
+	return
+}
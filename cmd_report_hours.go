@@ -0,0 +1,282 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/LQR471814/nu_plugin_caldav/internal/dto"
+	"github.com/LQR471814/nu_plugin_caldav/internal/events"
+	"github.com/LQR471814/nu_plugin_caldav/internal/nuconv"
+	"github.com/ainvaltin/nu-plugin"
+	"github.com/ainvaltin/nu-plugin/syntaxshape"
+	"github.com/ainvaltin/nu-plugin/types"
+	"github.com/emersion/go-ical"
+)
+
+var reportHoursCmd = &nu.Command{
+	Signature: nu.PluginSignature{
+		Name:        "caldav report hours",
+		Desc:        "Totals the time spent on events, grouped by category, calendar or summary.",
+		SearchTerms: []string{"caldav", "report", "hours", "billing", "time", "total"},
+		Category:    "Viewers",
+		Named: []nu.Flag{
+			{
+				Long:  "start",
+				Short: 's',
+				Desc:  "Only count time after this time.",
+				Shape: syntaxshape.DateTime(),
+			},
+			{
+				Long:  "end",
+				Short: 'e',
+				Desc:  "Only count time before this time.",
+				Shape: syntaxshape.DateTime(),
+			},
+			{
+				Long:  "by",
+				Short: 'b',
+				Desc:  "What to group events by: category (events are counted in each of their categories), calendar or summary.",
+				Shape: syntaxshape.String(),
+			},
+			{
+				Long:  "per",
+				Short: 'p',
+				Desc:  "Whether to total each day or week (starting on monday) in the --timezone, defaults to day.",
+				Shape: syntaxshape.String(),
+			},
+			{
+				Long:    "overlapping",
+				Short:   'o',
+				Default: &falseNu,
+				Desc:    "Count the full duration of events that take place at the same time in the same group instead of counting the time once.",
+			},
+			floatingTimezoneFlag,
+		},
+		InputOutputTypes: []nu.InOutTypes{
+			{
+				// TODO: fix typing later
+				// since some fields may be omitted and nushell does not yet
+				// support optional typing
+				In:  types.Any(),
+				Out: nuconv.HoursReportListType,
+			},
+		},
+	},
+	OnRun: reportHoursCmdExec,
+}
+
+func init() {
+	commands = append(commands, reportHoursCmd)
+}
+
+// hoursGroups returns the groups an occurrence is counted in.
+func hoursGroups(occ dto.Occurrence, by string) []string {
+	switch by {
+	case "category":
+		if len(occ.Event.Categories) == 0 {
+			return []string{""}
+		}
+		return slices.Compact(slices.Sorted(slices.Values(occ.Event.Categories)))
+	case "calendar":
+		return []string{calendarName(occ)}
+	}
+	if occ.Event.Summary == nil {
+		return []string{""}
+	}
+	return []string{*occ.Event.Summary}
+}
+
+// periodStart returns the start of the day (or week) a time is in.
+func periodStart(t time.Time, week bool, loc *time.Location) time.Time {
+	t = t.In(loc)
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+	if week {
+		// weeks start on monday
+		day = day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+	}
+	return day
+}
+
+// hoursTally accumulates the time spent on the events of a group.
+type hoursTally struct {
+	// current is the span of the overlapping events that is not counted yet
+	current *span
+	periods map[time.Time]time.Duration
+}
+
+// count adds a span to the periods it takes place in.
+func (t *hoursTally) count(s span, week bool, loc *time.Location) {
+	for s.start.Before(s.end) {
+		period := periodStart(s.start, week, loc)
+		next := period.AddDate(0, 0, 1)
+		if week {
+			next = period.AddDate(0, 0, 7)
+		}
+		if next.After(s.end) {
+			next = s.end
+		}
+		t.periods[period] += next.Sub(s.start)
+		s.start = next
+	}
+}
+
+// reportOptions configure an hours report.
+type reportOptions struct {
+	by          string
+	week        bool
+	overlapping bool
+	loc         *time.Location
+}
+
+// reportHours totals the time spent on the occurrences of a source within
+// [start, end], the time events of the same group take place at the same time
+// is counted once unless overlapping is set. Cancelled and all-day events are
+// not counted. Problems with the events are returned as warnings.
+func reportHours(src *heapSource, start, end time.Time, opts reportOptions) (out []dto.HoursReport, warnings []string) {
+	tallies := map[string]*hoursTally{}
+	for {
+		warnings = append(warnings, src.warnings()...)
+		occ, ok := src.peekOccurrence()
+		if !ok || !occ.Start.Before(end) {
+			break
+		}
+		src.pop()
+		if occ.AllDay || !occ.End.After(start) ||
+			(occ.Event.Status != nil && *occ.Event.Status == events.EVENT_STATUS_CANCELLED) {
+			continue
+		}
+		s := span{start: occ.Start, end: occ.End}
+		if s.start.Before(start) {
+			s.start = start
+		}
+		if s.end.After(end) {
+			s.end = end
+		}
+
+		for _, group := range hoursGroups(occ, opts.by) {
+			tally, ok := tallies[group]
+			if !ok {
+				tally = &hoursTally{periods: map[time.Time]time.Duration{}}
+				tallies[group] = tally
+			}
+			if opts.overlapping {
+				tally.count(s, opts.week, opts.loc)
+				continue
+			}
+			// occurrences come in order of their start, so the ones that
+			// overlap the current span extend it
+			switch {
+			case tally.current == nil:
+				tally.current = &span{s.start, s.end}
+			case !s.start.After(tally.current.end):
+				if s.end.After(tally.current.end) {
+					tally.current.end = s.end
+				}
+			default:
+				tally.count(*tally.current, opts.week, opts.loc)
+				tally.current = &span{s.start, s.end}
+			}
+		}
+	}
+	warnings = append(warnings, src.warnings()...)
+
+	for _, group := range slices.Sorted(maps.Keys(tallies)) {
+		tally := tallies[group]
+		if tally.current != nil {
+			tally.count(*tally.current, opts.week, opts.loc)
+		}
+		report := dto.HoursReport{Group: group}
+		for _, period := range slices.SortedFunc(maps.Keys(tally.periods), time.Time.Compare) {
+			report.Total += tally.periods[period]
+			report.Periods = append(report.Periods, dto.HoursPeriod{
+				Start:    period,
+				Duration: tally.periods[period],
+			})
+		}
+		out = append(out, report)
+	}
+	return
+}
+
+func reportHoursCmdExec(ctx context.Context, call *nu.ExecCommand) (err error) {
+	start, ok := call.Named["start"].Value.(time.Time)
+	if !ok {
+		err = fmt.Errorf("must specify -start")
+		return
+	}
+	end, ok := call.Named["end"].Value.(time.Time)
+	if !ok {
+		err = fmt.Errorf("must specify -end")
+		return
+	}
+	if end.Before(start) {
+		err = fmt.Errorf("end %v cannot be before start %v", end, start)
+		return
+	}
+
+	opts := reportOptions{}
+	by, err := stringFlag(call, "by")
+	if err != nil {
+		return
+	}
+	if by == nil {
+		err = fmt.Errorf("must specify --by")
+		return
+	}
+	opts.by = strings.ToLower(*by)
+	switch opts.by {
+	case "category", "calendar", "summary":
+	default:
+		err = fmt.Errorf("--by must be one of category, calendar or summary, got %q", *by)
+		return
+	}
+	per, err := stringFlag(call, "per")
+	if err != nil {
+		return
+	}
+	if per != nil {
+		switch strings.ToLower(*per) {
+		case "day":
+		case "week":
+			opts.week = true
+		default:
+			err = fmt.Errorf("--per must be one of day or week, got %q", *per)
+			return
+		}
+	}
+	v, ok := call.FlagValue("overlapping")
+	if ok {
+		opts.overlapping = v.Value.(bool)
+	}
+
+	opts.loc, err = timezoneFlag(call)
+	if err != nil {
+		return
+	}
+
+	objects, err := recvListInput(call, nuconv.EventObjectFromNu)
+	if err != nil {
+		return
+	}
+	objects = filterKinds(objects, ical.CompEvent)
+	for i, obj := range objects {
+		objects[i] = anchorObject(obj, opts.loc)
+	}
+
+	report, warnings := reportHours(newHeapSource(objects, start, end), start, end, opts)
+	for _, w := range warnings {
+		slog.Warn("report hours", "warning", w)
+	}
+
+	out, err := nuconv.HoursReportListToNu(report)
+	if err != nil {
+		return
+	}
+	err = call.ReturnValue(ctx, out)
+	return
+}
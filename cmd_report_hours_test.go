@@ -0,0 +1,102 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/LQR471814/nu_plugin_caldav/internal/dto"
+	"github.com/LQR471814/nu_plugin_caldav/internal/events"
+)
+
+func TestReportHours(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// a monday
+	start := time.Date(2025, 10, 20, 0, 0, 0, 0, ny)
+	end := start.AddDate(0, 0, 14)
+	at := func(days, h int) time.Time {
+		return time.Date(2025, 10, 20+days, h, 0, 0, 0, ny)
+	}
+	cancelled := events.EVENT_STATUS_CANCELLED
+	object := func(s, e time.Time, categories ...string) dto.EventObject {
+		return dto.EventObject{Main: dto.Event{
+			Categories: categories,
+			Start:      events.Datetime{Stamp: s},
+			End:        events.Datetime{Stamp: e},
+		}}
+	}
+	dropped := object(at(1, 9), at(1, 17), "acme")
+	dropped.Main.Status = &cancelled
+	objects := []dto.EventObject{
+		// started before the window
+		object(at(-1, 22), at(0, 2), "acme"),
+		object(at(0, 9), at(0, 11), "acme", "globex"),
+		// overlaps the previous event
+		object(at(0, 10), at(0, 12), "acme"),
+		// crosses midnight
+		object(at(2, 23), at(3, 1), "globex"),
+		object(at(8, 9), at(8, 10)),
+		dropped,
+	}
+
+	cases := []struct {
+		name     string
+		opts     reportOptions
+		expected []dto.HoursReport
+	}{
+		{
+			name: "daily",
+			opts: reportOptions{by: "category", loc: ny},
+			expected: []dto.HoursReport{
+				{Group: "", Total: time.Hour, Periods: []dto.HoursPeriod{
+					{Start: at(8, 0), Duration: time.Hour},
+				}},
+				{Group: "acme", Total: 5 * time.Hour, Periods: []dto.HoursPeriod{
+					{Start: at(0, 0), Duration: 5 * time.Hour},
+				}},
+				{Group: "globex", Total: 4 * time.Hour, Periods: []dto.HoursPeriod{
+					{Start: at(0, 0), Duration: 2 * time.Hour},
+					{Start: at(2, 0), Duration: time.Hour},
+					{Start: at(3, 0), Duration: time.Hour},
+				}},
+			},
+		},
+		{
+			name: "weekly overlapping",
+			opts: reportOptions{by: "category", week: true, overlapping: true, loc: ny},
+			expected: []dto.HoursReport{
+				{Group: "", Total: time.Hour, Periods: []dto.HoursPeriod{
+					{Start: at(7, 0), Duration: time.Hour},
+				}},
+				{Group: "acme", Total: 6 * time.Hour, Periods: []dto.HoursPeriod{
+					{Start: at(0, 0), Duration: 6 * time.Hour},
+				}},
+				{Group: "globex", Total: 4 * time.Hour, Periods: []dto.HoursPeriod{
+					{Start: at(0, 0), Duration: 4 * time.Hour},
+				}},
+			},
+		},
+	}
+	for _, c := range cases {
+		report, warnings := reportHours(newHeapSource(objects, start, end), start, end, c.opts)
+		if len(warnings) > 0 {
+			t.Fatalf("%s: unexpected warnings %v", c.name, warnings)
+		}
+		if len(report) != len(c.expected) {
+			t.Fatalf("%s: expected %d groups, got %+v", c.name, len(c.expected), report)
+		}
+		for i, group := range c.expected {
+			got := report[i]
+			if got.Group != group.Group || got.Total != group.Total || len(got.Periods) != len(group.Periods) {
+				t.Fatalf("%s: expected %+v, got %+v", c.name, group, got)
+			}
+			for j, period := range group.Periods {
+				if !got.Periods[j].Start.Equal(period.Start) || got.Periods[j].Duration != period.Duration {
+					t.Fatalf("%s: group %q: expected %+v, got %+v", c.name, group.Group, group.Periods, got.Periods)
+				}
+			}
+		}
+	}
+}
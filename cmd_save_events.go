@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
 	"path"
@@ -9,6 +10,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/LQR471814/nu_plugin_caldav/internal/db"
 	"github.com/LQR471814/nu_plugin_caldav/internal/dto"
 	"github.com/LQR471814/nu_plugin_caldav/internal/events"
 	"github.com/LQR471814/nu_plugin_caldav/internal/nuconv"
@@ -42,6 +44,12 @@ var saveEventsCmd = &nu.Command{
 				Default: &defaultParallelism,
 				Desc:    "Controls the amount of requests that can be made in parallel.",
 			},
+			{
+				Long:    "offline",
+				Short:   'o',
+				Default: &falseNu,
+				Desc:    "Queue the writes in the cache instead of sending them to the server, they can be sent later with `caldav push`.",
+			},
 		},
 		RequiredPositional: []nu.PositionalArg{
 			{
@@ -71,6 +79,64 @@ type saveEventCtx struct {
 	ctx          context.Context
 	calendarPath string
 	client       *caldav.Client
+	// driver and qry are set when saving offline, objects are then read from
+	// and queued in the cache instead of the server
+	driver *sql.DB
+	qry    *db.Queries
+}
+
+// fetchObjects reads the current version of the given objects.
+func (ctx saveEventCtx) fetchObjects(paths []string) ([]caldav.CalendarObject, error) {
+	if ctx.qry != nil {
+		return readCachedObjects(ctx.ctx, ctx.qry, paths)
+	}
+	return ctx.client.MultiGetCalendar(ctx.ctx, ctx.calendarPath, &caldav.CalendarMultiGet{
+		Paths: paths,
+		CompRequest: caldav.CalendarCompRequest{
+			Name:     ical.CompEvent,
+			AllProps: true,
+		},
+	})
+}
+
+// newEvent returns an empty event of a component kind, VEVENT if the kind is
+// not set.
+func newEvent(kind string, loc *time.Location) events.Event {
+	if kind == "" {
+		kind = ical.CompEvent
+	}
+	return events.Event{
+		Timezone: loc,
+		Event:    ical.Event{Component: ical.NewComponent(kind)},
+	}
+}
+
+// readEventObject splits a calendar object into its main event (or todo or
+// journal) and overrides.
+func readEventObject(o caldav.CalendarObject) events.EventObject {
+	obj := events.EventObject{
+		ObjectPath: o.Path,
+	}
+	tzs := events.FindTimezones(o.Data)
+	for _, child := range o.Data.Children {
+		switch child.Name {
+		case ical.CompEvent, ical.CompToDo, ical.CompJournal:
+		default:
+			continue
+		}
+		prop := child.Props.Get(ical.PropRecurrenceID)
+		ev := events.Event{
+			Event:     ical.Event{Component: child},
+			Timezone:  time.Local,
+			Timezones: tzs,
+		}
+		if prop != nil {
+			obj.Overrides = append(obj.Overrides, ev)
+			continue
+		}
+		obj.Main = ev
+	}
+	return obj
 }
 
 // returns full event object(s) with updates applied
@@ -90,39 +156,66 @@ func makeUpdatedObjects(
 	}
 
 	paths := make([]string, len(objectReplicas))
+	replicas := make(map[string]dto.EventObject, len(objectReplicas))
 	for i, replica := range objectReplicas {
 		paths[i] = *replica.ObjectPath
+		replicas[*replica.ObjectPath] = replica
 	}
-	objects, err := ctx.client.MultiGetCalendar(ctx.ctx, ctx.calendarPath, &caldav.CalendarMultiGet{
-		Paths: paths,
-		CompRequest: caldav.CalendarCompRequest{
-			Name:     ical.CompEvent,
-			AllProps: true,
-		},
-	})
+	objects, err := ctx.fetchObjects(paths)
 	if err != nil {
 		return
 	}
 
 	out = make([]events.EventObject, len(objects))
 	for i, o := range objects {
-		out[i] = events.EventObject{
-			ObjectPath: o.Path,
+		out[i] = readEventObject(o)
+
+		replica, ok := replicas[o.Path]
+		if !ok {
+			continue
 		}
-		for _, child := range o.Data.Children {
-			prop := child.Props.Get(ical.PropRecurrenceID)
-			ev := events.Event{
-				Event:    ical.Event{Component: child},
-				Timezone: time.Local,
+		err = applyObjectReplica(&out[i], replica)
+		if err != nil {
+			return nil, fmt.Errorf("apply update to %q: %w", o.Path, err)
+		}
+	}
+
+	return
+}
+
+// applyObjectReplica applies the fields of an event object onto the existing
+// version of the object, overrides are matched by their recurrence instance.
+func applyObjectReplica(obj *events.EventObject, replica dto.EventObject) (err error) {
+	err = replica.Main.Apply(obj.Main)
+	if err != nil {
+		return fmt.Errorf("apply main event: %w", err)
+	}
+	for _, override := range replica.Overrides {
+		if override.RecurrenceInstance == nil {
+			return fmt.Errorf("override must have recurrence_instance defined: %v", override)
+		}
+		var target *events.Event
+		for i, existing := range obj.Overrides {
+			instance, err := existing.GetRecurrenceInstance()
+			if err != nil {
+				return fmt.Errorf("get recurrence instance of existing override: %w", err)
 			}
-			if prop != nil {
-				out[i].Overrides = append(out[i].Overrides, ev)
-				continue
+			if instance.Stamp.Equal(override.RecurrenceInstance.Stamp) {
+				target = &obj.Overrides[i]
+				break
 			}
-			out[i].Main = ev
+		}
+		if target == nil {
+			ov := newEvent(obj.Main.Name, time.Local)
+			ov.Timezones = obj.Main.Timezones
+			obj.Overrides = append(obj.Overrides, ov)
+			target = &obj.Overrides[len(obj.Overrides)-1]
+		}
+		err = override.Apply(*target)
+		if err != nil {
+			return fmt.Errorf("apply override event: %w", err)
 		}
 	}
-
 	return
 }
 
@@ -135,18 +228,54 @@ type putEventObjectJob struct {
 func (j putEventObjectJob) Do(ctx context.Context) (err error) {
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
-	objpath := j.obj.ObjectPath
-	if objpath == "" {
-		uid, err := j.obj.Main.GetUID()
-		if err != nil {
-			return fmt.Errorf("get UID for calendar object path: %w", err)
-		}
-		objpath = path.Join(j.calpath, uid)
+	objpath, err := resolveObjectPath(j.calpath, j.obj)
+	if err != nil {
+		return
 	}
 	_, err = j.client.PutCalendarObject(ctx, objpath, j.obj.ToCalendar())
 	return
 }
 
+// queuePutObjects records the given objects in the cache to be pushed later.
+func queuePutObjects(ctx saveEventCtx, objects []events.EventObject, now time.Time) (err error) {
+	tx, err := ctx.driver.BeginTx(ctx.ctx, nil)
+	if err != nil {
+		return
+	}
+	defer tx.Rollback()
+	txqry := ctx.qry.WithTx(tx)
+
+	for _, obj := range objects {
+		var objpath, ics string
+		objpath, err = resolveObjectPath(ctx.calendarPath, obj)
+		if err != nil {
+			return
+		}
+		ics, err = encodeObjectData(obj.ToCalendar())
+		if err != nil {
+			return fmt.Errorf("encode object %q: %w", objpath, err)
+		}
+		err = queuePut(ctx.ctx, txqry, ctx.calendarPath, objpath, ics, now)
+		if err != nil {
+			return
+		}
+	}
+	return tx.Commit()
+}
+
+// resolveObjectPath returns the path of the object, new objects are placed
+// in the calendar under their UID.
+func resolveObjectPath(calpath string, obj events.EventObject) (string, error) {
+	if obj.ObjectPath != "" {
+		return obj.ObjectPath, nil
+	}
+	uid, err := obj.Main.GetUID()
+	if err != nil {
+		return "", fmt.Errorf("get UID for calendar object path: %w", err)
+	}
+	return path.Join(calpath, uid), nil
+}
+
 func escapeTextProperty(name string, get func() (string, error), set func(*string)) error {
 	text, err := get()
 	if errors.Is(err, events.ErrPropertyNotFound) {
@@ -211,14 +340,13 @@ func saveEventsCmdExec(ctx context.Context, call *nu.ExecCommand) (err error) {
 	currentTime := time.Now()
 
 	// parse flags
-	client, err := getClient(ctx, call)
-	if err != nil {
-		return
-	}
 	calendarPath, err := tryCast[string](call.Positional[0])
 	if err != nil {
 		return
 	}
+	if isSubscriptionURL(calendarPath) {
+		return fmt.Errorf("cannot save events to the subscription %q, subscriptions are read-only", calendarPath)
+	}
 	update := false
 	v, ok := call.FlagValue("update")
 	if ok {
@@ -229,12 +357,28 @@ func saveEventsCmdExec(ctx context.Context, call *nu.ExecCommand) (err error) {
 	if ok {
 		parallel = v.Value.(int)
 	}
+	offline := false
+	v, ok = call.FlagValue("offline")
+	if ok {
+		offline = v.Value.(bool)
+	}
 
 	subctx := saveEventCtx{
 		ctx:          ctx,
-		client:       client,
 		calendarPath: calendarPath,
 	}
+	if offline {
+		subctx.driver, subctx.qry, err = db.Open(ctx)
+		if err != nil {
+			return
+		}
+		defer subctx.driver.Close()
+	} else {
+		subctx.client, err = getClient(ctx, call)
+		if err != nil {
+			return
+		}
+	}
 
 	// process input events
 	var putObjects []events.EventObject
@@ -252,7 +396,7 @@ func saveEventsCmdExec(ctx context.Context, call *nu.ExecCommand) (err error) {
 	} else {
 		var updateObjectReplicas []dto.EventObject
 		for _, replica := range inputObjectReplicas {
-			if replica.ObjectPath != nil && *replica.ObjectPath == "" {
+			if replica.ObjectPath == nil || *replica.ObjectPath == "" {
 				continue
 			}
 			updateObjectReplicas = append(updateObjectReplicas, replica)
@@ -270,13 +414,13 @@ func saveEventsCmdExec(ctx context.Context, call *nu.ExecCommand) (err error) {
 			continue
 		}
 		obj := events.EventObject{}
-		obj.Main = events.Event{Timezone: time.Local, Event: *ical.NewEvent()}
+		obj.Main = newEvent(replica.Main.Kind, time.Local)
 		err = replica.Main.Apply(obj.Main)
 		if err != nil {
 			return fmt.Errorf("apply main event: %w", err)
 		}
 		for _, override := range replica.Overrides {
-			ev := events.Event{Timezone: time.Local, Event: *ical.NewEvent()}
+			ev := newEvent(replica.Main.Kind, time.Local)
 			err = override.Apply(ev)
 			if err != nil {
 				return fmt.Errorf("apply override event: %w", err)
@@ -286,7 +430,12 @@ func saveEventsCmdExec(ctx context.Context, call *nu.ExecCommand) (err error) {
 		putObjects = append(putObjects, obj)
 	}
 
-	// apply default property updates to new/modified objects
+	return putEventObjects(subctx, putObjects, parallel, currentTime)
+}
+
+// putEventObjects applies the default property updates to new/modified
+// objects and sends them to the server (or queues them when saving offline).
+func putEventObjects(ctx saveEventCtx, putObjects []events.EventObject, parallel int, currentTime time.Time) (err error) {
 	dtstamp := ical.NewProp(ical.PropDateTimeStamp)
 	dtstamp.SetDateTime(currentTime)
 	now := events.Datetime{Stamp: currentTime}
@@ -303,15 +452,19 @@ func saveEventsCmdExec(ctx context.Context, call *nu.ExecCommand) (err error) {
 		}
 	}
 
+	if ctx.qry != nil {
+		return queuePutObjects(ctx, putObjects, currentTime)
+	}
+
 	jobs := make([]job, len(putObjects))
 	for i, obj := range putObjects {
 		jobs[i] = putEventObjectJob{
-			calpath: calendarPath,
-			client:  client,
+			calpath: ctx.calendarPath,
+			client:  ctx.client,
 			obj:     obj,
 		}
 	}
-	err = parallelizeJobs(ctx, jobs, parallel)
+	err = parallelizeJobs(ctx.ctx, jobs, parallel)
 	if err != nil {
 		return
 	}
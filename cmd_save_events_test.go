@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/LQR471814/nu_plugin_caldav/internal/dto"
+	"github.com/LQR471814/nu_plugin_caldav/internal/events"
+	"github.com/emersion/go-ical"
+)
+
+func newStoredEvent(summary string, start time.Time) events.Event {
+	e := events.Event{Event: *ical.NewEvent(), Timezone: time.UTC}
+	e.SetUID("stored")
+	e.SetSummary(summary)
+	e.SetStart(events.Datetime{Stamp: start})
+	e.SetEnd(events.Datetime{Stamp: start.Add(time.Hour)})
+	return e
+}
+
+func TestApplyObjectReplica(t *testing.T) {
+	start := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+	existing := newStoredEvent("existing override", start.AddDate(0, 0, 7))
+	existing.SetRecurrenceInstance(&events.Datetime{Stamp: start.AddDate(0, 0, 7)})
+	obj := events.EventObject{
+		ObjectPath: "/cal/stored",
+		Main:       newStoredEvent("main", start),
+		Overrides:  []events.Event{existing},
+	}
+
+	summary := func(s string) *string { return &s }
+	replica := dto.EventObject{
+		Main: dto.Event{
+			Summary: summary("renamed"),
+			Start:   events.Datetime{Stamp: start},
+			End:     events.Datetime{Stamp: start.Add(time.Hour)},
+		},
+		Overrides: []dto.Event{
+			{
+				// matches the existing override
+				Summary:            summary("edited override"),
+				Start:              events.Datetime{Stamp: start.AddDate(0, 0, 7).Add(time.Hour)},
+				End:                events.Datetime{Stamp: start.AddDate(0, 0, 7).Add(2 * time.Hour)},
+				RecurrenceInstance: &events.Datetime{Stamp: start.AddDate(0, 0, 7)},
+			},
+			{
+				Summary:            summary("new override"),
+				Start:              events.Datetime{Stamp: start.AddDate(0, 0, 14)},
+				End:                events.Datetime{Stamp: start.AddDate(0, 0, 14).Add(time.Hour)},
+				RecurrenceInstance: &events.Datetime{Stamp: start.AddDate(0, 0, 14)},
+			},
+		},
+	}
+	err := applyObjectReplica(&obj, replica)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if s, _ := obj.Main.GetSummary(); s != "renamed" {
+		t.Fatalf("expected the main event to be updated, got %q", s)
+	}
+	// the fields the replica does not set are kept
+	if uid, _ := obj.Main.GetUID(); uid != "stored" {
+		t.Fatalf("expected the UID to be kept, got %q", uid)
+	}
+	if len(obj.Overrides) != 2 {
+		t.Fatalf("expected the existing override to be updated and one to be added, got %d", len(obj.Overrides))
+	}
+	for i, expected := range []string{"edited override", "new override"} {
+		if s, _ := obj.Overrides[i].GetSummary(); s != expected {
+			t.Fatalf("expected override %d to be %q, got %q", i, expected, s)
+		}
+	}
+
+	replica.Overrides = []dto.Event{{Summary: summary("no instance")}}
+	if applyObjectReplica(&obj, replica) == nil {
+		t.Fatal("expected an error for an override without a recurrence instance")
+	}
+}
+
+func TestSaveTodoAndJournal(t *testing.T) {
+	ctx := context.Background()
+	driver, qry := openTestCache(t)
+	subctx := saveEventCtx{ctx: ctx, calendarPath: "/cal/", driver: driver, qry: qry}
+	now := time.Date(2026, 1, 5, 8, 0, 0, 0, time.UTC)
+	text := func(s string) *string { return &s }
+	due := events.Datetime{Stamp: now.Add(3 * time.Hour)}
+
+	for _, replica := range []dto.Event{
+		{
+			Kind:    ical.CompToDo,
+			Uid:     text("todo"),
+			Summary: text("Taxes"),
+			Start:   events.Datetime{Stamp: now.Add(time.Hour)},
+			Due:     &due,
+		},
+		{
+			Kind:    ical.CompJournal,
+			Uid:     text("journal"),
+			Summary: text("Notes"),
+			Start:   events.Datetime{Stamp: now},
+		},
+	} {
+		obj := events.EventObject{Main: newEvent(replica.Kind, time.UTC)}
+		err := replica.Apply(obj.Main)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = putEventObjects(subctx, []events.EventObject{obj}, 1, now)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// update the stored object
+		objectPath := "/cal/" + *replica.Uid
+		replica.Summary = text("renamed")
+		updated, err := makeUpdatedObjects(subctx, []dto.EventObject{{ObjectPath: &objectPath, Main: replica}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(updated) != 1 || updated[0].Main.Name != replica.Kind {
+			t.Fatalf("%s: expected the stored object to be read, got %+v", replica.Kind, updated)
+		}
+		err = putEventObjects(subctx, updated, 1, now)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		stored, err := readCachedObjects(ctx, qry, []string{objectPath})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(stored) != 1 {
+			t.Fatalf("%s: expected the object to be stored, got %d objects", replica.Kind, len(stored))
+		}
+		out, err := dto.NewEventObject(stored[0], time.UTC)
+		if err != nil {
+			t.Fatal(err)
+		}
+		main := out.Main
+		if main.Kind != replica.Kind || main.Summary == nil || *main.Summary != "renamed" ||
+			!main.Start.Stamp.Equal(replica.Start.Stamp) {
+			t.Fatalf("%s: unexpected stored event %s", replica.Kind, main)
+		}
+		if replica.Due != nil && (main.Due == nil || !main.Due.Stamp.Equal(replica.Due.Stamp)) {
+			t.Fatalf("%s: unexpected due %v", replica.Kind, main.Due)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"context"
+	"strings"
+
+	"github.com/LQR471814/nu_plugin_caldav/internal/dav"
+	"github.com/ainvaltin/nu-plugin"
+	"github.com/ainvaltin/nu-plugin/syntaxshape"
+	"github.com/ainvaltin/nu-plugin/types"
+)
+
+var shareePositionals = []nu.PositionalArg{
+	{
+		Name:  "calendar_path",
+		Desc:  "The `path` attribute of the calendar record returned by `caldav query calendars`.",
+		Shape: syntaxshape.String(),
+	},
+	{
+		Name:  "sharee",
+		Desc:  "The email address or principal path of the user, see `caldav query principals`.",
+		Shape: syntaxshape.String(),
+	},
+}
+
+var shareCalendarCmd = &nu.Command{
+	Signature: nu.PluginSignature{
+		Name:        "caldav share calendar",
+		Category:    "Network",
+		Desc:        "Shares a calendar with a user, or changes the access of an existing sharee.",
+		SearchTerms: []string{"caldav", "share", "calendar", "invite", "access"},
+		Named: []nu.Flag{
+			{
+				Long:    "read-write",
+				Short:   'w',
+				Default: &falseNu,
+				Desc:    "Allow the sharee to modify the calendar instead of only reading it.",
+			},
+			{
+				Long:  "name",
+				Short: 'n',
+				Shape: syntaxshape.String(),
+				Desc:  "The name of the sharee.",
+			},
+			{
+				Long:  "summary",
+				Short: 's',
+				Shape: syntaxshape.String(),
+				Desc:  "A description of the share that is included in the invite.",
+			},
+		},
+		RequiredPositional: shareePositionals,
+		InputOutputTypes: []nu.InOutTypes{
+			{
+				In:  types.Nothing(),
+				Out: types.Nothing(),
+			},
+		},
+	},
+	OnRun: shareCalendarCmdExec,
+}
+
+var unshareCalendarCmd = &nu.Command{
+	Signature: nu.PluginSignature{
+		Name:               "caldav unshare calendar",
+		Category:           "Network",
+		Desc:               "Stops sharing a calendar with a user.",
+		SearchTerms:        []string{"caldav", "unshare", "calendar", "access", "remove"},
+		RequiredPositional: shareePositionals,
+		InputOutputTypes: []nu.InOutTypes{
+			{
+				In:  types.Nothing(),
+				Out: types.Nothing(),
+			},
+		},
+	},
+	OnRun: unshareCalendarCmdExec,
+}
+
+func init() {
+	commands = append(commands, shareCalendarCmd, unshareCalendarCmd)
+}
+
+// shareeHref turns a plain email address into a mailto: URI, principal paths
+// and URIs are returned as is.
+func shareeHref(sharee string) string {
+	if strings.Contains(sharee, "@") && !strings.Contains(sharee, ":") {
+		return "mailto:" + sharee
+	}
+	return sharee
+}
+
+func shareCalendarCmdExec(ctx context.Context, call *nu.ExecCommand) (err error) {
+	calendarPath, err := tryCast[string](call.Positional[0])
+	if err != nil {
+		return
+	}
+	href, err := tryCast[string](call.Positional[1])
+	if err != nil {
+		return
+	}
+	sharee := dav.Sharee{Href: shareeHref(href)}
+	v, ok := call.FlagValue("read-write")
+	if ok {
+		sharee.ReadWrite = v.Value.(bool)
+	}
+	name, err := stringFlag(call, "name")
+	if err != nil {
+		return
+	}
+	if name != nil {
+		sharee.CommonName = *name
+	}
+	summary, err := stringFlag(call, "summary")
+	if err != nil {
+		return
+	}
+
+	client, err := getDavClient(ctx, call)
+	if err != nil {
+		return
+	}
+	var summaryText string
+	if summary != nil {
+		summaryText = *summary
+	}
+	err = client.Share(ctx, calendarPath, sharee, summaryText)
+	return
+}
+
+func unshareCalendarCmdExec(ctx context.Context, call *nu.ExecCommand) (err error) {
+	calendarPath, err := tryCast[string](call.Positional[0])
+	if err != nil {
+		return
+	}
+	href, err := tryCast[string](call.Positional[1])
+	if err != nil {
+		return
+	}
+	client, err := getDavClient(ctx, call)
+	if err != nil {
+		return
+	}
+	err = client.Unshare(ctx, calendarPath, shareeHref(href))
+	return
+}
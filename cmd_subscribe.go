@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/LQR471814/nu_plugin_caldav/internal/db"
+	"github.com/ainvaltin/nu-plugin"
+	"github.com/ainvaltin/nu-plugin/syntaxshape"
+	"github.com/ainvaltin/nu-plugin/types"
+)
+
+var subscribeCmd = &nu.Command{
+	Signature: nu.PluginSignature{
+		Name:        "caldav subscribe",
+		Category:    "Network",
+		Desc:        "Subscribes to an iCalendar feed (webcal:// or https://), the feed is cached and can be queried like any other calendar by passing its URL as the calendar path. Subscriptions are read-only.",
+		SearchTerms: []string{"caldav", "subscribe", "subscription", "webcal", "ics", "feed"},
+		RequiredPositional: []nu.PositionalArg{
+			{
+				Name:  "url",
+				Desc:  "The URL of the feed.",
+				Shape: syntaxshape.String(),
+			},
+			{
+				Name:  "name",
+				Desc:  "The display name of the subscription.",
+				Shape: syntaxshape.String(),
+			},
+		},
+		InputOutputTypes: []nu.InOutTypes{
+			{
+				In:  types.Nothing(),
+				Out: types.String(),
+			},
+		},
+	},
+	OnRun: subscribeCmdExec,
+}
+
+var unsubscribeCmd = &nu.Command{
+	Signature: nu.PluginSignature{
+		Name:        "caldav unsubscribe",
+		Category:    "Network",
+		Desc:        "Removes a subscription and its cached events.",
+		SearchTerms: []string{"caldav", "unsubscribe", "subscription", "webcal", "ics", "feed"},
+		RequiredPositional: []nu.PositionalArg{
+			{
+				Name:  "url",
+				Desc:  "The URL passed to `caldav subscribe`.",
+				Shape: syntaxshape.String(),
+			},
+		},
+		InputOutputTypes: []nu.InOutTypes{
+			{
+				In:  types.Nothing(),
+				Out: types.Nothing(),
+			},
+		},
+	},
+	OnRun: unsubscribeCmdExec,
+}
+
+func init() {
+	commands = append(commands, subscribeCmd, unsubscribeCmd)
+}
+
+func subscribeCmdExec(ctx context.Context, call *nu.ExecCommand) (err error) {
+	url, err := tryCast[string](call.Positional[0])
+	if err != nil {
+		return
+	}
+	name, err := tryCast[string](call.Positional[1])
+	if err != nil {
+		return
+	}
+	if !isSubscriptionURL(url) {
+		return fmt.Errorf("%q is not the URL of a feed, expected a webcal://, http:// or https:// URL", url)
+	}
+
+	driver, qry, err := db.Open(ctx)
+	if err != nil {
+		return
+	}
+	defer driver.Close()
+
+	err = qry.PutSubscription(ctx, db.PutSubscriptionParams{
+		Url:  url,
+		Name: name,
+	})
+	if err != nil {
+		return
+	}
+	// a renamed subscription must be downloaded again to update the cached
+	// calendar name
+	err = qry.PutSubscriptionValidators(ctx, db.PutSubscriptionValidatorsParams{Url: url})
+	if err != nil {
+		return
+	}
+	err = syncCalendars(ctx, nil, nil, driver, qry, []string{url}, 1)
+	if err != nil {
+		return
+	}
+
+	err = call.ReturnValue(ctx, nu.ToValue(url))
+	return
+}
+
+func unsubscribeCmdExec(ctx context.Context, call *nu.ExecCommand) (err error) {
+	url, err := tryCast[string](call.Positional[0])
+	if err != nil {
+		return
+	}
+	err = purgeCalendar(ctx, url, false)
+	if err != nil {
+		return
+	}
+
+	driver, qry, err := db.Open(ctx)
+	if err != nil {
+		return
+	}
+	defer driver.Close()
+	err = qry.DeleteSubscription(ctx, url)
+	return
+}
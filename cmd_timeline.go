@@ -8,7 +8,9 @@ import (
 	"time"
 
 	"github.com/LQR471814/nu_plugin_caldav/internal/dto"
+	"github.com/LQR471814/nu_plugin_caldav/internal/events"
 	"github.com/LQR471814/nu_plugin_caldav/internal/nuconv"
+	"github.com/LQR471814/nu_plugin_caldav/internal/recurrence"
 	"github.com/ainvaltin/nu-plugin"
 	"github.com/ainvaltin/nu-plugin/syntaxshape"
 	"github.com/ainvaltin/nu-plugin/types"
@@ -34,6 +36,31 @@ var timelineCmd = &nu.Command{
 				Desc:  "Filter for all events before this end time.",
 				Shape: syntaxshape.DateTime(),
 			},
+			{
+				Long:  "bucket",
+				Short: 'b',
+				Desc:  "Resample the timeline into fixed size slots (ex. 30min, 1hr, 1day) with the fraction of each slot that is busy and the events active during it.",
+				Shape: syntaxshape.Duration(),
+			},
+			{
+				Long:    "merge-busy",
+				Short:   'm',
+				Default: &falseNu,
+				Desc:    "Collapse consecutive busy segments into one.",
+			},
+			{
+				Long:  "travel",
+				Desc:  "Insert travel events of this length before events whose location differs from the previous event's, transitions without enough time to travel are reported as warnings.",
+				Shape: syntaxshape.Duration(),
+			},
+			{
+				Long:  "travel-speed",
+				Desc:  "Derive the length of travel events from the distance between the GEOs of events at this speed (in km/h), --travel is used for events without a GEO.",
+				Shape: syntaxshape.Number(),
+			},
+			todosFlag,
+			journalsFlag,
+			floatingTimezoneFlag,
 		},
 		InputOutputTypes: []nu.InOutTypes{
 			{
@@ -52,78 +79,206 @@ func init() {
 	commands = append(commands, timelineCmd)
 }
 
-func expandEvents(out *[]dto.Event, object dto.EventObject, start, end time.Time) {
-	set := &rrule.Set{}
-
-	if object.Main.RecurrenceRule.RRule != nil {
-		set.RRule(object.Main.RecurrenceRule.RRule)
-	} else {
-		// single recurrence rule
-		rule, err := rrule.NewRRule(rrule.ROption{
-			Dtstart: object.Main.Start.Stamp,
-			Count:   1,
-		})
-		if err != nil {
-			panic(err)
-		}
-		set.RRule(rule)
+// anchorDatetime resolves a floating datetime in the given location keeping its
+// wall clock time, all-day dates are moved to the start of their day.
+func anchorDatetime(d events.Datetime, loc *time.Location) events.Datetime {
+	s := d.Stamp
+	switch {
+	case d.AllDay:
+		d.Stamp = time.Date(s.Year(), s.Month(), s.Day(), 0, 0, 0, 0, loc)
+	case d.Floating:
+		d.Stamp = time.Date(s.Year(), s.Month(), s.Day(), s.Hour(), s.Minute(), s.Second(), s.Nanosecond(), loc)
 	}
+	return d
+}
 
-	rdates := make([]time.Time, len(object.Main.RecurrenceDates))
-	for i, d := range object.Main.RecurrenceDates {
-		rdates[i] = d.Stamp
+func anchorDatetimes(list []events.Datetime, loc *time.Location) []events.Datetime {
+	if list == nil {
+		return nil
+	}
+	out := make([]events.Datetime, len(list))
+	for i, d := range list {
+		out[i] = anchorDatetime(d, loc)
 	}
-	set.SetRDates(rdates)
+	return out
+}
 
-	exdates := make([]time.Time, len(object.Main.RecurrenceExceptionDates))
-	for i, d := range object.Main.RecurrenceExceptionDates {
-		exdates[i] = d.Stamp
+// anchorEvent resolves the floating times of an event in the given location,
+// all-day events span whole days in that location.
+func anchorEvent(e dto.Event, loc *time.Location) dto.Event {
+	e.Start = anchorDatetime(e.Start, loc)
+	e.End = anchorDatetime(e.End, loc)
+	if e.Start.AllDay && !e.End.Stamp.After(e.Start.Stamp) {
+		e.End.Stamp = e.Start.Stamp.AddDate(0, 0, 1)
 	}
-	// not a part of EXDATE, but added to prevent rrule from generating
-	// recurrences on those specific date instances as overrides will
-	// be added separately
-	for _, override := range object.Overrides {
-		exdates = append(exdates, override.RecurrenceInstance.Stamp)
-		if override.Start.Stamp.Before(start) || override.Start.Stamp.After(end) {
-			continue
+	e.RecurrenceDates = anchorDatetimes(e.RecurrenceDates, loc)
+	e.RecurrenceExceptionDates = anchorDatetimes(e.RecurrenceExceptionDates, loc)
+	if e.RecurrencePeriods != nil {
+		periods := make([]events.Period, len(e.RecurrencePeriods))
+		for i, p := range e.RecurrencePeriods {
+			periods[i] = events.Period{
+				Start: anchorDatetime(p.Start, loc),
+				End:   anchorDatetime(p.End, loc),
+			}
 		}
-		*out = append(*out, override)
+		e.RecurrencePeriods = periods
+	}
+	if e.RecurrenceInstance != nil {
+		instance := anchorDatetime(*e.RecurrenceInstance, loc)
+		e.RecurrenceInstance = &instance
+	}
+	if e.Due != nil {
+		due := anchorDatetime(*e.Due, loc)
+		e.Due = &due
 	}
-	set.SetExDates(exdates)
+	return e
+}
 
-	times := set.Between(start, end, true)
-	for _, startTime := range times {
-		if startTime.Before(start) {
-			panic(fmt.Errorf("recurrence start time should not be before overall start time: %v", startTime))
+// anchorObject resolves the floating times of an event object in the given
+// location.
+func anchorObject(obj dto.EventObject, loc *time.Location) dto.EventObject {
+	out := obj
+	out.Main = anchorEvent(obj.Main, loc)
+	out.Overrides = make([]dto.Event, len(obj.Overrides))
+	for i, override := range obj.Overrides {
+		out.Overrides[i] = anchorEvent(override, loc)
+	}
+	return out
+}
+
+// calendarDays returns the number of days between the dates of two times.
+func calendarDays(from, to time.Time) int {
+	a := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	b := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	return int(b.Sub(a) / (24 * time.Hour))
+}
+
+// eventLocation returns the location the recurrences of an event are expanded
+// in, the location of the TZID of its start if it is known as nushell only
+// keeps the UTC offset of datetimes.
+func eventLocation(start events.Datetime) *time.Location {
+	if start.Timezone != "" {
+		loc, err := events.LoadTimezone(start.Timezone, nil)
+		if err == nil {
+			return loc
 		}
-		if startTime.After(end) {
-			panic(fmt.Errorf("recurrence start time should not be after overall end time: %v", startTime))
+	}
+	return start.Stamp.Location()
+}
+
+func ruleOptions(rules ...dto.RRule) (out []rrule.ROption) {
+	for _, r := range rules {
+		if r.RRule != nil {
+			out = append(out, r.OrigOptions)
 		}
-		replica := object.Main
-		dur := replica.End.Stamp.Sub(replica.Start.Stamp)
-		replica.Start.Stamp = startTime
-		replica.End.Stamp = startTime.Add(dur)
-		*out = append(*out, replica)
 	}
+	return
 }
 
-func convertToTimeline(eventList []dto.Event, start, end time.Time) (out []dto.TimeSegment) {
-	// pre-conditions
-	if end.Before(start) {
-		panic("timeline END cannot be before START")
+func stamps(list []events.Datetime, loc *time.Location) []time.Time {
+	out := make([]time.Time, len(list))
+	for i, d := range list {
+		out[i] = d.Stamp.In(loc)
+	}
+	return out
+}
+
+// recurrenceSet returns the recurrence set of the main event of an object,
+// expanded in the event's own location so that recurrences keep their wall
+// clock time across DST transitions.
+func recurrenceSet(e dto.Event) recurrence.Set {
+	loc := eventLocation(e.Start)
+	set := recurrence.Set{
+		Start:   e.Start.Stamp.In(loc),
+		AllDay:  e.Start.AllDay,
+		RRules:  ruleOptions(append([]dto.RRule{e.RecurrenceRule}, e.ExtraRecurrenceRules...)...),
+		ExRules: ruleOptions(e.ExceptionRules...),
+		RDates:  stamps(e.RecurrenceDates, loc),
+		ExDates: stamps(e.RecurrenceExceptionDates, loc),
+	}
+	switch {
+	case e.Start.AllDay:
+		// all-day events last whole days even if a day is shorter or
+		// longer because of a DST transition
+		set.Days = calendarDays(e.Start.Stamp, e.End.Stamp)
+	case e.Duration != nil:
+		set.Days = int(*e.Duration / (24 * time.Hour))
+		set.Duration = *e.Duration % (24 * time.Hour)
+	default:
+		set.Duration = e.End.Stamp.Sub(e.Start.Stamp)
+	}
+	for _, p := range e.RecurrencePeriods {
+		set.RPeriods = append(set.RPeriods, recurrence.Period{
+			Start: p.Start.Stamp.In(loc),
+			End:   p.End.Stamp.In(loc),
+		})
 	}
-	for _, e := range eventList {
-		if e.End.Stamp.Before(e.Start.Stamp) {
-			panic(fmt.Errorf("event END cannot be before the event's START: %v", e))
+	return set
+}
+
+// expandObject returns the occurrences of an event object that overlap the
+// window sorted by their start.
+func expandObject(object dto.EventObject, start, end time.Time) (out []dto.Occurrence, err error) {
+	set := recurrenceSet(object.Main)
+	recurring := len(set.RRules) > 0 || len(set.RDates) > 0 || len(set.RPeriods) > 0
+
+	overrides := make([]recurrence.Override, len(object.Overrides))
+	for i, override := range object.Overrides {
+		if override.RecurrenceInstance == nil {
+			err = fmt.Errorf("recurrence override %d does not have a recurrence instance", i)
+			return
 		}
-		if e.Start.Stamp.Before(start) {
-			panic(fmt.Errorf("event START cannot be before timeline START: %v", e))
+		overrides[i] = recurrence.Override{
+			RecurrenceID:  override.RecurrenceInstance.Stamp,
+			ThisAndFuture: override.ThisAndFuture,
+			Start:         override.Start.Stamp,
+			End:           override.End.Stamp,
 		}
 	}
 
-	if len(eventList) == 0 {
-		return nil
+	instances, err := set.Expand(overrides, start, end)
+	if err != nil {
+		return
+	}
+	out = make([]dto.Occurrence, len(instances))
+	for i, inst := range instances {
+		replica := object.Main
+		if inst.Override >= 0 {
+			replica = object.Overrides[inst.Override]
+		}
+		replica.Start.Stamp = inst.Start
+		replica.End.Stamp = inst.End
+		switch {
+		case replica.RecurrenceInstance != nil:
+			// instances after a THISANDFUTURE override are copies of it
+			id := *replica.RecurrenceInstance
+			id.Stamp = inst.RecurrenceID
+			replica.RecurrenceInstance = &id
+		case recurring:
+			id := object.Main.Start
+			id.Stamp = inst.RecurrenceID
+			replica.RecurrenceInstance = &id
+		}
+
+		occ := dto.Occurrence{
+			ObjectPath:   object.ObjectPath,
+			CalendarPath: object.CalendarPath,
+			CalendarName: object.CalendarName,
+			Start:        inst.Start,
+			End:          inst.End,
+			AllDay:       replica.Start.AllDay,
+			Override:     inst.Override >= 0,
+			Event:        replica,
+		}
+		if replica.RecurrenceInstance != nil {
+			occ.RecurrenceID = &replica.RecurrenceInstance.Stamp
+		}
+		out[i] = occ
 	}
+	return
+}
+
+func convertToTimeline(eventList []dto.Event, start, end time.Time) (out []dto.TimeSegment, err error) {
 	slices.SortFunc(eventList, func(a, b dto.Event) int {
 		if a.Start.Stamp.Before(b.Start.Stamp) {
 			return -1
@@ -133,15 +288,135 @@ func convertToTimeline(eventList []dto.Event, start, end time.Time) (out []dto.T
 		}
 		return 0
 	})
+	// events that start after the window are left out like the occurrences
+	// expanded by a heapSource, so that their problems are not reported
+	if i := slices.IndexFunc(eventList, func(e dto.Event) bool {
+		return e.Start.Stamp.After(end)
+	}); i >= 0 {
+		eventList = eventList[:i]
+	}
+	err = sweepTimeline(&sliceSource{events: eventList}, start, end, func(segment dto.TimeSegment) error {
+		out = append(out, segment)
+		return nil
+	})
+	return
+}
+
+// describeEvent names an event in warnings.
+func describeEvent(e dto.Event) string {
+	switch {
+	case e.Summary != nil:
+		return fmt.Sprintf("event %q", *e.Summary)
+	case e.Uid != nil:
+		return fmt.Sprintf("event %s", *e.Uid)
+	}
+	return fmt.Sprintf("event at %v", e.Start.Stamp)
+}
+
+// sweepTimeline emits the segments of the timeline of the events of a source
+// as soon as they are final. Events are clipped to the window, invalid events
+// are skipped and reported in the warnings of the segment they would have been
+// active in.
+func sweepTimeline(src eventSource, start, end time.Time, emit func(dto.TimeSegment) error) error {
+	if end.Before(start) {
+		return fmt.Errorf("timeline end %v cannot be before its start %v", end, start)
+	}
 
+	// warnings are kept with the time they belong to until the segment that
+	// contains it is emitted
+	type warning struct {
+		at  time.Time
+		msg string
+		// transition is set instead of msg for impossible transitions
+		transition *dto.Transition
+	}
+	var warnings []warning
+	transitions, _ := src.(transitionSource)
 	// t is the current time
 	t := start
-	// nextEventCursor points to the next event whose start_time > t (may be undefined)
-	nextEventCursor := 0
+	// next returns the next valid event of the source within the window
+	next := func() (dto.Event, bool) {
+		for {
+			// peek before taking the warnings as it skips invalid events
+			e, ok := src.peek()
+			for _, msg := range src.warnings() {
+				warnings = append(warnings, warning{at: t, msg: msg})
+			}
+			if transitions != nil {
+				for _, tr := range transitions.impossibleTransitions() {
+					warnings = append(warnings, warning{at: t, transition: &tr})
+				}
+			}
+			if !ok || e.Start.Stamp.After(end) {
+				return dto.Event{}, false
+			}
+			if e.Start.Stamp.Before(start) && !e.End.Stamp.After(start) {
+				// ends before the window
+				src.pop()
+				continue
+			}
+			if e.Start.Stamp.Before(start) {
+				e.Start.Stamp = start
+			}
+			if e.End.Stamp.After(end) {
+				e.End.Stamp = end
+			}
+			return e, true
+		}
+	}
+
+	// takeWarnings returns the messages and impossible transitions of the
+	// warnings before a time
+	takeWarnings := func(before time.Time, all bool) (msgs []string, trs []dto.Transition) {
+		var kept []warning
+		for _, w := range warnings {
+			if !all && !w.at.Before(before) {
+				kept = append(kept, w)
+				continue
+			}
+			if w.transition != nil {
+				trs = append(trs, *w.transition)
+				continue
+			}
+			msgs = append(msgs, w.msg)
+		}
+		warnings = kept
+		return
+	}
+
+	if _, ok := src.peek(); !ok {
+		msgs := src.warnings()
+		if len(msgs) == 0 {
+			return nil
+		}
+		return emit(dto.TimeSegment{
+			Now:      start,
+			Duration: end.Sub(start),
+			Warnings: msgs,
+		})
+	}
+
+	// segments are emitted one behind so that the warnings of the events
+	// skipped at the end of the window can be added to the last one
+	var pending *dto.TimeSegment
+	flush := func(next *dto.TimeSegment) error {
+		if pending != nil {
+			err := emit(*pending)
+			if err != nil {
+				return err
+			}
+		}
+		pending = next
+		return nil
+	}
+
 	// active keeps track of the events whose start_time < t && end_time > t (may be empty)
 	var active []dto.Event
+	// total and prevEmpty are used to check the post conditions
+	total := time.Duration(0)
+	prevEmpty := false
 
-	for !t.Equal(end) && !t.After(end) {
+	for t.Before(end) {
 		// --- collect active events
 
 		// remove inactive events
@@ -152,15 +427,15 @@ func convertToTimeline(eventList []dto.Event, start, end time.Time) (out []dto.T
 			}
 		}
 
-		// collect all active events and advasnce event cursor until reaching
-		// an event whose start_time > t
-		for nextEventCursor < len(eventList) {
-			e := eventList[nextEventCursor]
-			if e.Start.Stamp.After(t) {
+		// collect all active events and advance the source until reaching an
+		// event whose start_time > t
+		for {
+			e, ok := next()
+			if !ok || e.Start.Stamp.After(t) {
 				break
 			}
 			newActive = append(newActive, e)
-			nextEventCursor++
+			src.pop()
 		}
 
 		active = newActive
@@ -180,8 +455,8 @@ func convertToTimeline(eventList []dto.Event, start, end time.Time) (out []dto.T
 				hasNext = true
 			}
 		}
-		if nextEventCursor < len(eventList) {
-			dur := eventList[nextEventCursor].Start.Stamp.Sub(t)
+		if e, ok := next(); ok {
+			dur := e.Start.Stamp.Sub(t)
 			if dur < minNextDur {
 				minNextDur = dur
 				hasNext = true
@@ -192,47 +467,96 @@ func convertToTimeline(eventList []dto.Event, start, end time.Time) (out []dto.T
 			minNextDur = end.Sub(t)
 		}
 
-		out = append(out, dto.TimeSegment{
+		segment := dto.TimeSegment{
 			Now:          t,
 			Duration:     minNextDur,
 			ActiveEvents: active,
-		})
-
-		t = t.Add(minNextDur)
-	}
+		}
+		segment.Warnings, segment.ImpossibleTransitions = takeWarnings(t.Add(minNextDur), false)
+		if slices.ContainsFunc(active, isBusy) {
+			segment.Busy = 1
+		}
 
-	// post conditions
-	total := time.Duration(0)
-	for i, segment := range out {
-		total += segment.Duration
+		// post conditions
 		segmentEnd := segment.Now.Add(segment.Duration)
 		if segmentEnd.After(end) {
-			panic(fmt.Errorf("timeline segment cannot cross segment end: (idx: %d) of %+v", i, out))
+			return fmt.Errorf("timeline segment cannot cross the timeline end: %+v", segment)
 		}
 		for _, active := range segment.ActiveEvents {
 			if active.Start.Stamp.After(segmentEnd) {
-				panic(fmt.Errorf("active event cannot start after the time segment's end: (idx: %d) of %+v", i, out))
+				return fmt.Errorf("active event cannot start after the time segment's end: %+v", segment)
 			}
 			if active.End.Stamp.Before(segmentEnd) {
-				panic(fmt.Errorf("active event should not end before the time segment's end: (idx: %d) of %+v", i, out))
+				return fmt.Errorf("active event should not end before the time segment's end: %+v", segment)
 			}
 		}
-		prev := i - 1
-		if prev >= 0 && out[prev].ActiveEvents == nil && segment.ActiveEvents == nil {
-			panic(fmt.Errorf(
-				"no two consecutive time segments should both have nil active events: %v",
-				out,
-			))
+		if pending != nil && prevEmpty && segment.ActiveEvents == nil {
+			return fmt.Errorf("no two consecutive time segments should both have nil active events: %+v", segment)
+		}
+		total += segment.Duration
+		prevEmpty = segment.ActiveEvents == nil
+
+		err := flush(&segment)
+		if err != nil {
+			return err
+		}
+
+		t = t.Add(minNextDur)
+	}
+
+	// skip the events left at the end of the window for their warnings
+	for {
+		_, ok := next()
+		if !ok {
+			break
+		}
+		src.pop()
+	}
+	if msgs, trs := takeWarnings(end, true); len(msgs) > 0 || len(trs) > 0 {
+		if pending == nil {
+			pending = &dto.TimeSegment{Now: start}
 		}
+		pending.Warnings = append(pending.Warnings, msgs...)
+		pending.ImpossibleTransitions = append(pending.ImpossibleTransitions, trs...)
 	}
+
 	if total != end.Sub(start) {
-		panic(fmt.Errorf(
+		return fmt.Errorf(
 			"sum of timeline segment total != end - start: got %+v expected %+v",
 			total,
 			end.Sub(start),
-		))
+		)
 	}
+	return flush(nil)
+}
 
+// travelFlags reads the --travel and --travel-speed flags, it returns nil if
+// neither is set.
+func travelFlags(call *nu.ExecCommand) (opts *travelOptions, err error) {
+	_, hasBuffer := call.FlagValue("travel")
+	speed, hasSpeed := call.FlagValue("travel-speed")
+	if !hasBuffer && !hasSpeed {
+		return
+	}
+	opts = &travelOptions{}
+	opts.buffer, err = durationFlag(call, "travel", 0)
+	if err != nil {
+		return
+	}
+	if hasSpeed {
+		switch v := speed.Value.(type) {
+		case int64:
+			opts.speed = float64(v)
+		case float64:
+			opts.speed = v
+		default:
+			err = fmt.Errorf("--travel-speed must be a number, got %T", speed.Value)
+			return
+		}
+		if opts.speed <= 0 {
+			err = fmt.Errorf("--travel-speed must be positive, got %v", opts.speed)
+		}
+	}
 	return
 }
 
@@ -248,21 +572,85 @@ func timelineCmdExec(ctx context.Context, call *nu.ExecCommand) (err error) {
 		return
 	}
 
+	floating, err := timezoneFlag(call)
+	if err != nil {
+		return
+	}
+	bucket, err := durationFlag(call, "bucket", 0)
+	if err != nil {
+		return
+	}
+	_, hasBucket := call.FlagValue("bucket")
+	if hasBucket && bucket == 0 {
+		err = fmt.Errorf("--bucket must be longer than 0")
+		return
+	}
+	mergeBusy := false
+	v, ok := call.FlagValue("merge-busy")
+	if ok {
+		mergeBusy = v.Value.(bool)
+	}
+	if hasBucket && mergeBusy {
+		err = fmt.Errorf("cannot specify both --bucket and --merge-busy")
+		return
+	}
+
+	travel, err := travelFlags(call)
+	if err != nil {
+		return
+	}
+
 	objects, err := recvListInput(call, nuconv.EventObjectFromNu)
 	if err != nil {
 		return
 	}
 
-	var eventList []dto.Event
-	for _, obj := range objects {
-		expandEvents(&eventList, obj, start, end)
+	objects = filterKinds(objects, kindsFlags(call)...)
+	for i, obj := range objects {
+		objects[i] = anchorObject(obj, floating)
+	}
+	var src eventSource = newHeapSource(objects, start, end)
+	if travel != nil {
+		src = &travelSource{src: src, opts: *travel}
 	}
-	timeline := convertToTimeline(eventList, start, end)
 
-	out, err := nuconv.TimelineToNu(timeline)
+	output, err := call.ReturnListStream(ctx)
 	if err != nil {
 		return
 	}
-	err = call.ReturnValue(ctx, out)
+	defer close(output)
+
+	emit := func(segment dto.TimeSegment) error {
+		v, err := nuconv.TimeSegmentToNu(segment)
+		if err != nil {
+			return err
+		}
+		select {
+		case output <- v:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	var sink segmentSink
+	switch {
+	case hasBucket:
+		// whole day slots start at the same wall clock time in the timezone
+		sink = newBucketer(start.In(floating), end, bucket, emit)
+	case mergeBusy:
+		sink = &busyMerger{emit: emit}
+	}
+	if sink != nil {
+		emit = sink.add
+	}
+
+	err = sweepTimeline(src, start, end, emit)
+	if err == nil && sink != nil {
+		err = sink.flush()
+	}
+	if err != nil && ctx.Err() == nil {
+		output <- nu.ToValue(err)
+		err = nil
+	}
 	return
 }
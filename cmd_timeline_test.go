@@ -1,12 +1,17 @@
 package main
 
 import (
+	"fmt"
 	"math/rand"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
-	"github.com/LQR471814/nu_plugin_caldav/internal/events"
 	"github.com/LQR471814/nu_plugin_caldav/internal/dto"
+	"github.com/LQR471814/nu_plugin_caldav/internal/events"
+	"github.com/emersion/go-ical"
+	"github.com/teambition/rrule-go"
 )
 
 func abs[T int64 | int32 | int16 | int8](v T) T {
@@ -16,16 +21,46 @@ func abs[T int64 | int32 | int16 | int8](v T) T {
 	return v
 }
 
+// checkTimeline validates the invariants of a timeline.
+func checkTimeline(t *testing.T, segments []dto.TimeSegment, start, end time.Time) {
+	t.Helper()
+	now := start
+	total := time.Duration(0)
+	for i, segment := range segments {
+		if !segment.Now.Equal(now) {
+			t.Fatalf("segment %d: expected to start at %v, got %v", i, now, segment.Now)
+		}
+		segmentEnd := segment.Now.Add(segment.Duration)
+		if segmentEnd.After(end) {
+			t.Fatalf("segment %d: crosses the timeline end %v", i, end)
+		}
+		for _, active := range segment.ActiveEvents {
+			if active.Start.Stamp.Before(start) || active.End.Stamp.After(end) {
+				t.Fatalf("segment %d: active event %+v is not clipped to the timeline", i, active)
+			}
+			if active.Start.Stamp.After(segment.Now) || active.End.Stamp.Before(segmentEnd) {
+				t.Fatalf("segment %d: event %+v is not active for the whole segment", i, active)
+			}
+		}
+		if i > 0 && segments[i-1].ActiveEvents == nil && segment.ActiveEvents == nil {
+			t.Fatalf("segment %d: two consecutive segments have no active events", i)
+		}
+		now = segmentEnd
+		total += segment.Duration
+	}
+	if len(segments) > 0 && total != end.Sub(start) {
+		t.Fatalf("expected the segments to last %v, got %v", end.Sub(start), total)
+	}
+}
+
 func FuzzConvertToTimeline(f *testing.F) {
 	// inputs:
 	// - overall_start: int
 	// - overall_end: int
 	// - overall_end >= overall_start
 	// - list[Event]: (length: int)
-	//   - start: int
-	//   - end: int
-	//   - end >= start
-	//   - start >= overall_start
+	//   - start: int (may be before overall_start)
+	//   - end: int (may be before start, such events are invalid)
 
 	// outputs:
 	// - list[TimeSegment]:
@@ -35,19 +70,23 @@ func FuzzConvertToTimeline(f *testing.F) {
 	//   - active: list[Event]:
 	//     - let s = the current time segment
 	//     - forall e in list[Event] (e.start < s.start+s.dur & e.end >= s.start+s.dur)
+	//     - events are clipped to [overall_start, overall_end]
 	//   - no two consecutive time segments have the same active events
+	//   - warnings: one for each invalid event that starts before overall_end
 
 	f.Add(int64(0), int64(0), int32(0), uint16(0))
+	f.Add(int64(7), int64(1700000000), int32(86400), uint16(40))
 	f.Fuzz(func(t *testing.T, seed, overallStartSec int64, overallDuration int32, eventLength uint16) {
 		r := rand.New(rand.NewSource(seed))
 
 		overallStart := time.Unix(abs(overallStartSec), 0)
 		overallEnd := overallStart.Add(time.Second * time.Duration(abs(overallDuration)))
 
+		invalid := 0
 		eventList := make([]dto.Event, eventLength)
 		for i := range eventLength {
-			offset := time.Duration(abs(r.Int31())) * time.Second
-			dur := time.Duration(abs(r.Int31())) * time.Second
+			offset := time.Duration(r.Int31()-r.Int31()/4) * time.Second
+			dur := time.Duration(r.Int31()-r.Int31()/8) * time.Second
 			start := overallStart.Add(offset)
 			eventList[i] = dto.Event{
 				Start: events.Datetime{
@@ -57,10 +96,515 @@ func FuzzConvertToTimeline(f *testing.F) {
 					Stamp: start.Add(dur),
 				},
 			}
+			if dur < 0 && !start.After(overallEnd) {
+				invalid++
+			}
+		}
+
+		segments, err := convertToTimeline(eventList, overallStart, overallEnd)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkTimeline(t, segments, overallStart, overallEnd)
+
+		warnings := 0
+		for _, segment := range segments {
+			warnings += len(segment.Warnings)
+		}
+		if warnings != invalid {
+			t.Fatalf("expected %d warnings, got %d", invalid, warnings)
+		}
+	})
+}
+
+func TestConvertToTimelineClipsEvents(t *testing.T) {
+	start := time.Date(2025, 10, 20, 9, 0, 0, 0, time.UTC)
+	end := start.Add(8 * time.Hour)
+	summary := "Broken"
+	eventList := []dto.Event{
+		{
+			// started before the timeline and still running
+			Start: events.Datetime{Stamp: start.Add(-2 * time.Hour)},
+			End:   events.Datetime{Stamp: start.Add(time.Hour)},
+		},
+		{
+			// ends before it starts
+			Summary: &summary,
+			Start:   events.Datetime{Stamp: start.Add(2 * time.Hour)},
+			End:     events.Datetime{Stamp: start.Add(time.Hour)},
+		},
+		{
+			// runs past the timeline end
+			Start: events.Datetime{Stamp: end.Add(-time.Hour)},
+			End:   events.Datetime{Stamp: end.Add(time.Hour)},
+		},
+	}
+
+	segments, err := convertToTimeline(eventList, start, end)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkTimeline(t, segments, start, end)
+	if len(segments) != 3 {
+		t.Fatalf("expected 3 segments, got %+v", segments)
+	}
+	if segments[0].Duration != time.Hour || len(segments[0].ActiveEvents) != 1 ||
+		!segments[0].ActiveEvents[0].Start.Stamp.Equal(start) {
+		t.Fatalf("unexpected first segment %+v", segments[0])
+	}
+	// the source skips the invalid event when the timeline looks for the
+	// event after the first one
+	if len(segments[0].Warnings) != 1 || !strings.Contains(segments[0].Warnings[0], summary) {
+		t.Fatalf("expected a warning for the invalid event, got %+v", segments[0])
+	}
+	if !segments[2].ActiveEvents[0].End.Stamp.Equal(end) {
+		t.Fatalf("unexpected last segment %+v", segments[2])
+	}
+
+	_, err = convertToTimeline(eventList, end, start)
+	if err == nil {
+		t.Fatal("expected an error when the end is before the start")
+	}
+}
+
+func TestAnchorAllDayEvent(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// an all-day event read in another timezone, across the start of DST
+	event := dto.Event{
+		Start: events.Datetime{
+			Stamp:    time.Date(2025, 3, 8, 0, 0, 0, 0, time.UTC),
+			AllDay:   true,
+			Floating: true,
+		},
+		End: events.Datetime{
+			Stamp:    time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC),
+			AllDay:   true,
+			Floating: true,
+		},
+	}
+	anchored := anchorEvent(event, loc)
+	if !anchored.Start.Stamp.Equal(time.Date(2025, 3, 8, 0, 0, 0, 0, loc)) ||
+		!anchored.End.Stamp.Equal(time.Date(2025, 3, 10, 0, 0, 0, 0, loc)) {
+		t.Fatalf("unexpected anchored event %v - %v", anchored.Start.Stamp, anchored.End.Stamp)
+	}
+	// the event lasts 47 hours as one of its days is 23 hours long
+	if d := anchored.End.Stamp.Sub(anchored.Start.Stamp); d != 47*time.Hour {
+		t.Fatalf("unexpected duration %v", d)
+	}
+
+	start := time.Date(2025, 3, 1, 0, 0, 0, 0, loc)
+	expanded, err := expandObject(dto.EventObject{Main: anchored}, start, start.AddDate(0, 1, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(expanded) != 1 || !expanded[0].End.Equal(anchored.End.Stamp) {
+		t.Fatalf("unexpected expanded events %+v", expanded)
+	}
+}
+
+func TestAnchorFloatingEventKeepsWallClock(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	event := dto.Event{
+		Start: events.Datetime{
+			Stamp:    time.Date(2025, 6, 1, 9, 30, 0, 0, time.FixedZone("", -4*3600)),
+			Floating: true,
+		},
+		End: events.Datetime{
+			Stamp:    time.Date(2025, 6, 1, 10, 30, 0, 0, time.FixedZone("", -4*3600)),
+			Floating: true,
+		},
+	}
+	anchored := anchorEvent(event, loc)
+	if !anchored.Start.Stamp.Equal(time.Date(2025, 6, 1, 9, 30, 0, 0, loc)) {
+		t.Fatalf("unexpected start %v", anchored.Start.Stamp)
+	}
+}
+
+func TestExpandObjectOccurrences(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rule, err := rrule.NewRRule(rrule.ROption{Freq: rrule.WEEKLY, Count: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := "/calendars/work/standup.ics"
+	start := time.Date(2025, 10, 20, 9, 0, 0, 0, loc)
+	override := dto.Event{
+		Start:              events.Datetime{Stamp: start.AddDate(0, 0, 8)},
+		End:                events.Datetime{Stamp: start.AddDate(0, 0, 8).Add(time.Hour)},
+		RecurrenceInstance: &events.Datetime{Stamp: start.AddDate(0, 0, 7)},
+	}
+	object := dto.EventObject{
+		ObjectPath: &path,
+		Main: dto.Event{
+			// the start read from nushell only keeps its UTC offset
+			Start: events.Datetime{
+				Stamp:    start.In(time.FixedZone("", -4*3600)),
+				Timezone: "America/New_York",
+			},
+			End:            events.Datetime{Stamp: start.Add(time.Hour)},
+			RecurrenceRule: dto.RRule{RRule: rule},
+		},
+		Overrides: []dto.Event{override},
+	}
+
+	occurrences, err := expandObject(object, start, start.AddDate(0, 1, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(occurrences) != 3 {
+		t.Fatalf("unexpected occurrences %+v", occurrences)
+	}
+	for i, occ := range occurrences {
+		if occ.ObjectPath != &path || occ.RecurrenceID == nil || occ.Override != (i == 1) {
+			t.Fatalf("unexpected occurrence %d %+v", i, occ)
+		}
+	}
+	// the last occurrence is after the end of DST and keeps its wall clock
+	// time
+	if last := occurrences[2].Start; !last.Equal(time.Date(2025, 11, 3, 9, 0, 0, 0, loc)) {
+		t.Fatalf("unexpected last occurrence %v", last)
+	}
+	if !occurrences[1].Start.Equal(override.Start.Stamp) || !occurrences[1].RecurrenceID.Equal(start.AddDate(0, 0, 7)) {
+		t.Fatalf("unexpected override occurrence %+v", occurrences[1])
+	}
+}
+
+func TestHeapSourceMatchesExpansion(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, loc)
+	end := start.AddDate(1, 0, 0)
+
+	var objects []dto.EventObject
+	for i, freq := range []rrule.Frequency{rrule.DAILY, rrule.WEEKLY, rrule.MONTHLY} {
+		rule, err := rrule.NewRRule(rrule.ROption{Freq: freq})
+		if err != nil {
+			t.Fatal(err)
 		}
+		eventStart := start.Add(-time.Hour + time.Duration(i)*40*time.Minute)
+		objects = append(objects, dto.EventObject{Main: dto.Event{
+			Start:          events.Datetime{Stamp: eventStart},
+			End:            events.Datetime{Stamp: eventStart.Add(90 * time.Minute)},
+			RecurrenceRule: dto.RRule{RRule: rule},
+		}})
+	}
+	single := start.AddDate(0, 5, 3)
+	objects = append(objects, dto.EventObject{Main: dto.Event{
+		Start: events.Datetime{Stamp: single},
+		End:   events.Datetime{Stamp: single.Add(3 * 24 * time.Hour)},
+	}})
 
-		// all invariants are validated within the function itself which will
-		// cause the function to panic if an invariant is violated
-		convertToTimeline(eventList, overallStart, overallEnd)
+	var eventList []dto.Event
+	for _, obj := range objects {
+		occurrences, err := expandObject(obj, start, end)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, occ := range occurrences {
+			eventList = append(eventList, occ.Event)
+		}
+	}
+	expected, err := convertToTimeline(eventList, start, end)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	src := newHeapSource(objects, start, end)
+	var streamed []dto.TimeSegment
+	err = sweepTimeline(src, start, end, func(segment dto.TimeSegment) error {
+		streamed = append(streamed, segment)
+		return nil
 	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(streamed) != len(expected) {
+		t.Fatalf("expected %d segments, got %d", len(expected), len(streamed))
+	}
+	for i := range expected {
+		if !streamed[i].Now.Equal(expected[i].Now) ||
+			streamed[i].Duration != expected[i].Duration ||
+			len(streamed[i].ActiveEvents) != len(expected[i].ActiveEvents) {
+			t.Fatalf("segment %d: expected %+v, got %+v", i, expected[i], streamed[i])
+		}
+	}
+}
+
+func TestTimelineBuckets(t *testing.T) {
+	start := time.Date(2025, 10, 20, 9, 0, 0, 0, time.UTC)
+	end := start.Add(150 * time.Minute)
+	transparent := events.EVENT_TRANSPARENCY_TRANSPARENT
+	eventList := []dto.Event{
+		{
+			Start: events.Datetime{Stamp: start.Add(15 * time.Minute)},
+			End:   events.Datetime{Stamp: start.Add(45 * time.Minute)},
+		},
+		{
+			Start: events.Datetime{Stamp: start.Add(30 * time.Minute)},
+			End:   events.Datetime{Stamp: start.Add(75 * time.Minute)},
+		},
+		{
+			Transparency: &transparent,
+			Start:        events.Datetime{Stamp: start.Add(90 * time.Minute)},
+			End:          events.Datetime{Stamp: start.Add(120 * time.Minute)},
+		},
+	}
+	segments, err := convertToTimeline(eventList, start, end)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buckets []dto.TimeSegment
+	b := newBucketer(start, end, time.Hour, func(segment dto.TimeSegment) error {
+		buckets = append(buckets, segment)
+		return nil
+	})
+	for _, segment := range segments {
+		if err := b.add(segment); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := b.flush(); err != nil {
+		t.Fatal(err)
+	}
+	expected := []struct {
+		dur    time.Duration
+		busy   float64
+		active int
+	}{
+		{time.Hour, 0.75, 2},
+		{time.Hour, 0.25, 2},
+		{30 * time.Minute, 0, 0},
+	}
+	if len(buckets) != len(expected) {
+		t.Fatalf("expected %d buckets, got %+v", len(expected), buckets)
+	}
+	for i, e := range expected {
+		if !buckets[i].Now.Equal(start.Add(time.Duration(i)*time.Hour)) || buckets[i].Duration != e.dur || buckets[i].Busy != e.busy || len(buckets[i].ActiveEvents) != e.active {
+			t.Fatalf("bucket %d: expected %+v, got %+v", i, e, buckets[i])
+		}
+	}
+
+	var merged []dto.TimeSegment
+	m := &busyMerger{emit: func(segment dto.TimeSegment) error {
+		merged = append(merged, segment)
+		return nil
+	}}
+	for _, segment := range segments {
+		if err := m.add(segment); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := m.flush(); err != nil {
+		t.Fatal(err)
+	}
+	// free, busy, free, transparent, free
+	if len(merged) != 5 || merged[1].Duration != time.Hour || len(merged[1].ActiveEvents) != 2 ||
+		merged[1].Busy != 1 || merged[3].Busy != 0 {
+		t.Fatalf("unexpected merged timeline %+v", merged)
+	}
+}
+
+func TestTimelineBucketsWholeDays(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// DST ends on the 2nd of november
+	start := time.Date(2025, 11, 1, 0, 0, 0, 0, ny)
+	end := time.Date(2025, 11, 4, 0, 0, 0, 0, ny)
+	uid := "standup"
+	standup := func(day int) dto.Event {
+		s := time.Date(2025, 11, day, 9, 0, 0, 0, ny)
+		return dto.Event{
+			Uid:                &uid,
+			Start:              events.Datetime{Stamp: s},
+			End:                events.Datetime{Stamp: s.Add(time.Hour)},
+			RecurrenceInstance: &events.Datetime{Stamp: s},
+		}
+	}
+
+	var buckets []dto.TimeSegment
+	b := newBucketer(start, end, 24*time.Hour, func(segment dto.TimeSegment) error {
+		buckets = append(buckets, segment)
+		return nil
+	})
+	segments := []dto.TimeSegment{
+		// a warning of a segment on the boundary of the second day
+		{Now: time.Date(2025, 11, 2, 0, 0, 0, 0, ny), Warnings: []string{"sunday"}},
+		{Now: standup(2).Start.Stamp, Duration: 30 * time.Minute, Busy: 1, ActiveEvents: []dto.Event{standup(2)}},
+		{Now: standup(2).Start.Stamp.Add(30 * time.Minute), Duration: 30 * time.Minute, Busy: 1, ActiveEvents: []dto.Event{standup(2)}},
+		{Now: standup(3).Start.Stamp, Duration: time.Hour, Busy: 1, ActiveEvents: []dto.Event{standup(3)}},
+	}
+	for _, segment := range segments {
+		if err := b.add(segment); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := b.flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(buckets) != 3 {
+		t.Fatalf("expected 3 buckets, got %+v", buckets)
+	}
+	for i, bucket := range buckets {
+		day := time.Date(2025, 11, 1+i, 0, 0, 0, 0, ny)
+		if !bucket.Now.Equal(day) || !bucket.Now.Add(bucket.Duration).Equal(day.AddDate(0, 0, 1)) {
+			t.Errorf("bucket %d: unexpected span %v (%v)", i, bucket.Now, bucket.Duration)
+		}
+	}
+	if buckets[1].Duration != 25*time.Hour {
+		t.Errorf("expected the day DST ends on to last 25h, got %v", buckets[1].Duration)
+	}
+	if len(buckets[0].Warnings) != 0 || len(buckets[1].Warnings) != 1 {
+		t.Errorf("expected the warning in the second bucket, got %v and %v", buckets[0].Warnings, buckets[1].Warnings)
+	}
+	if buckets[1].Busy != float64(time.Hour)/float64(25*time.Hour) {
+		t.Errorf("unexpected busy ratio %v", buckets[1].Busy)
+	}
+	if len(buckets[1].ActiveEvents) != 1 || len(buckets[2].ActiveEvents) != 1 {
+		t.Errorf("expected one instance per bucket, got %+v", buckets)
+	}
+}
+
+func TestTravelSource(t *testing.T) {
+	start := time.Date(2025, 10, 20, 9, 0, 0, 0, time.UTC)
+	end := start.Add(8 * time.Hour)
+	event := func(location string, geo *events.EventGeo, from, to time.Duration) dto.Event {
+		return dto.Event{
+			Summary:  &location,
+			Location: &location,
+			Geo:      geo,
+			Start:    events.Datetime{Stamp: start.Add(from)},
+			End:      events.Datetime{Stamp: start.Add(to)},
+		}
+	}
+	// about 9.5km apart
+	hq := &events.EventGeo{Latitude: 40.7580, Longitude: -73.9855}
+	lab := &events.EventGeo{Latitude: 40.6782, Longitude: -73.9442}
+	eventList := []dto.Event{
+		event("HQ", hq, 0, time.Hour),
+		// same place
+		event("hq", hq, time.Hour, 90*time.Minute),
+		// 30km/h: 20 minutes away, leaves enough time
+		event("Lab", lab, 2*time.Hour, 3*time.Hour),
+		// no GEO, falls back to the fixed buffer which does not fit
+		event("Cafe", nil, 3*time.Hour+5*time.Minute, 4*time.Hour),
+	}
+	src := &travelSource{
+		src:  &sliceSource{events: eventList},
+		opts: travelOptions{buffer: 15 * time.Minute, speed: 30},
+	}
+	var segments []dto.TimeSegment
+	err := sweepTimeline(src, start, end, func(segment dto.TimeSegment) error {
+		segments = append(segments, segment)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkTimeline(t, segments, start, end)
+
+	var travels []string
+	var warnings []string
+	for _, segment := range segments {
+		warnings = append(warnings, segment.Warnings...)
+		for _, e := range segment.ActiveEvents {
+			if slices.Contains(e.Categories, travelCategory) {
+				travels = append(travels, fmt.Sprintf(
+					"%s %v-%v", *e.Summary,
+					e.Start.Stamp.Sub(start), e.End.Stamp.Sub(start),
+				))
+			}
+		}
+	}
+	expected := []string{
+		"Travel to Lab 1h40m0s-2h0m0s",
+		"Travel to Cafe 3h0m0s-3h5m0s",
+	}
+	if !slices.Equal(travels, expected) {
+		t.Fatalf("expected travel events %v, got %v", expected, travels)
+	}
+	if len(warnings) != 0 {
+		t.Fatalf("expected impossible transitions not to be warnings, got %v", warnings)
+	}
+
+	var transitions []dto.Transition
+	for _, segment := range segments {
+		transitions = append(transitions, segment.ImpossibleTransitions...)
+	}
+	if len(transitions) != 1 {
+		t.Fatalf("expected 1 impossible transition, got %+v", transitions)
+	}
+	tr := transitions[0]
+	if *tr.From.Summary != "Lab" || *tr.To.Summary != "Cafe" ||
+		tr.Travel != 15*time.Minute || tr.Available != 5*time.Minute {
+		t.Fatalf("unexpected transition %+v", tr)
+	}
+}
+
+func TestTimelineKinds(t *testing.T) {
+	start := time.Date(2025, 10, 20, 9, 0, 0, 0, time.UTC)
+	end := start.Add(8 * time.Hour)
+	objects := []dto.EventObject{
+		{Main: dto.Event{
+			Start: events.Datetime{Stamp: start},
+			End:   events.Datetime{Stamp: start.Add(time.Hour)},
+		}},
+		{Main: dto.Event{
+			Kind:  ical.CompToDo,
+			Start: events.Datetime{Stamp: start.Add(2 * time.Hour)},
+			End:   events.Datetime{Stamp: start.Add(4 * time.Hour)},
+		}},
+		// a todo without a start or due
+		{Main: dto.Event{Kind: ical.CompToDo}},
+		{Main: dto.Event{
+			Kind:  ical.CompJournal,
+			Start: events.Datetime{Stamp: start.Add(5 * time.Hour)},
+			End:   events.Datetime{Stamp: start.Add(5 * time.Hour)},
+		}},
+	}
+
+	if filtered := filterKinds(objects, ical.CompEvent); len(filtered) != 1 {
+		t.Fatalf("expected only the event, got %+v", filtered)
+	}
+	filtered := filterKinds(objects, ical.CompEvent, ical.CompToDo, ical.CompJournal)
+	if len(filtered) != 3 {
+		t.Fatalf("expected the todo without a time to be dropped, got %+v", filtered)
+	}
+
+	var eventList []dto.Event
+	for _, obj := range filtered {
+		eventList = append(eventList, obj.Main)
+	}
+	segments, err := convertToTimeline(eventList, start, end)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkTimeline(t, segments, start, end)
+	kinds := map[string]bool{}
+	for _, segment := range segments {
+		for _, e := range segment.ActiveEvents {
+			kinds[e.Kind] = true
+			if e.Kind != "" && segment.Busy != 0 {
+				t.Fatalf("expected %s to not be busy, got %+v", e.Kind, segment)
+			}
+		}
+	}
+	if !kinds[ical.CompToDo] || !kinds[ical.CompJournal] {
+		t.Fatalf("expected the todo and journal in the timeline, got %+v", segments)
+	}
 }
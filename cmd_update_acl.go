@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/LQR471814/nu_plugin_caldav/internal/dav"
+	"github.com/ainvaltin/nu-plugin"
+	"github.com/ainvaltin/nu-plugin/syntaxshape"
+	"github.com/ainvaltin/nu-plugin/types"
+)
+
+var updateACLCmd = &nu.Command{
+	Signature: nu.PluginSignature{
+		Name:        "caldav update acl",
+		Category:    "Network",
+		Desc:        "Sets the privileges a principal is granted by a calendar's ACL, the principal is removed from the ACL if no privileges are given, privileges denied to it are kept.",
+		SearchTerms: []string{"caldav", "update", "acl", "access", "privileges", "grant", "revoke"},
+		RequiredPositional: []nu.PositionalArg{
+			{
+				Name:  "calendar_path",
+				Desc:  "The `path` attribute of the calendar record returned by `caldav query calendars`.",
+				Shape: syntaxshape.String(),
+			},
+			{
+				Name:  "principal",
+				Desc:  "The path of a principal (see `caldav query principals`) or one of: all, authenticated, unauthenticated, self.",
+				Shape: syntaxshape.String(),
+			},
+		},
+		RestPositional: &nu.PositionalArg{
+			Name:  "privileges",
+			Desc:  "The privileges to grant, ex. read, write, read-free-busy, all.",
+			Shape: syntaxshape.String(),
+		},
+		InputOutputTypes: []nu.InOutTypes{
+			{
+				In:  types.Nothing(),
+				Out: types.Nothing(),
+			},
+		},
+	},
+	OnRun: updateACLCmdExec,
+}
+
+func init() {
+	commands = append(commands, updateACLCmd)
+}
+
+// setACLGrant replaces the privileges granted to a principal, the privileges
+// denied to it are kept. An empty list of privileges removes the principal's
+// entries unless they deny privileges.
+func setACLGrant(acl []dav.ACE, principal string, privileges []string) (out []dav.ACE, err error) {
+	entry := dav.ACE{Principal: principal}
+	for _, p := range privileges {
+		entry.Grant = append(entry.Grant, dav.Privilege(p))
+	}
+	for _, ace := range acl {
+		if ace.Principal != principal || ace.Inherited != "" {
+			out = append(out, ace)
+			continue
+		}
+		if ace.Protected {
+			err = fmt.Errorf("the ACL entry of %q is protected", principal)
+			return
+		}
+		// keep the namespace of pseudo-principals read from the server
+		entry.PrincipalName = ace.PrincipalName
+		for _, p := range ace.Deny {
+			if !slices.Contains(entry.Deny, p) {
+				entry.Deny = append(entry.Deny, p)
+			}
+		}
+	}
+	if len(entry.Grant) > 0 || len(entry.Deny) > 0 {
+		out = append(out, entry)
+	}
+	return
+}
+
+func updateACLCmdExec(ctx context.Context, call *nu.ExecCommand) (err error) {
+	calendarPath, err := tryCast[string](call.Positional[0])
+	if err != nil {
+		return
+	}
+	principal, err := tryCast[string](call.Positional[1])
+	if err != nil {
+		return
+	}
+	var privileges []string
+	for _, v := range call.Positional[2:] {
+		var privilege string
+		privilege, err = tryCast[string](v)
+		if err != nil {
+			return
+		}
+		privileges = append(privileges, privilege)
+	}
+
+	client, err := getDavClient(ctx, call)
+	if err != nil {
+		return
+	}
+	_, acl, err := client.FindAccess(ctx, calendarPath)
+	if err != nil {
+		return
+	}
+	acl, err = setACLGrant(acl, principal, privileges)
+	if err != nil {
+		return
+	}
+	err = client.SetACL(ctx, calendarPath, acl)
+	return
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"encoding/xml"
+	"slices"
+	"testing"
+
+	"github.com/LQR471814/nu_plugin_caldav/internal/dav"
+)
+
+func TestSetACLGrantKeepsDeny(t *testing.T) {
+	acl := []dav.ACE{
+		{Principal: "property:owner", Grant: []xml.Name{dav.Privilege("all")}, Protected: true},
+		{Principal: "/principals/sam/", Grant: []xml.Name{dav.Privilege("read")}, Deny: []xml.Name{dav.Privilege("write")}},
+	}
+
+	out, err := setACLGrant(acl, "/principals/sam/", []string{"read", "read-free-busy"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(out) != 2 {
+		t.Fatalf("expected 2 entries, got %+v", out)
+	}
+	sam := out[1]
+	if !slices.Equal(dav.PrivilegeNames(sam.Grant), []string{"read", "read-free-busy"}) || !slices.Equal(dav.PrivilegeNames(sam.Deny), []string{"write"}) {
+		t.Fatalf("unexpected entry %+v", sam)
+	}
+
+	// revoking the grants keeps the entry denying privileges
+	out, err = setACLGrant(acl, "/principals/sam/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(out) != 2 || len(out[1].Grant) != 0 || !slices.Equal(dav.PrivilegeNames(out[1].Deny), []string{"write"}) {
+		t.Fatalf("unexpected entries %+v", out)
+	}
+
+	if _, err = setACLGrant(acl, "property:owner", nil); err == nil {
+		t.Fatal("expected protected entries to be rejected")
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/LQR471814/nu_plugin_caldav/internal/db"
+	"github.com/ainvaltin/nu-plugin"
+	"github.com/ainvaltin/nu-plugin/syntaxshape"
+	"github.com/ainvaltin/nu-plugin/types"
+)
+
+var updateCalendarCmd = &nu.Command{
+	Signature: nu.PluginSignature{
+		Name:        "caldav update calendar",
+		Category:    "Network",
+		Desc:        "Changes the name, description, color, or timezone of a calendar.",
+		SearchTerms: []string{"caldav", "update", "calendar", "proppatch", "rename"},
+		Named: append([]nu.Flag{
+			{
+				Long:  "name",
+				Short: 'n',
+				Shape: syntaxshape.String(),
+				Desc:  "The display name of the calendar.",
+			},
+		}, calendarPropertyFlags...),
+		RequiredPositional: []nu.PositionalArg{
+			{
+				Name:  "calendar_path",
+				Desc:  "The `path` attribute of the calendar record returned by `caldav query calendars`.",
+				Shape: syntaxshape.String(),
+			},
+		},
+		InputOutputTypes: []nu.InOutTypes{
+			{
+				In:  types.Nothing(),
+				Out: types.Nothing(),
+			},
+		},
+	},
+	OnRun: updateCalendarCmdExec,
+}
+
+func init() {
+	commands = append(commands, updateCalendarCmd)
+}
+
+func updateCalendarCmdExec(ctx context.Context, call *nu.ExecCommand) (err error) {
+	calendarPath, err := tryCast[string](call.Positional[0])
+	if err != nil {
+		return
+	}
+	opts, err := calendarOptionsFromFlags(call, time.Now())
+	if err != nil {
+		return
+	}
+	opts.DisplayName, err = stringFlag(call, "name")
+	if err != nil {
+		return
+	}
+
+	client, err := getDavClient(ctx, call)
+	if err != nil {
+		return
+	}
+	err = client.UpdateCalendar(ctx, calendarPath, opts)
+	if err != nil {
+		return
+	}
+
+	driver, qry, err := db.Open(ctx)
+	if err != nil {
+		return
+	}
+	defer driver.Close()
+	err = putCachedCalendarProperties(ctx, qry, calendarPath, opts)
+	// the calendar has not been cached yet
+	if errors.Is(err, sql.ErrNoRows) {
+		err = nil
+	}
+	return
+}
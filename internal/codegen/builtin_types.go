@@ -152,8 +152,10 @@ func (s sliceBridge) GoType() reflect.Type {
 }
 
 func (s sliceBridge) TypeExpr() string {
-	child := s.router.Lookup(s.t.Elem()).GoType()
-	if s.t.Elem().Kind() == reflect.Struct {
+	bridge := s.router.Lookup(s.t.Elem())
+	child := bridge.GoType()
+	// structs bridged as scalar values (ex. dto.RRule) are lists
+	if _, ok := bridge.(structBridge); ok {
 		return fmt.Sprintf("types.Table(%s)", TypeDeclSyntaxID(child))
 	}
 	return fmt.Sprintf("types.List(%s)", TypeDeclSyntaxID(child))
@@ -21,12 +21,23 @@ func code() *Code {
 	c.AddImport("github.com/LQR471814/nu_plugin_caldav/internal/events")
 	c.AddImport("github.com/LQR471814/nu_plugin_caldav/internal/dto")
 	c.AddImport("github.com/teambition/rrule-go")
-	c.AddImport("github.com/emersion/go-webdav/caldav")
 	c.Use("EventObjectList", reflect.TypeFor[dto.EventObjectList]())
 	c.Use("EventObject", reflect.TypeFor[dto.EventObject]())
 	c.Use("Event", reflect.TypeFor[dto.Event]())
 	c.Use("Timeline", reflect.TypeFor[dto.Timeline]())
+	c.Use("TimeSegment", reflect.TypeFor[dto.TimeSegment]())
+	c.Use("OccurrenceList", reflect.TypeFor[dto.OccurrenceList]())
+	c.Use("FreeSlotList", reflect.TypeFor[dto.FreeSlotList]())
+	c.Use("ConflictList", reflect.TypeFor[dto.ConflictList]())
+	c.Use("HoursReportList", reflect.TypeFor[dto.HoursReportList]())
+	c.Use("Agenda", reflect.TypeFor[dto.Agenda]())
 	c.Use("CalendarList", reflect.TypeFor[dto.CalendarList]())
+	c.Use("PushOutcomeList", reflect.TypeFor[dto.PushOutcomeList]())
+	c.Use("CacheStatus", reflect.TypeFor[dto.CacheStatus]())
+	c.Use("CachedCalendarList", reflect.TypeFor[dto.CachedCalendarList]())
+	c.Use("SyncFailureList", reflect.TypeFor[dto.SyncFailureList]())
+	c.Use("AccessEntryList", reflect.TypeFor[dto.AccessEntryList]())
+	c.Use("PrincipalList", reflect.TypeFor[dto.PrincipalList]())
 	return c
 }
 
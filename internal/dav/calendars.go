@@ -0,0 +1,133 @@
+package dav
+
+import (
+	"context"
+	"encoding/xml"
+	"path"
+	"slices"
+	"strconv"
+)
+
+const nsCalendarServer = "http://calendarserver.org/ns/"
+
+var (
+	propResourceType    = xml.Name{Space: nsDAV, Local: "resourcetype"}
+	propOwner           = xml.Name{Space: nsDAV, Local: "owner"}
+	propSyncToken       = xml.Name{Space: nsDAV, Local: "sync-token"}
+	propPrivilegeSet    = xml.Name{Space: nsDAV, Local: "current-user-privilege-set"}
+	propMaxResourceSize = xml.Name{Space: nsCalDAV, Local: "max-resource-size"}
+	propCalendarOrder   = xml.Name{Space: nsApple, Local: "calendar-order"}
+	propCTag            = xml.Name{Space: nsCalendarServer, Local: "getctag"}
+)
+
+// Calendar is a calendar collection with the properties used by calendar
+// clients.
+type Calendar struct {
+	Path        string
+	DisplayName string
+	Description string
+	// Color is the Apple calendar-color, usually of the form #RRGGBB.
+	Color string
+	// Order is the Apple calendar-order, the position of the calendar in a
+	// list of calendars.
+	Order *int
+	// Timezone is the iCalendar object containing the VTIMEZONE of the
+	// calendar.
+	Timezone        string
+	MaxResourceSize int64
+	// SupportedComponents are the components (ex. VEVENT, VTODO) that can be
+	// stored in the calendar, empty if the server does not restrict them.
+	SupportedComponents []string
+	CTag                string
+	SyncToken           string
+	// Privileges are the privileges (ex. read, write) that the current user
+	// has on the calendar.
+	Privileges []string
+	// Owner is the path of the principal that owns the calendar.
+	Owner string
+	// ResourceTypes are the types of the collection, ex. calendar,
+	// subscribed, shared, schedule-inbox.
+	ResourceTypes []string
+}
+
+// calendarResourceTypes are the resource types of the collections returned by
+// FindCalendars.
+var calendarResourceTypes = []string{"calendar", "subscribed", "schedule-inbox", "schedule-outbox"}
+
+func newCalendar(r Resource) (cal Calendar) {
+	cal.Path = r.Path
+	cal.DisplayName, _ = r.Text(propDisplayName)
+	cal.Description, _ = r.Text(propCalendarDescription)
+	cal.Color, _ = r.Text(propCalendarColor)
+	cal.Timezone, _ = r.Text(propCalendarTimezone)
+	cal.CTag, _ = r.Text(propCTag)
+	cal.SyncToken, _ = r.Text(propSyncToken)
+	cal.Owner, _ = r.Href(propOwner)
+
+	order, ok := r.Text(propCalendarOrder)
+	if ok {
+		n, err := strconv.Atoi(order)
+		if err == nil {
+			cal.Order = &n
+		}
+	}
+	size, ok := r.Text(propMaxResourceSize)
+	if ok {
+		cal.MaxResourceSize, _ = strconv.ParseInt(size, 10, 64)
+	}
+
+	for _, comp := range r.props[propSupportedComponents].Children {
+		name := comp.attr("name")
+		if name != "" {
+			cal.SupportedComponents = append(cal.SupportedComponents, name)
+		}
+	}
+	// privileges are of the form <privilege><read/></privilege>
+	for _, privilege := range r.props[propPrivilegeSet].Children {
+		for _, p := range privilege.Children {
+			cal.Privileges = append(cal.Privileges, p.XMLName.Local)
+		}
+	}
+	for _, t := range r.Children(propResourceType) {
+		cal.ResourceTypes = append(cal.ResourceTypes, t.Local)
+	}
+	return
+}
+
+// FindCalendars lists the calendars (including subscriptions and scheduling
+// inboxes and outboxes) under a homeset.
+func (c *Client) FindCalendars(ctx context.Context, homeset string) (calendars []Calendar, err error) {
+	resources, err := c.propfind(
+		ctx, homeset, 1,
+		propResourceType,
+		propDisplayName,
+		propCalendarDescription,
+		propCalendarColor,
+		propCalendarOrder,
+		propCalendarTimezone,
+		propMaxResourceSize,
+		propSupportedComponents,
+		propCTag,
+		propSyncToken,
+		propPrivilegeSet,
+		propOwner,
+	)
+	if err != nil {
+		return
+	}
+	homesetPath := path.Clean(c.resolvePath(homeset))
+	for _, r := range resources {
+		if path.Clean(r.Path) == homesetPath {
+			continue
+		}
+		cal := newCalendar(r)
+		isCalendar := slices.ContainsFunc(cal.ResourceTypes, func(t string) bool {
+			return slices.Contains(calendarResourceTypes, t)
+		})
+		if !isCalendar {
+			continue
+		}
+		calendars = append(calendars, cal)
+	}
+	return
+}
@@ -0,0 +1,98 @@
+package dav
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+func TestFindCalendars(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Depth") != "1" {
+			t.Errorf("expected depth 1, got %q", r.Header.Get("Depth"))
+		}
+		w.WriteHeader(http.StatusMultiStatus)
+		io.WriteString(w, `<?xml version="1.0" encoding="utf-8"?>
+<d:multistatus xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav" xmlns:cs="http://calendarserver.org/ns/" xmlns:a="http://apple.com/ns/ical/">
+	<d:response>
+		<d:href>/calendars/user/</d:href>
+		<d:propstat>
+			<d:prop><d:resourcetype><d:collection/></d:resourcetype></d:prop>
+			<d:status>HTTP/1.1 200 OK</d:status>
+		</d:propstat>
+	</d:response>
+	<d:response>
+		<d:href>/calendars/user/work/</d:href>
+		<d:propstat>
+			<d:prop>
+				<d:resourcetype><d:collection/><c:calendar/><cs:shared/></d:resourcetype>
+				<d:displayname>Work</d:displayname>
+				<a:calendar-color>#ff0000</a:calendar-color>
+				<a:calendar-order>2</a:calendar-order>
+				<cs:getctag>ctag-1</cs:getctag>
+				<d:sync-token>http://example.com/sync/1</d:sync-token>
+				<d:owner><d:href>/principals/user/</d:href></d:owner>
+				<c:supported-calendar-component-set><c:comp name="VEVENT"/><c:comp name="VTODO"/></c:supported-calendar-component-set>
+				<d:current-user-privilege-set>
+					<d:privilege><d:read/></d:privilege>
+					<d:privilege><d:write/></d:privilege>
+				</d:current-user-privilege-set>
+			</d:prop>
+			<d:status>HTTP/1.1 200 OK</d:status>
+		</d:propstat>
+	</d:response>
+	<d:response>
+		<d:href>/calendars/user/inbox/</d:href>
+		<d:propstat>
+			<d:prop><d:resourcetype><d:collection/><c:schedule-inbox/></d:resourcetype></d:prop>
+			<d:status>HTTP/1.1 200 OK</d:status>
+		</d:propstat>
+	</d:response>
+	<d:response>
+		<d:href>/calendars/user/notes/</d:href>
+		<d:propstat>
+			<d:prop><d:resourcetype><d:collection/></d:resourcetype></d:prop>
+			<d:status>HTTP/1.1 200 OK</d:status>
+		</d:propstat>
+	</d:response>
+</d:multistatus>`)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.Client(), server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	calendars, err := client.FindCalendars(t.Context(), "/calendars/user/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(calendars) != 2 {
+		t.Fatalf("expected work and inbox, got %+v", calendars)
+	}
+
+	work := calendars[0]
+	if work.DisplayName != "Work" || work.Color != "#ff0000" || work.CTag != "ctag-1" {
+		t.Errorf("unexpected text properties %+v", work)
+	}
+	if work.Order == nil || *work.Order != 2 {
+		t.Errorf("unexpected order %v", work.Order)
+	}
+	if work.Owner != "/principals/user/" {
+		t.Errorf("unexpected owner %q", work.Owner)
+	}
+	if !slices.Equal(work.SupportedComponents, []string{"VEVENT", "VTODO"}) {
+		t.Errorf("unexpected components %v", work.SupportedComponents)
+	}
+	if !slices.Equal(work.Privileges, []string{"read", "write"}) {
+		t.Errorf("unexpected privileges %v", work.Privileges)
+	}
+	if !slices.Equal(work.ResourceTypes, []string{"collection", "calendar", "shared"}) {
+		t.Errorf("unexpected resource types %v", work.ResourceTypes)
+	}
+	if !slices.Contains(calendars[1].ResourceTypes, "schedule-inbox") {
+		t.Errorf("expected inbox, got %+v", calendars[1])
+	}
+}
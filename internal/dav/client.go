@@ -0,0 +1,162 @@
+// Package dav implements the WebDAV and CalDAV requests that are not supported
+// by go-webdav.
+package dav
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/emersion/go-webdav"
+)
+
+// ErrPreconditionFailed is returned when the resource on the server does not
+// match the Precondition of a request.
+var ErrPreconditionFailed = errors.New("precondition failed")
+
+// HTTPError is returned when the server responds with a non-2xx status code.
+type HTTPError struct {
+	Code    int
+	Message string
+}
+
+func (err *HTTPError) Error() string {
+	s := fmt.Sprintf("%d %s", err.Code, http.StatusText(err.Code))
+	if err.Message != "" {
+		return fmt.Sprintf("%s: %s", s, err.Message)
+	}
+	return s
+}
+
+func (err *HTTPError) Is(target error) bool {
+	return target == ErrPreconditionFailed && err.Code == http.StatusPreconditionFailed
+}
+
+// Precondition restricts a request to a particular state of the resource on
+// the server.
+type Precondition struct {
+	// IfMatch is the ETag the resource must currently have.
+	IfMatch string
+	// IfNoneMatch requires that the resource does not exist yet.
+	IfNoneMatch bool
+}
+
+func (p Precondition) apply(header http.Header) {
+	if p.IfMatch != "" {
+		header.Set("If-Match", quoteETag(p.IfMatch))
+	}
+	if p.IfNoneMatch {
+		header.Set("If-None-Match", "*")
+	}
+}
+
+// go-webdav returns ETags without their quotes
+func quoteETag(etag string) string {
+	if strings.HasPrefix(etag, "\"") || strings.HasPrefix(etag, "W/") {
+		return etag
+	}
+	return fmt.Sprintf("\"%s\"", etag)
+}
+
+func unquoteETag(etag string) string {
+	return strings.Trim(strings.TrimPrefix(etag, "W/"), "\"")
+}
+
+type Client struct {
+	http     webdav.HTTPClient
+	endpoint *url.URL
+}
+
+func NewClient(c webdav.HTTPClient, endpoint string) (*Client, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if u.Path == "" {
+		u.Path = "/"
+	}
+	return &Client{http: c, endpoint: u}, nil
+}
+
+// resolvePath returns the absolute path of p, relative paths are resolved
+// against the endpoint.
+func (c *Client) resolvePath(p string) string {
+	if !strings.HasPrefix(p, "/") {
+		p = path.Join(c.endpoint.Path, p)
+	}
+	return p
+}
+
+func (c *Client) resolve(p string) string {
+	u := url.URL{
+		Scheme: c.endpoint.Scheme,
+		User:   c.endpoint.User,
+		Host:   c.endpoint.Host,
+		Path:   c.resolvePath(p),
+	}
+	return u.String()
+}
+
+func (c *Client) do(ctx context.Context, method, p string, header http.Header, body []byte) (resp *http.Response, err error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.resolve(p), reader)
+	if err != nil {
+		return
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	resp, err = c.http.Do(req)
+	if err != nil {
+		return
+	}
+	if resp.StatusCode/100 != 2 {
+		defer resp.Body.Close()
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		err = &HTTPError{
+			Code:    resp.StatusCode,
+			Message: strings.TrimSpace(string(msg)),
+		}
+		resp = nil
+		return
+	}
+	return
+}
+
+// Put writes the given iCalendar data to a calendar object, returning the new
+// ETag of the object (if the server provides one).
+func (c *Client) Put(ctx context.Context, p string, ics []byte, cond Precondition) (etag string, err error) {
+	header := http.Header{}
+	header.Set("Content-Type", "text/calendar; charset=utf-8")
+	cond.apply(header)
+
+	resp, err := c.do(ctx, http.MethodPut, p, header, ics)
+	if err != nil {
+		return
+	}
+	resp.Body.Close()
+	etag = unquoteETag(resp.Header.Get("ETag"))
+	return
+}
+
+// Delete removes a calendar object or collection.
+func (c *Client) Delete(ctx context.Context, p string, cond Precondition) (err error) {
+	header := http.Header{}
+	cond.apply(header)
+
+	resp, err := c.do(ctx, http.MethodDelete, p, header, nil)
+	if err != nil {
+		return
+	}
+	resp.Body.Close()
+	return
+}
@@ -0,0 +1,133 @@
+package dav
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+var (
+	propCalendarDescription = xml.Name{Space: nsCalDAV, Local: "calendar-description"}
+	propCalendarTimezone    = xml.Name{Space: nsCalDAV, Local: "calendar-timezone"}
+	propSupportedComponents = xml.Name{Space: nsCalDAV, Local: "supported-calendar-component-set"}
+)
+
+// CalendarOptions are the properties of a calendar that can be set when it is
+// created or updated, nil properties are left unchanged.
+type CalendarOptions struct {
+	DisplayName *string
+	Description *string
+	// Color is the color of the calendar in the form #RRGGBB or #RRGGBBAA.
+	Color *string
+	// Timezone is an iCalendar object containing exactly one VTIMEZONE.
+	Timezone *string
+	// SupportedComponents restricts the components that can be stored in the
+	// calendar (ex. VEVENT, VTODO), it can only be set on creation.
+	SupportedComponents []string
+}
+
+func writeTextProp(buf *bytes.Buffer, name xml.Name, value string) {
+	fmt.Fprintf(buf, `<x:%s xmlns:x="%s">`, name.Local, name.Space)
+	xml.EscapeText(buf, []byte(value))
+	fmt.Fprintf(buf, `</x:%s>`, name.Local)
+}
+
+// props returns the XML of the set properties.
+func (o CalendarOptions) props() []byte {
+	var buf bytes.Buffer
+	if o.DisplayName != nil {
+		writeTextProp(&buf, propDisplayName, *o.DisplayName)
+	}
+	if o.Description != nil {
+		writeTextProp(&buf, propCalendarDescription, *o.Description)
+	}
+	if o.Color != nil {
+		writeTextProp(&buf, propCalendarColor, *o.Color)
+	}
+	if o.Timezone != nil {
+		writeTextProp(&buf, propCalendarTimezone, *o.Timezone)
+	}
+	if len(o.SupportedComponents) > 0 {
+		fmt.Fprintf(&buf, `<c:%s xmlns:c="%s">`, propSupportedComponents.Local, nsCalDAV)
+		for _, comp := range o.SupportedComponents {
+			buf.WriteString(`<c:comp name="`)
+			xml.EscapeText(&buf, []byte(comp))
+			buf.WriteString(`"/>`)
+		}
+		fmt.Fprintf(&buf, `</c:%s>`, propSupportedComponents.Local)
+	}
+	return buf.Bytes()
+}
+
+// MakeCalendar creates a new calendar collection at the given path.
+func (c *Client) MakeCalendar(ctx context.Context, p string, opts CalendarOptions) (err error) {
+	var body bytes.Buffer
+	body.WriteString(xml.Header)
+	fmt.Fprintf(&body, `<c:mkcalendar xmlns:c="%s" xmlns:d="%s"><d:set><d:prop>`, nsCalDAV, nsDAV)
+	body.Write(opts.props())
+	body.WriteString(`</d:prop></d:set></c:mkcalendar>`)
+
+	header := http.Header{}
+	header.Set("Content-Type", "application/xml; charset=utf-8")
+	resp, err := c.do(ctx, "MKCALENDAR", p, header, body.Bytes())
+	if err != nil {
+		return
+	}
+	resp.Body.Close()
+	return
+}
+
+// UpdateCalendar changes the properties of an existing calendar collection.
+func (c *Client) UpdateCalendar(ctx context.Context, p string, opts CalendarOptions) (err error) {
+	if len(opts.SupportedComponents) > 0 {
+		return fmt.Errorf("the supported components of a calendar cannot be changed")
+	}
+	props := opts.props()
+	if len(props) == 0 {
+		return
+	}
+
+	var body bytes.Buffer
+	body.WriteString(xml.Header)
+	fmt.Fprintf(&body, `<d:propertyupdate xmlns:d="%s"><d:set><d:prop>`, nsDAV)
+	body.Write(props)
+	body.WriteString(`</d:prop></d:set></d:propertyupdate>`)
+
+	header := http.Header{}
+	header.Set("Content-Type", "application/xml; charset=utf-8")
+	resp, err := c.do(ctx, "PROPPATCH", p, header, body.Bytes())
+	if err != nil {
+		return
+	}
+	defer resp.Body.Close()
+
+	// a 207 response reports the status of every property, they are either
+	// all set or none of them are
+	if resp.StatusCode != http.StatusMultiStatus {
+		return
+	}
+	var ms multistatus
+	err = xml.NewDecoder(resp.Body).Decode(&ms)
+	if err != nil {
+		err = fmt.Errorf("decode multistatus: %w", err)
+		return
+	}
+	var failed []string
+	for _, r := range ms.Responses {
+		for _, ps := range r.Propstats {
+			if strings.Contains(ps.Status, " 200") {
+				continue
+			}
+			for _, prop := range ps.Prop.Props {
+				failed = append(failed, fmt.Sprintf("%s (%s)", prop.XMLName.Local, ps.Status))
+			}
+		}
+	}
+	if len(failed) > 0 {
+		err = fmt.Errorf("update calendar properties: %s", strings.Join(failed, ", "))
+	}
+	return
+}
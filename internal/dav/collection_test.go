@@ -0,0 +1,70 @@
+package dav
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMakeCalendar(t *testing.T) {
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "MKCALENDAR" || r.URL.Path != "/calendars/user/new/" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.Client(), server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	name := "Work & Play"
+	err = client.MakeCalendar(t.Context(), "/calendars/user/new/", CalendarOptions{
+		DisplayName:         &name,
+		SupportedComponents: []string{"VEVENT"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{
+		"<x:displayname xmlns:x=\"DAV:\">Work &amp; Play</x:displayname>",
+		"<c:comp name=\"VEVENT\"/>",
+	} {
+		if !strings.Contains(body, expected) {
+			t.Errorf("expected body to contain %q, got %s", expected, body)
+		}
+	}
+}
+
+func TestUpdateCalendarReportsFailedProperties(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusMultiStatus)
+		io.WriteString(w, `<?xml version="1.0" encoding="utf-8"?>
+<d:multistatus xmlns:d="DAV:" xmlns:a="http://apple.com/ns/ical/">
+	<d:response>
+		<d:href>/calendars/user/work/</d:href>
+		<d:propstat>
+			<d:prop><a:calendar-color/></d:prop>
+			<d:status>HTTP/1.1 403 Forbidden</d:status>
+		</d:propstat>
+	</d:response>
+</d:multistatus>`)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.Client(), server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	color := "#ff0000"
+	err = client.UpdateCalendar(t.Context(), "/calendars/user/work/", CalendarOptions{Color: &color})
+	if err == nil || !strings.Contains(err.Error(), "calendar-color") {
+		t.Fatalf("expected calendar-color to be reported, got %v", err)
+	}
+}
@@ -0,0 +1,63 @@
+package dav
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+)
+
+var (
+	propCalendarUserAddressSet = xml.Name{Space: nsCalDAV, Local: "calendar-user-address-set"}
+	propCalendarHomeSet        = xml.Name{Space: nsCalDAV, Local: "calendar-home-set"}
+)
+
+// Principal is a user or group on the server.
+type Principal struct {
+	Path        string
+	DisplayName string
+	// Addresses are the calendar user addresses of the principal, usually of
+	// the form mailto:user@example.com.
+	Addresses       []string
+	CalendarHomeSet string
+}
+
+// SearchPrincipals finds the principals whose display name or calendar user
+// address contain the given text, p is any resource on the server (ex. the
+// current user's principal).
+func (c *Client) SearchPrincipals(ctx context.Context, p string, search string) (principals []Principal, err error) {
+	var body bytes.Buffer
+	body.WriteString(xml.Header)
+	fmt.Fprintf(&body, `<d:principal-property-search xmlns:d="%s" xmlns:c="%s" test="anyof">`, nsDAV, nsCalDAV)
+	for _, prop := range []xml.Name{propDisplayName, propCalendarUserAddressSet} {
+		fmt.Fprintf(&body, `<d:property-search><d:prop><x:%s xmlns:x="%s"/></d:prop><d:match>`, prop.Local, prop.Space)
+		xml.EscapeText(&body, []byte(search))
+		body.WriteString(`</d:match></d:property-search>`)
+	}
+	body.WriteString(`<d:prop><d:displayname/><c:calendar-user-address-set/><c:calendar-home-set/></d:prop>`)
+	body.WriteString(`<d:apply-to-principal-collection-set/>`)
+	body.WriteString(`</d:principal-property-search>`)
+
+	header := http.Header{}
+	header.Set("Content-Type", "application/xml; charset=utf-8")
+	header.Set("Depth", "0")
+	resp, err := c.do(ctx, "REPORT", p, header, body.Bytes())
+	if err != nil {
+		return
+	}
+	defer resp.Body.Close()
+
+	resources, err := decodeMultistatus(resp.Body)
+	if err != nil {
+		return
+	}
+	for _, r := range resources {
+		principal := Principal{Path: r.Path}
+		principal.DisplayName, _ = r.Text(propDisplayName)
+		principal.Addresses = r.Hrefs(propCalendarUserAddressSet)
+		principal.CalendarHomeSet, _ = r.Href(propCalendarHomeSet)
+		principals = append(principals, principal)
+	}
+	return
+}
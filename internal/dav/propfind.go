@@ -0,0 +1,196 @@
+package dav
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	nsDAV    = "DAV:"
+	nsCalDAV = "urn:ietf:params:xml:ns:caldav"
+	nsApple  = "http://apple.com/ns/ical/"
+)
+
+var elemHref = xml.Name{Space: nsDAV, Local: "href"}
+
+var (
+	propDisplayName   = xml.Name{Space: nsDAV, Local: "displayname"}
+	propCalendarColor = xml.Name{Space: nsApple, Local: "calendar-color"}
+)
+
+type multistatus struct {
+	XMLName   xml.Name   `xml:"DAV: multistatus"`
+	Responses []response `xml:"DAV: response"`
+}
+
+type response struct {
+	Href      string     `xml:"DAV: href"`
+	Propstats []propstat `xml:"DAV: propstat"`
+}
+
+type propstat struct {
+	Prop   rawProps `xml:"DAV: prop"`
+	Status string   `xml:"DAV: status"`
+}
+
+type rawProps struct {
+	Props []element `xml:",any"`
+}
+
+// element is an arbitrary XML element, it is decoded as a tree so that the
+// namespaces of its children are resolved.
+type element struct {
+	XMLName  xml.Name
+	Attrs    []xml.Attr `xml:",any,attr"`
+	Children []element  `xml:",any"`
+	Text     string     `xml:",chardata"`
+}
+
+// child returns the first child element with the given name.
+func (e element) child(name xml.Name) (child element, ok bool) {
+	for _, c := range e.Children {
+		if c.XMLName == name {
+			return c, true
+		}
+	}
+	return
+}
+
+func (e element) attr(local string) string {
+	for _, a := range e.Attrs {
+		if a.Name.Local == local {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+// Resource is a resource returned by a PROPFIND request with the properties
+// that the server found.
+type Resource struct {
+	Path  string
+	props map[xml.Name]element
+}
+
+// Text returns the text content of a property.
+func (r Resource) Text(name xml.Name) (text string, ok bool) {
+	prop, ok := r.props[name]
+	if !ok {
+		return
+	}
+	text = strings.TrimSpace(prop.Text)
+	return
+}
+
+// Children returns the names of the child elements of a property, ex. the
+// types of a DAV:resourcetype.
+func (r Resource) Children(name xml.Name) (children []xml.Name) {
+	for _, child := range r.props[name].Children {
+		children = append(children, child.XMLName)
+	}
+	return
+}
+
+// Href returns the DAV:href contained in a property, ex. the DAV:owner.
+func (r Resource) Href(name xml.Name) (href string, ok bool) {
+	child, ok := r.props[name].child(elemHref)
+	href = strings.TrimSpace(child.Text)
+	return
+}
+
+// Hrefs returns all the DAV:href contained in a property.
+func (r Resource) Hrefs(name xml.Name) (hrefs []string) {
+	for _, child := range r.props[name].Children {
+		if child.XMLName == elemHref {
+			hrefs = append(hrefs, strings.TrimSpace(child.Text))
+		}
+	}
+	return
+}
+
+func propfindBody(names []xml.Name) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	buf.WriteString(`<propfind xmlns="DAV:"><prop>`)
+	for _, name := range names {
+		fmt.Fprintf(&buf, `<x:%s xmlns:x="%s"/>`, name.Local, name.Space)
+	}
+	buf.WriteString(`</prop></propfind>`)
+	return buf.Bytes()
+}
+
+// propfind requests the given properties of a resource (depth 0) or of the
+// members of a collection (depth 1).
+func (c *Client) propfind(ctx context.Context, p string, depth int, names ...xml.Name) (resources []Resource, err error) {
+	header := http.Header{}
+	header.Set("Content-Type", "application/xml; charset=utf-8")
+	header.Set("Depth", fmt.Sprint(depth))
+
+	resp, err := c.do(ctx, "PROPFIND", p, header, propfindBody(names))
+	if err != nil {
+		return
+	}
+	defer resp.Body.Close()
+	return decodeMultistatus(resp.Body)
+}
+
+// decodeMultistatus decodes the resources in a 207 Multi-Status response.
+func decodeMultistatus(body io.Reader) (resources []Resource, err error) {
+	var ms multistatus
+	err = xml.NewDecoder(body).Decode(&ms)
+	if err != nil {
+		err = fmt.Errorf("decode multistatus: %w", err)
+		return
+	}
+	for _, r := range ms.Responses {
+		resource := Resource{
+			Path:  r.Href,
+			props: map[xml.Name]element{},
+		}
+		u, parseErr := url.Parse(r.Href)
+		if parseErr == nil {
+			resource.Path = u.Path
+		}
+		for _, ps := range r.Propstats {
+			// properties the server does not have are reported with 404
+			if !strings.Contains(ps.Status, " 200") {
+				continue
+			}
+			for _, prop := range ps.Prop.Props {
+				resource.props[prop.XMLName] = prop
+			}
+		}
+		resources = append(resources, resource)
+	}
+	return
+}
+
+// CalendarProperties are the properties of a calendar that go-webdav does not
+// read.
+type CalendarProperties struct {
+	DisplayName string
+	// Color is the color of the calendar as set by Apple clients, usually of
+	// the form #RRGGBB or #RRGGBBAA.
+	Color string
+}
+
+// FindCalendarProperties reads the display name and color of a calendar.
+func (c *Client) FindCalendarProperties(ctx context.Context, p string) (props CalendarProperties, err error) {
+	resources, err := c.propfind(ctx, p, 0, propDisplayName, propCalendarColor)
+	if err != nil {
+		return
+	}
+	if len(resources) == 0 {
+		err = fmt.Errorf("no properties returned for %q", p)
+		return
+	}
+	props.DisplayName, _ = resources[0].Text(propDisplayName)
+	props.Color, _ = resources[0].Text(propCalendarColor)
+	return
+}
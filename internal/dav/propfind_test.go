@@ -0,0 +1,52 @@
+package dav
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestFindCalendarProperties(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PROPFIND" || r.Header.Get("Depth") != "0" {
+			t.Errorf("unexpected request %s depth %q", r.Method, r.Header.Get("Depth"))
+		}
+		body, _ := io.ReadAll(r.Body)
+		if !strings.Contains(string(body), "calendar-color") {
+			t.Errorf("calendar-color not requested: %s", body)
+		}
+		w.WriteHeader(http.StatusMultiStatus)
+		io.WriteString(w, `<?xml version="1.0" encoding="utf-8"?>
+<d:multistatus xmlns:d="DAV:" xmlns:a="http://apple.com/ns/ical/">
+	<d:response>
+		<d:href>/calendars/user/work/</d:href>
+		<d:propstat>
+			<d:prop><d:displayname>Work</d:displayname></d:prop>
+			<d:status>HTTP/1.1 200 OK</d:status>
+		</d:propstat>
+		<d:propstat>
+			<d:prop><a:calendar-color/></d:prop>
+			<d:status>HTTP/1.1 404 Not Found</d:status>
+		</d:propstat>
+	</d:response>
+</d:multistatus>`)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.Client(), server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	props, err := client.FindCalendarProperties(t.Context(), "/calendars/user/work/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if props.DisplayName != "Work" {
+		t.Fatalf("expected display name Work, got %q", props.DisplayName)
+	}
+	if props.Color != "" {
+		t.Fatalf("expected no color, got %q", props.Color)
+	}
+}
@@ -0,0 +1,345 @@
+package dav
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+var (
+	propInvite = xml.Name{Space: nsCalendarServer, Local: "invite"}
+	propACL    = xml.Name{Space: nsDAV, Local: "acl"}
+
+	elemUser       = xml.Name{Space: nsCalendarServer, Local: "user"}
+	elemCommonName = xml.Name{Space: nsCalendarServer, Local: "common-name"}
+	elemAccess     = xml.Name{Space: nsCalendarServer, Local: "access"}
+	elemReadWrite  = xml.Name{Space: nsCalendarServer, Local: "read-write"}
+
+	elemPrincipal = xml.Name{Space: nsDAV, Local: "principal"}
+	elemGrant     = xml.Name{Space: nsDAV, Local: "grant"}
+	elemDeny      = xml.Name{Space: nsDAV, Local: "deny"}
+	elemProtected = xml.Name{Space: nsDAV, Local: "protected"}
+	elemInherited = xml.Name{Space: nsDAV, Local: "inherited"}
+	elemProperty  = xml.Name{Space: nsDAV, Local: "property"}
+)
+
+// inviteStatuses maps the CalendarServer invite status elements to the status
+// of a Sharee.
+var inviteStatuses = map[string]string{
+	"invite-accepted":   "accepted",
+	"invite-noresponse": "pending",
+	"invite-declined":   "declined",
+	"invite-invalid":    "invalid",
+}
+
+// Sharee is a user a calendar was shared with through CalendarServer sharing.
+type Sharee struct {
+	// Href is usually of the form mailto:user@example.com or a principal
+	// path.
+	Href       string
+	CommonName string
+	// ReadWrite is true if the sharee can modify the calendar.
+	ReadWrite bool
+	// Status is one of: accepted, pending, declined, invalid.
+	Status string
+}
+
+// ACE is an access control entry of a WebDAV ACL.
+type ACE struct {
+	// Principal is the path of a principal, one of the pseudo-principals:
+	// all, authenticated, unauthenticated, self, or a property of the
+	// resource containing a principal in the form property:<name> (ex.
+	// property:owner).
+	Principal string
+	// PrincipalName is the element of a pseudo-principal or of the property
+	// containing a principal, it is empty for paths. Entries read from the
+	// server keep the namespace of their elements so that they are written
+	// back unchanged.
+	PrincipalName xml.Name
+	Grant         []xml.Name
+	Deny          []xml.Name
+	// Protected entries cannot be changed.
+	Protected bool
+	// Inherited is the path of the resource the entry is inherited from.
+	Inherited string
+}
+
+func newSharee(user element) (sharee Sharee) {
+	href, _ := user.child(elemHref)
+	sharee.Href = strings.TrimSpace(href.Text)
+	name, _ := user.child(elemCommonName)
+	sharee.CommonName = strings.TrimSpace(name.Text)
+	access, _ := user.child(elemAccess)
+	_, sharee.ReadWrite = access.child(elemReadWrite)
+	for _, c := range user.Children {
+		status, ok := inviteStatuses[c.XMLName.Local]
+		if ok && c.XMLName.Space == nsCalendarServer {
+			sharee.Status = status
+		}
+	}
+	return
+}
+
+// caldavPrivileges are the privileges defined by CalDAV and CalDAV
+// scheduling, other privileges are assumed to be defined by WebDAV.
+var caldavPrivileges = []string{
+	"read-free-busy",
+	"schedule-deliver", "schedule-deliver-invite", "schedule-deliver-reply", "schedule-query-freebusy",
+	"schedule-send", "schedule-send-invite", "schedule-send-reply", "schedule-send-freebusy",
+}
+
+// Privilege returns the element of the privilege with the given name.
+func Privilege(name string) xml.Name {
+	if slices.Contains(caldavPrivileges, name) {
+		return xml.Name{Space: nsCalDAV, Local: name}
+	}
+	return xml.Name{Space: nsDAV, Local: name}
+}
+
+// PrivilegeNames returns the names of privileges without their namespaces.
+func PrivilegeNames(privileges []xml.Name) (names []string) {
+	for _, p := range privileges {
+		names = append(names, p.Local)
+	}
+	return
+}
+
+func readPrivileges(e element) (privileges []xml.Name) {
+	for _, privilege := range e.Children {
+		for _, p := range privilege.Children {
+			privileges = append(privileges, p.XMLName)
+		}
+	}
+	return
+}
+
+func newACE(ace element) (out ACE) {
+	principal, _ := ace.child(elemPrincipal)
+	for _, c := range principal.Children {
+		switch c.XMLName {
+		case elemHref:
+			out.Principal = strings.TrimSpace(c.Text)
+		case elemProperty:
+			if len(c.Children) > 0 {
+				out.Principal = "property:" + c.Children[0].XMLName.Local
+				out.PrincipalName = c.Children[0].XMLName
+			}
+		default:
+			out.Principal = c.XMLName.Local
+			out.PrincipalName = c.XMLName
+		}
+	}
+	grant, _ := ace.child(elemGrant)
+	out.Grant = readPrivileges(grant)
+	deny, _ := ace.child(elemDeny)
+	out.Deny = readPrivileges(deny)
+	_, out.Protected = ace.child(elemProtected)
+	inherited, _ := ace.child(elemInherited)
+	href, _ := inherited.child(elemHref)
+	out.Inherited = strings.TrimSpace(href.Text)
+	return
+}
+
+// FindAccess reads the sharees and the ACL of a calendar, properties the
+// server does not support (or the user may not read) are left empty.
+func (c *Client) FindAccess(ctx context.Context, p string) (sharees []Sharee, acl []ACE, err error) {
+	resources, err := c.propfind(ctx, p, 0, propInvite, propACL)
+	if err != nil {
+		return
+	}
+	if len(resources) == 0 {
+		err = fmt.Errorf("no properties returned for %q", p)
+		return
+	}
+	r := resources[0]
+	for _, user := range r.props[propInvite].Children {
+		if user.XMLName == elemUser {
+			sharees = append(sharees, newSharee(user))
+		}
+	}
+	for _, ace := range r.props[propACL].Children {
+		acl = append(acl, newACE(ace))
+	}
+	return
+}
+
+func (c *Client) postShare(ctx context.Context, p string, inner []byte) (err error) {
+	var body bytes.Buffer
+	body.WriteString(xml.Header)
+	fmt.Fprintf(&body, `<cs:share xmlns:cs="%s" xmlns:d="%s">`, nsCalendarServer, nsDAV)
+	body.Write(inner)
+	body.WriteString(`</cs:share>`)
+
+	header := http.Header{}
+	header.Set("Content-Type", "application/xml; charset=utf-8")
+	resp, err := c.do(ctx, http.MethodPost, p, header, body.Bytes())
+	if err != nil {
+		return
+	}
+	resp.Body.Close()
+	return
+}
+
+// Share invites a user to a calendar, or changes the access of an existing
+// sharee.
+func (c *Client) Share(ctx context.Context, p string, sharee Sharee, summary string) (err error) {
+	var buf bytes.Buffer
+	buf.WriteString(`<cs:set><d:href>`)
+	xml.EscapeText(&buf, []byte(sharee.Href))
+	buf.WriteString(`</d:href>`)
+	if sharee.CommonName != "" {
+		buf.WriteString(`<cs:common-name>`)
+		xml.EscapeText(&buf, []byte(sharee.CommonName))
+		buf.WriteString(`</cs:common-name>`)
+	}
+	if summary != "" {
+		buf.WriteString(`<cs:summary>`)
+		xml.EscapeText(&buf, []byte(summary))
+		buf.WriteString(`</cs:summary>`)
+	}
+	if sharee.ReadWrite {
+		buf.WriteString(`<cs:read-write/>`)
+	} else {
+		buf.WriteString(`<cs:read/>`)
+	}
+	buf.WriteString(`</cs:set>`)
+	return c.postShare(ctx, p, buf.Bytes())
+}
+
+// Unshare removes a sharee from a calendar.
+func (c *Client) Unshare(ctx context.Context, p string, href string) (err error) {
+	var buf bytes.Buffer
+	buf.WriteString(`<cs:remove><d:href>`)
+	xml.EscapeText(&buf, []byte(href))
+	buf.WriteString(`</d:href></cs:remove>`)
+	return c.postShare(ctx, p, buf.Bytes())
+}
+
+var pseudoPrincipals = []string{"all", "authenticated", "unauthenticated", "self"}
+
+// isXMLName reports whether name can be used as the local name of an
+// element.
+func isXMLName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, r := range name {
+		if unicode.IsLetter(r) || r == '_' {
+			continue
+		}
+		if i > 0 && (unicode.IsDigit(r) || r == '-' || r == '.') {
+			continue
+		}
+		return false
+	}
+	return true
+}
+
+// writeEmptyElement writes an empty element with the given name, elements
+// outside of the DAV: namespace declare their own.
+func writeEmptyElement(buf *bytes.Buffer, name xml.Name) {
+	switch name.Space {
+	case nsDAV:
+		fmt.Fprintf(buf, `<d:%s/>`, name.Local)
+	case "":
+		fmt.Fprintf(buf, `<%s xmlns=""/>`, name.Local)
+	default:
+		fmt.Fprintf(buf, `<x:%s xmlns:x="`, name.Local)
+		xml.EscapeText(buf, []byte(name.Space))
+		buf.WriteString(`"/>`)
+	}
+}
+
+func writePrincipal(buf *bytes.Buffer, ace ACE) (err error) {
+	property := strings.HasPrefix(ace.Principal, "property:")
+	name := ace.PrincipalName
+	if name.Local == "" {
+		switch {
+		case property:
+			name = xml.Name{Space: nsDAV, Local: strings.TrimPrefix(ace.Principal, "property:")}
+		case slices.Contains(pseudoPrincipals, ace.Principal):
+			name = xml.Name{Space: nsDAV, Local: ace.Principal}
+		default:
+			buf.WriteString(`<d:principal><d:href>`)
+			xml.EscapeText(buf, []byte(ace.Principal))
+			buf.WriteString(`</d:href></d:principal>`)
+			return
+		}
+	}
+	if !isXMLName(name.Local) {
+		return fmt.Errorf("invalid principal %q", ace.Principal)
+	}
+
+	buf.WriteString(`<d:principal>`)
+	if property {
+		buf.WriteString(`<d:property>`)
+		writeEmptyElement(buf, name)
+		buf.WriteString(`</d:property>`)
+	} else {
+		writeEmptyElement(buf, name)
+	}
+	buf.WriteString(`</d:principal>`)
+	return
+}
+
+func writePrivileges(buf *bytes.Buffer, privileges []xml.Name) (err error) {
+	for _, p := range privileges {
+		if !isXMLName(p.Local) {
+			return fmt.Errorf("invalid privilege %q", p.Local)
+		}
+		buf.WriteString(`<d:privilege>`)
+		writeEmptyElement(buf, p)
+		buf.WriteString(`</d:privilege>`)
+	}
+	return
+}
+
+// SetACL replaces the ACL of a resource, protected and inherited entries
+// cannot be changed so they are skipped.
+func (c *Client) SetACL(ctx context.Context, p string, acl []ACE) (err error) {
+	var body bytes.Buffer
+	body.WriteString(xml.Header)
+	fmt.Fprintf(&body, `<d:acl xmlns:d="%s">`, nsDAV)
+	for _, ace := range acl {
+		if ace.Protected || ace.Inherited != "" {
+			continue
+		}
+		body.WriteString(`<d:ace>`)
+		err = writePrincipal(&body, ace)
+		if err != nil {
+			return
+		}
+		if len(ace.Grant) > 0 {
+			body.WriteString(`<d:grant>`)
+			err = writePrivileges(&body, ace.Grant)
+			if err != nil {
+				return
+			}
+			body.WriteString(`</d:grant>`)
+		}
+		if len(ace.Deny) > 0 {
+			body.WriteString(`<d:deny>`)
+			err = writePrivileges(&body, ace.Deny)
+			if err != nil {
+				return
+			}
+			body.WriteString(`</d:deny>`)
+		}
+		body.WriteString(`</d:ace>`)
+	}
+	body.WriteString(`</d:acl>`)
+
+	header := http.Header{}
+	header.Set("Content-Type", "application/xml; charset=utf-8")
+	resp, err := c.do(ctx, "ACL", p, header, body.Bytes())
+	if err != nil {
+		return
+	}
+	resp.Body.Close()
+	return
+}
@@ -0,0 +1,213 @@
+package dav
+
+import (
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestFindAccess(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusMultiStatus)
+		io.WriteString(w, `<?xml version="1.0" encoding="utf-8"?>
+<d:multistatus xmlns:d="DAV:" xmlns:cs="http://calendarserver.org/ns/">
+	<d:response>
+		<d:href>/calendars/user/work/</d:href>
+		<d:propstat>
+			<d:prop>
+				<cs:invite>
+					<cs:organizer><d:href>/principals/user/</d:href></cs:organizer>
+					<cs:user>
+						<d:href>mailto:alice@example.com</d:href>
+						<cs:common-name>Alice</cs:common-name>
+						<cs:invite-accepted/>
+						<cs:access><cs:read-write/></cs:access>
+					</cs:user>
+				</cs:invite>
+				<d:acl>
+					<d:ace>
+						<d:principal><d:property><d:owner/></d:property></d:principal>
+						<d:grant><d:privilege><d:all/></d:privilege></d:grant>
+						<d:protected/>
+					</d:ace>
+					<d:ace>
+						<d:principal><d:href>/principals/bob/</d:href></d:principal>
+						<d:grant><d:privilege><d:read/></d:privilege></d:grant>
+					</d:ace>
+				</d:acl>
+			</d:prop>
+			<d:status>HTTP/1.1 200 OK</d:status>
+		</d:propstat>
+	</d:response>
+</d:multistatus>`)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.Client(), server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sharees, acl, err := client.FindAccess(t.Context(), "/calendars/user/work/")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedSharee := Sharee{
+		Href:       "mailto:alice@example.com",
+		CommonName: "Alice",
+		ReadWrite:  true,
+		Status:     "accepted",
+	}
+	if len(sharees) != 1 || sharees[0] != expectedSharee {
+		t.Fatalf("unexpected sharees %+v", sharees)
+	}
+	if len(acl) != 2 {
+		t.Fatalf("unexpected acl %+v", acl)
+	}
+	if acl[0].Principal != "property:owner" || !acl[0].Protected {
+		t.Errorf("unexpected owner entry %+v", acl[0])
+	}
+	if acl[1].Principal != "/principals/bob/" || !slices.Equal(acl[1].Grant, []xml.Name{{Space: nsDAV, Local: "read"}}) {
+		t.Errorf("unexpected bob entry %+v", acl[1])
+	}
+}
+
+func TestSetACLSkipsProtectedEntries(t *testing.T) {
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "ACL" {
+			t.Errorf("unexpected method %s", r.Method)
+		}
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.Client(), server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = client.SetACL(t.Context(), "/calendars/user/work/", []ACE{
+		{Principal: "property:owner", Grant: []xml.Name{Privilege("all")}, Protected: true},
+		{Principal: "authenticated", Grant: []xml.Name{Privilege("read-free-busy")}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(body, "owner") {
+		t.Errorf("expected protected entry to be skipped, got %s", body)
+	}
+	for _, expected := range []string{
+		"<d:principal><d:authenticated/></d:principal>",
+		`<x:read-free-busy xmlns:x="urn:ietf:params:xml:ns:caldav"/>`,
+	} {
+		if !strings.Contains(body, expected) {
+			t.Errorf("expected body to contain %q, got %s", expected, body)
+		}
+	}
+}
+
+func TestSetACLRejectsInvalidNames(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.Client(), server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, ace := range []ACE{
+		{Principal: `property:owner/><d:all`, Grant: []xml.Name{Privilege("read")}},
+		{Principal: "authenticated", Grant: []xml.Name{Privilege(`read"/><x:all`)}},
+		{Principal: "authenticated", Deny: []xml.Name{Privilege("write bind")}},
+	} {
+		err = client.SetACL(t.Context(), "/calendars/user/work/", []ACE{ace})
+		if err == nil {
+			t.Errorf("expected an error for %+v", ace)
+		}
+	}
+}
+
+func TestSetACLEscapesHrefs(t *testing.T) {
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.Client(), server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = client.SetACL(t.Context(), "/calendars/user/work/", []ACE{
+		{Principal: "/principals/a&b<c>/", Grant: []xml.Name{Privilege("read")}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "<d:href>/principals/a&amp;b&lt;c&gt;/</d:href>"
+	if !strings.Contains(body, expected) {
+		t.Errorf("expected body to contain %q, got %s", expected, body)
+	}
+}
+
+func TestSetACLKeepsNamespaces(t *testing.T) {
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "ACL" {
+			b, _ := io.ReadAll(r.Body)
+			body = string(b)
+			return
+		}
+		w.WriteHeader(http.StatusMultiStatus)
+		io.WriteString(w, `<?xml version="1.0" encoding="utf-8"?>
+<d:multistatus xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav" xmlns:v="urn:example:vendor">
+	<d:response>
+		<d:href>/calendars/user/work/</d:href>
+		<d:propstat>
+			<d:prop>
+				<d:acl>
+					<d:ace>
+						<d:principal><v:group/></d:principal>
+						<d:grant>
+							<d:privilege><c:schedule-deliver/></d:privilege>
+							<d:privilege><v:publish/></d:privilege>
+						</d:grant>
+					</d:ace>
+				</d:acl>
+			</d:prop>
+			<d:status>HTTP/1.1 200 OK</d:status>
+		</d:propstat>
+	</d:response>
+</d:multistatus>`)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.Client(), server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, acl, err := client.FindAccess(t.Context(), "/calendars/user/work/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = client.SetACL(t.Context(), "/calendars/user/work/", acl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{
+		`<d:principal><x:group xmlns:x="urn:example:vendor"/></d:principal>`,
+		`<x:schedule-deliver xmlns:x="urn:ietf:params:xml:ns:caldav"/>`,
+		`<x:publish xmlns:x="urn:example:vendor"/>`,
+	} {
+		if !strings.Contains(body, expected) {
+			t.Errorf("expected body to contain %q, got %s", expected, body)
+		}
+	}
+}
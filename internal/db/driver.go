@@ -3,54 +3,92 @@ package db
 import (
 	"context"
 	"database/sql"
+	"embed"
 	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
 
-	_ "embed"
-
 	"github.com/shibukawa/configdir"
 	_ "modernc.org/sqlite"
 )
 
-//go:embed schema.sql
-var schema string
-
-const db_version = 1
+// migrations contains the schema as a sequence of sql scripts, the version of
+// a database is the amount of migrations that have been applied to it.
+//
+//go:embed migrations/*.sql
+var migrations embed.FS
 
-func setupDB(ctx context.Context, tx *sql.Tx, txqry *Queries) (err error) {
-	_, err = tx.ExecContext(ctx, schema)
+func migrate(ctx context.Context, tx *sql.Tx, txqry *Queries, version int64) (err error) {
+	entries, err := fs.ReadDir(migrations, "migrations")
 	if err != nil {
 		return
 	}
-	err = txqry.PutMetadata(ctx, db_version)
+	if version > int64(len(entries)) {
+		err = fmt.Errorf(
+			"cache version %d is newer than the latest known version %d, run `caldav purge cache` to reset it",
+			version,
+			len(entries),
+		)
+		return
+	}
+	if version == int64(len(entries)) {
+		return
+	}
+	for _, entry := range entries[version:] {
+		var script []byte
+		script, err = migrations.ReadFile("migrations/" + entry.Name())
+		if err != nil {
+			return
+		}
+		_, err = tx.ExecContext(ctx, string(script))
+		if err != nil {
+			err = fmt.Errorf("apply migration %s: %w", entry.Name(), err)
+			return
+		}
+	}
+	err = txqry.PutMetadata(ctx, int64(len(entries)))
 	return
 }
 
 const state_file = "state.db"
 
-func Purge() (err error) {
+func cachePath() string {
 	dirs := configdir.New("LQR471814", "nu_plugin_caldav")
-	cache := dirs.QueryCacheFolder().Path
-	return os.Remove(filepath.Join(cache, state_file))
+	return dirs.QueryCacheFolder().Path
+}
+
+// File returns the path of the plugin's cache in the user's cache directory.
+func File() string {
+	return filepath.Join(cachePath(), state_file)
+}
+
+func Purge() (err error) {
+	return os.Remove(File())
 }
 
+// Open opens the plugin's cache in the user's cache directory.
 func Open(ctx context.Context) (driver *sql.DB, qry *Queries, err error) {
-	dirs := configdir.New("LQR471814", "nu_plugin_caldav")
-	cache := dirs.QueryCacheFolder().Path
+	cache := cachePath()
 
 	err = os.MkdirAll(cache, 0777)
 	if err != nil {
 		return
 	}
+	return OpenFile(ctx, File())
+}
+
+// OpenFile opens the cache stored at the given file, migrating it to the
+// latest version if necessary.
+func OpenFile(ctx context.Context, file string) (driver *sql.DB, qry *Queries, err error) {
 	driver, err = sql.Open("sqlite", fmt.Sprintf(
 		"file:%s?"+
 			"_journal_mode=WAL&"+
 			"_synchronous=NORMAL&"+
 			"_busy_timeout=10000",
-		filepath.Join(cache, state_file),
+		file,
 	))
 	if err != nil {
 		return
@@ -70,24 +108,21 @@ func Open(ctx context.Context) (driver *sql.DB, qry *Queries, err error) {
 	txqry := qry.WithTx(tx)
 
 	version, err := txqry.ReadMetadata(ctx)
-
-	// if db is already setup
-	if err == nil && version == db_version {
+	// if empty db
+	if err != nil && (errors.Is(err, sql.ErrNoRows) ||
+		strings.Contains(err.Error(), "no such table")) {
+		version = 0
+		err = nil
+	}
+	// if some unexpected error
+	if err != nil {
 		return
 	}
 
-	// if empty db
-	if strings.Contains(err.Error(), "no such table") ||
-		errors.Is(err, sql.ErrNoRows) ||
-		version != db_version {
-		err = setupDB(ctx, tx, txqry)
-		if err != nil {
-			return
-		}
-		tx.Commit()
+	err = migrate(ctx, tx, txqry, version)
+	if err != nil {
 		return
 	}
-
-	// if some unexpected error
+	err = tx.Commit()
 	return
 }
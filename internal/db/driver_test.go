@@ -0,0 +1,189 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+)
+
+func openTestDB(t *testing.T) *sql.DB {
+	driver, err := sql.Open("sqlite", "file::memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// in-memory databases are per-connection
+	driver.SetMaxOpenConns(1)
+	t.Cleanup(func() { driver.Close() })
+	return driver
+}
+
+func applyMigrations(t *testing.T, driver *sql.DB, version int64) {
+	ctx := context.Background()
+	tx, err := driver.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback()
+	err = migrate(ctx, tx, New(driver).WithTx(tx), version)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = tx.Commit()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMigrateEmptyDatabase(t *testing.T) {
+	ctx := context.Background()
+	driver := openTestDB(t)
+	applyMigrations(t, driver, 0)
+
+	qry := New(driver)
+	err := qry.PutCalendar(ctx, PutCalendarParams{Path: "/cal/"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = qry.PutEvent(ctx, PutEventParams{
+		Path:         "/cal/event.ics",
+		CalendarPath: "/cal/",
+		Ics:          "BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// migrating an up-to-date database is a no-op
+	version, err := qry.ReadMetadata(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	applyMigrations(t, driver, version)
+}
+
+func TestMigrateResetsSyncTokensOfGobCache(t *testing.T) {
+	ctx := context.Background()
+	driver := openTestDB(t)
+
+	script, err := migrations.ReadFile("migrations/0001_init.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = driver.ExecContext(ctx, string(script))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = driver.ExecContext(ctx, `
+		insert into calendar (path, sync_token) values ('/cal/', 'token');
+		insert into event_object (path, calendar_path, dto) values ('/cal/event.ics', '/cal/', x'00');
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	applyMigrations(t, driver, 1)
+
+	token, err := New(driver).ReadCalendar(ctx, "/cal/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token.Valid {
+		t.Fatalf("expected sync token to be reset, got %q", token.String)
+	}
+	var count int
+	err = driver.QueryRowContext(ctx, "select count(*) from event_object").Scan(&count)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 0 {
+		t.Fatalf("expected gob encoded events to be dropped, got %d", count)
+	}
+}
+
+func TestReadCachedCalendarsCountsObjects(t *testing.T) {
+	ctx := context.Background()
+	driver := openTestDB(t)
+	applyMigrations(t, driver, 0)
+
+	qry := New(driver)
+	for _, p := range []string{"/a/", "/b/"} {
+		err := qry.PutCalendar(ctx, PutCalendarParams{
+			Path:     p,
+			LastSync: sql.NullInt64{Int64: 1, Valid: true},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	err := qry.PutEvent(ctx, PutEventParams{
+		Path:         "/a/event.ics",
+		CalendarPath: "/a/",
+		Ics:          "BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	calendars, err := qry.ReadCachedCalendars(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(calendars) != 2 {
+		t.Fatalf("expected 2 calendars, got %+v", calendars)
+	}
+	if calendars[0].ObjectCount != 1 || calendars[1].ObjectCount != 0 {
+		t.Fatalf("unexpected object counts %+v", calendars)
+	}
+	if calendars[0].LastSync.Int64 != 1 {
+		t.Fatalf("expected last sync to be stored, got %+v", calendars[0].LastSync)
+	}
+}
+
+func TestMigrateSyncFailuresReferenceObjects(t *testing.T) {
+	ctx := context.Background()
+	driver := openTestDB(t)
+
+	// the schema before sync failures referenced the cached objects
+	entries, err := migrations.ReadDir("migrations")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries[:7] {
+		script, err := migrations.ReadFile("migrations/" + entry.Name())
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = driver.ExecContext(ctx, string(script))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	_, err = driver.ExecContext(ctx, `
+		insert into calendar (path, sync_token) values ('/cal/', 'token');
+		insert into event_object (path, calendar_path, ics) values ('/cal/event.ics', '/cal/', 'cached');
+		insert into sync_failure (path, calendar_path, ics, error, failed_at) values
+			('/cal/event.ics', '/cal/', 'copy', 'broken', 1),
+			('/cal/gone.ics', '/cal/', 'copy', 'broken', 1);
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	applyMigrations(t, driver, 7)
+
+	qry := New(driver)
+	failures, err := qry.ReadSyncFailures(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(failures) != 1 || failures[0].Ics != "cached" || failures[0].Error != "broken" {
+		t.Fatalf("expected the failure of the cached object to be kept, got %+v", failures)
+	}
+	token, err := qry.ReadCalendar(ctx, "/cal/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token.Valid {
+		t.Fatalf("expected sync token to be reset, got %q", token.String)
+	}
+}
@@ -0,0 +1,18 @@
+-- event_object previously stored an encoding/gob blob of the parsed event
+-- which cannot be converted, all calendars are resynced from scratch instead.
+drop table event_object;
+
+-- event_object stores an event resource exactly as it was returned by the
+-- server, it is parsed again every time it is read
+create table event_object (
+	path text primary key,
+	calendar_path text not null references calendar(path)
+		on update cascade
+		on delete cascade,
+	-- etag is the entity tag of the stored version of the resource
+	etag text,
+	-- ics is the raw iCalendar data of the resource
+	ics text not null
+);
+
+update calendar set sync_token = null;
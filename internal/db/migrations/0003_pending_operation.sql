@@ -0,0 +1,16 @@
+-- pending_operation stores writes made while offline, they are replayed in
+-- order of id by `caldav push`, there is at most one operation per object
+create table pending_operation (
+	id integer primary key autoincrement,
+	-- kind is one of: put, delete
+	kind text not null,
+	calendar_path text not null,
+	object_path text not null unique,
+	-- etag is the entity tag of the object on the server that the operation
+	-- was based on, null if the object did not exist on the server
+	etag text,
+	-- ics is the iCalendar data to put, null for deletes
+	ics text,
+	-- created_at is the unix timestamp (in seconds) the operation was queued
+	created_at integer not null
+);
@@ -0,0 +1,2 @@
+-- last_sync is the unix timestamp (in seconds) of the last successful sync
+alter table calendar add column last_sync integer;
@@ -0,0 +1,11 @@
+-- sync_failure records the objects received from the server that could not be
+-- parsed as events, they are still cached in event_object but are skipped when
+-- reading events
+create table sync_failure (
+	path text primary key,
+	calendar_path text not null,
+	ics text not null,
+	error text not null,
+	-- failed_at is the unix timestamp (in seconds) of the last failed parse
+	failed_at integer not null
+);
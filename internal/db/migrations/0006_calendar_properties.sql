@@ -0,0 +1,4 @@
+-- display_name and color are the properties of the calendar on the server as
+-- of the last sync
+alter table calendar add column display_name text;
+alter table calendar add column color text;
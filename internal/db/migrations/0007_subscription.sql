@@ -0,0 +1,13 @@
+-- subscription stores the calendars that are read from an iCalendar feed (ex.
+-- a webcal:// URL) instead of a CalDAV collection, their events are stored in
+-- event_object with the URL of the feed as the calendar path
+create table subscription (
+	url text primary key,
+	name text not null,
+	-- etag and last_modified are the validators of the last response, they
+	-- are used to make conditional requests
+	etag text,
+	last_modified text,
+	-- refreshed_at is the unix timestamp (in seconds) of the last refresh
+	refreshed_at integer
+);
@@ -0,0 +1,21 @@
+-- sync_failure is keyed by the path of the cached object that failed to parse
+-- instead of keeping a second copy of its iCalendar data, failures are deleted
+-- along with their objects by DeleteSyncFailures
+create table sync_failure_object (
+	path text primary key,
+	calendar_path text not null,
+	error text not null,
+	-- failed_at is the unix timestamp (in seconds) of the last failed parse
+	failed_at integer not null
+);
+
+insert into sync_failure_object (path, calendar_path, error, failed_at)
+select path, calendar_path, error, failed_at from sync_failure
+where path in (select path from event_object);
+
+drop table sync_failure;
+alter table sync_failure_object rename to sync_failure;
+
+-- objects cached before failures were recorded are parsed again by a full
+-- resync so that they are counted
+update calendar set sync_token = null;
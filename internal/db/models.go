@@ -9,17 +9,46 @@ import (
 )
 
 type Calendar struct {
-	Path      string
-	SyncToken sql.NullString
+	Path        string
+	SyncToken   sql.NullString
+	LastSync    sql.NullInt64
+	DisplayName sql.NullString
+	Color       sql.NullString
 }
 
 type EventObject struct {
 	Path         string
 	CalendarPath string
-	Dto          []byte
+	Etag         sql.NullString
+	Ics          string
 }
 
 type Metadata struct {
 	ID      int64
 	Version int64
 }
+
+type PendingOperation struct {
+	ID           int64
+	Kind         string
+	CalendarPath string
+	ObjectPath   string
+	Etag         sql.NullString
+	Ics          sql.NullString
+	CreatedAt    int64
+}
+
+type Subscription struct {
+	Url          string
+	Name         string
+	Etag         sql.NullString
+	LastModified sql.NullString
+	RefreshedAt  sql.NullInt64
+}
+
+type SyncFailure struct {
+	Path         string
+	CalendarPath string
+	Error        string
+	FailedAt     int64
+}
@@ -2,26 +2,34 @@ package db
 
 import (
 	"context"
+	"database/sql"
 )
 
+// readEvents reads the events of a calendar with the pending operations
+// applied on top of them.
 const readEvents = `-- name: ReadEvents :many
-select path, dto from event_object where calendar_path = ?
+select path, etag, ics from event_object
+where calendar_path = ? and path not in (select object_path from pending_operation)
+union all
+select object_path, etag, ics from pending_operation
+where calendar_path = ? and kind = 'put'
 `
 
 type ReadEventsRow struct {
 	Path string
-	Dto  []byte
+	Etag sql.NullString
+	Ics  string
 }
 
 func (q *Queries) ReadEvents(ctx context.Context, calendarPath string, out chan ReadEventsRow) error {
-	rows, err := q.db.QueryContext(ctx, readEvents, calendarPath)
+	rows, err := q.db.QueryContext(ctx, readEvents, calendarPath, calendarPath)
 	if err != nil {
 		return err
 	}
 	defer rows.Close()
 	for rows.Next() {
 		var i ReadEventsRow
-		if err := rows.Scan(&i.Path, &i.Dto); err != nil {
+		if err := rows.Scan(&i.Path, &i.Etag, &i.Ics); err != nil {
 			return err
 		}
 		out <- i
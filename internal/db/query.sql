@@ -12,19 +12,132 @@ on conflict (id) do update set
 select sync_token from calendar where path = ?;
 
 -- name: PutCalendar :exec
-insert into calendar (path, sync_token)
-values (?, ?)
+insert into calendar (path, sync_token, last_sync)
+values (?, ?, ?)
 on conflict (path) do update set
-	sync_token = excluded.sync_token;
+	sync_token = excluded.sync_token,
+	last_sync = excluded.last_sync;
+
+-- name: PutCalendarProperties :exec
+update calendar set
+	display_name = ?,
+	color = ?
+where path = ?;
+
+-- name: ReadCalendarProperties :one
+select display_name, color from calendar
+where path = ?;
+
+-- name: ReadCachedCalendars :many
+select
+	calendar.path,
+	calendar.sync_token,
+	calendar.last_sync,
+	(select count(*) from event_object where event_object.calendar_path = calendar.path) as object_count,
+	(
+		select count(*) from sync_failure
+		join event_object on event_object.path = sync_failure.path
+		where sync_failure.calendar_path = calendar.path
+	) as failure_count
+from calendar
+order by calendar.path;
+
+-- name: DeleteCalendar :exec
+delete from calendar
+where path = ?;
+
+-- name: DeleteCalendarEvents :exec
+delete from event_object
+where calendar_path = ?;
+
+-- name: ReadCacheCounts :one
+select
+	(select count(*) from calendar) as calendars,
+	(select count(*) from event_object) as objects,
+	(select count(*) from pending_operation) as pending_operations;
 
 -- name: PutEvent :exec
-insert into event_object (path, calendar_path, dto)
-values (?, ?, ?)
+insert into event_object (path, calendar_path, etag, ics)
+values (?, ?, ?, ?)
 on conflict (path) do update set
 	calendar_path = excluded.calendar_path,
-	dto = excluded.dto;
+	etag = excluded.etag,
+	ics = excluded.ics;
 
 -- name: DeleteEvents :exec
 delete from event_object
 where path in (sqlc.slice('paths'));
 
+
+-- name: ReadEvent :one
+select calendar_path, etag, ics from event_object
+where path = ?;
+
+-- name: PutPendingOperation :exec
+insert into pending_operation (kind, calendar_path, object_path, etag, ics, created_at)
+values (?, ?, ?, ?, ?, ?);
+
+-- name: ReadPendingOperations :many
+select id, kind, calendar_path, object_path, etag, ics, created_at
+from pending_operation
+order by id;
+
+-- name: ReadObjectPendingOperation :one
+select id, kind, calendar_path, object_path, etag, ics, created_at
+from pending_operation
+where object_path = ?;
+
+-- name: DeletePendingOperation :exec
+delete from pending_operation
+where id = ?;
+
+-- name: PutSyncFailure :exec
+insert into sync_failure (path, calendar_path, error, failed_at)
+values (?, ?, ?, ?)
+on conflict (path) do update set
+	calendar_path = excluded.calendar_path,
+	error = excluded.error,
+	failed_at = excluded.failed_at;
+
+-- name: ReadSyncFailures :many
+select sync_failure.path, sync_failure.calendar_path, event_object.ics, sync_failure.error, sync_failure.failed_at
+from sync_failure
+join event_object on event_object.path = sync_failure.path
+order by sync_failure.calendar_path, sync_failure.path;
+
+-- name: DeleteSyncFailures :exec
+delete from sync_failure
+where path in (sqlc.slice('paths'));
+
+-- name: DeleteCalendarSyncFailures :exec
+delete from sync_failure
+where calendar_path = ?;
+
+-- name: DeleteCalendarPendingOperations :exec
+delete from pending_operation
+where calendar_path = ?;
+
+-- name: PutSubscription :exec
+insert into subscription (url, name)
+values (?, ?)
+on conflict (url) do update set
+	name = excluded.name;
+
+-- name: PutSubscriptionValidators :exec
+update subscription set
+	etag = ?,
+	last_modified = ?,
+	refreshed_at = ?
+where url = ?;
+
+-- name: ReadSubscription :one
+select * from subscription
+where url = ?;
+
+-- name: ReadSubscriptions :many
+select * from subscription
+order by url;
+
+-- name: DeleteSubscription :exec
+delete from subscription
+where url = ?;
@@ -11,6 +11,46 @@ import (
 	"strings"
 )
 
+const deleteCalendar = `-- name: DeleteCalendar :exec
+delete from calendar
+where path = ?
+`
+
+func (q *Queries) DeleteCalendar(ctx context.Context, path string) error {
+	_, err := q.db.ExecContext(ctx, deleteCalendar, path)
+	return err
+}
+
+const deleteCalendarEvents = `-- name: DeleteCalendarEvents :exec
+delete from event_object
+where calendar_path = ?
+`
+
+func (q *Queries) DeleteCalendarEvents(ctx context.Context, calendarPath string) error {
+	_, err := q.db.ExecContext(ctx, deleteCalendarEvents, calendarPath)
+	return err
+}
+
+const deleteCalendarPendingOperations = `-- name: DeleteCalendarPendingOperations :exec
+delete from pending_operation
+where calendar_path = ?
+`
+
+func (q *Queries) DeleteCalendarPendingOperations(ctx context.Context, calendarPath string) error {
+	_, err := q.db.ExecContext(ctx, deleteCalendarPendingOperations, calendarPath)
+	return err
+}
+
+const deleteCalendarSyncFailures = `-- name: DeleteCalendarSyncFailures :exec
+delete from sync_failure
+where calendar_path = ?
+`
+
+func (q *Queries) DeleteCalendarSyncFailures(ctx context.Context, calendarPath string) error {
+	_, err := q.db.ExecContext(ctx, deleteCalendarSyncFailures, calendarPath)
+	return err
+}
+
 const deleteEvents = `-- name: DeleteEvents :exec
 delete from event_object
 where path in (/*SLICE:paths*/?)
@@ -31,39 +71,106 @@ func (q *Queries) DeleteEvents(ctx context.Context, paths []string) error {
 	return err
 }
 
+const deletePendingOperation = `-- name: DeletePendingOperation :exec
+delete from pending_operation
+where id = ?
+`
+
+func (q *Queries) DeletePendingOperation(ctx context.Context, id int64) error {
+	_, err := q.db.ExecContext(ctx, deletePendingOperation, id)
+	return err
+}
+
+const deleteSubscription = `-- name: DeleteSubscription :exec
+delete from subscription
+where url = ?
+`
+
+func (q *Queries) DeleteSubscription(ctx context.Context, url string) error {
+	_, err := q.db.ExecContext(ctx, deleteSubscription, url)
+	return err
+}
+
+const deleteSyncFailures = `-- name: DeleteSyncFailures :exec
+delete from sync_failure
+where path in (/*SLICE:paths*/?)
+`
+
+func (q *Queries) DeleteSyncFailures(ctx context.Context, paths []string) error {
+	query := deleteSyncFailures
+	var queryParams []interface{}
+	if len(paths) > 0 {
+		for _, v := range paths {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:paths*/?", strings.Repeat(",?", len(paths))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:paths*/?", "NULL", 1)
+	}
+	_, err := q.db.ExecContext(ctx, query, queryParams...)
+	return err
+}
+
 const putCalendar = `-- name: PutCalendar :exec
-insert into calendar (path, sync_token)
-values (?, ?)
+insert into calendar (path, sync_token, last_sync)
+values (?, ?, ?)
 on conflict (path) do update set
-	sync_token = excluded.sync_token
+	sync_token = excluded.sync_token,
+	last_sync = excluded.last_sync
 `
 
 type PutCalendarParams struct {
 	Path      string
 	SyncToken sql.NullString
+	LastSync  sql.NullInt64
 }
 
 func (q *Queries) PutCalendar(ctx context.Context, arg PutCalendarParams) error {
-	_, err := q.db.ExecContext(ctx, putCalendar, arg.Path, arg.SyncToken)
+	_, err := q.db.ExecContext(ctx, putCalendar, arg.Path, arg.SyncToken, arg.LastSync)
+	return err
+}
+
+const putCalendarProperties = `-- name: PutCalendarProperties :exec
+update calendar set
+	display_name = ?,
+	color = ?
+where path = ?
+`
+
+type PutCalendarPropertiesParams struct {
+	DisplayName sql.NullString
+	Color       sql.NullString
+	Path        string
+}
+
+func (q *Queries) PutCalendarProperties(ctx context.Context, arg PutCalendarPropertiesParams) error {
+	_, err := q.db.ExecContext(ctx, putCalendarProperties, arg.DisplayName, arg.Color, arg.Path)
 	return err
 }
 
 const putEvent = `-- name: PutEvent :exec
-insert into event_object (path, calendar_path, dto)
-values (?, ?, ?)
+insert into event_object (path, calendar_path, etag, ics)
+values (?, ?, ?, ?)
 on conflict (path) do update set
 	calendar_path = excluded.calendar_path,
-	dto = excluded.dto
+	etag = excluded.etag,
+	ics = excluded.ics
 `
 
 type PutEventParams struct {
 	Path         string
 	CalendarPath string
-	Dto          []byte
+	Etag         sql.NullString
+	Ics          string
 }
 
 func (q *Queries) PutEvent(ctx context.Context, arg PutEventParams) error {
-	_, err := q.db.ExecContext(ctx, putEvent, arg.Path, arg.CalendarPath, arg.Dto)
+	_, err := q.db.ExecContext(ctx, putEvent,
+		arg.Path,
+		arg.CalendarPath,
+		arg.Etag,
+		arg.Ics,
+	)
 	return err
 }
 
@@ -79,6 +186,172 @@ func (q *Queries) PutMetadata(ctx context.Context, version int64) error {
 	return err
 }
 
+const putPendingOperation = `-- name: PutPendingOperation :exec
+insert into pending_operation (kind, calendar_path, object_path, etag, ics, created_at)
+values (?, ?, ?, ?, ?, ?)
+`
+
+type PutPendingOperationParams struct {
+	Kind         string
+	CalendarPath string
+	ObjectPath   string
+	Etag         sql.NullString
+	Ics          sql.NullString
+	CreatedAt    int64
+}
+
+func (q *Queries) PutPendingOperation(ctx context.Context, arg PutPendingOperationParams) error {
+	_, err := q.db.ExecContext(ctx, putPendingOperation,
+		arg.Kind,
+		arg.CalendarPath,
+		arg.ObjectPath,
+		arg.Etag,
+		arg.Ics,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const putSubscription = `-- name: PutSubscription :exec
+insert into subscription (url, name)
+values (?, ?)
+on conflict (url) do update set
+	name = excluded.name
+`
+
+type PutSubscriptionParams struct {
+	Url  string
+	Name string
+}
+
+func (q *Queries) PutSubscription(ctx context.Context, arg PutSubscriptionParams) error {
+	_, err := q.db.ExecContext(ctx, putSubscription, arg.Url, arg.Name)
+	return err
+}
+
+const putSubscriptionValidators = `-- name: PutSubscriptionValidators :exec
+update subscription set
+	etag = ?,
+	last_modified = ?,
+	refreshed_at = ?
+where url = ?
+`
+
+type PutSubscriptionValidatorsParams struct {
+	Etag         sql.NullString
+	LastModified sql.NullString
+	RefreshedAt  sql.NullInt64
+	Url          string
+}
+
+func (q *Queries) PutSubscriptionValidators(ctx context.Context, arg PutSubscriptionValidatorsParams) error {
+	_, err := q.db.ExecContext(ctx, putSubscriptionValidators,
+		arg.Etag,
+		arg.LastModified,
+		arg.RefreshedAt,
+		arg.Url,
+	)
+	return err
+}
+
+const putSyncFailure = `-- name: PutSyncFailure :exec
+insert into sync_failure (path, calendar_path, error, failed_at)
+values (?, ?, ?, ?)
+on conflict (path) do update set
+	calendar_path = excluded.calendar_path,
+	error = excluded.error,
+	failed_at = excluded.failed_at
+`
+
+type PutSyncFailureParams struct {
+	Path         string
+	CalendarPath string
+	Error        string
+	FailedAt     int64
+}
+
+func (q *Queries) PutSyncFailure(ctx context.Context, arg PutSyncFailureParams) error {
+	_, err := q.db.ExecContext(ctx, putSyncFailure,
+		arg.Path,
+		arg.CalendarPath,
+		arg.Error,
+		arg.FailedAt,
+	)
+	return err
+}
+
+const readCacheCounts = `-- name: ReadCacheCounts :one
+select
+	(select count(*) from calendar) as calendars,
+	(select count(*) from event_object) as objects,
+	(select count(*) from pending_operation) as pending_operations
+`
+
+type ReadCacheCountsRow struct {
+	Calendars         int64
+	Objects           int64
+	PendingOperations int64
+}
+
+func (q *Queries) ReadCacheCounts(ctx context.Context) (ReadCacheCountsRow, error) {
+	row := q.db.QueryRowContext(ctx, readCacheCounts)
+	var i ReadCacheCountsRow
+	err := row.Scan(&i.Calendars, &i.Objects, &i.PendingOperations)
+	return i, err
+}
+
+const readCachedCalendars = `-- name: ReadCachedCalendars :many
+select
+	calendar.path,
+	calendar.sync_token,
+	calendar.last_sync,
+	(select count(*) from event_object where event_object.calendar_path = calendar.path) as object_count,
+	(
+		select count(*) from sync_failure
+		join event_object on event_object.path = sync_failure.path
+		where sync_failure.calendar_path = calendar.path
+	) as failure_count
+from calendar
+order by calendar.path
+`
+
+type ReadCachedCalendarsRow struct {
+	Path         string
+	SyncToken    sql.NullString
+	LastSync     sql.NullInt64
+	ObjectCount  int64
+	FailureCount int64
+}
+
+func (q *Queries) ReadCachedCalendars(ctx context.Context) ([]ReadCachedCalendarsRow, error) {
+	rows, err := q.db.QueryContext(ctx, readCachedCalendars)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ReadCachedCalendarsRow
+	for rows.Next() {
+		var i ReadCachedCalendarsRow
+		if err := rows.Scan(
+			&i.Path,
+			&i.SyncToken,
+			&i.LastSync,
+			&i.ObjectCount,
+			&i.FailureCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const readCalendar = `-- name: ReadCalendar :one
 select sync_token from calendar where path = ?
 `
@@ -90,6 +363,41 @@ func (q *Queries) ReadCalendar(ctx context.Context, path string) (sql.NullString
 	return sync_token, err
 }
 
+const readCalendarProperties = `-- name: ReadCalendarProperties :one
+select display_name, color from calendar
+where path = ?
+`
+
+type ReadCalendarPropertiesRow struct {
+	DisplayName sql.NullString
+	Color       sql.NullString
+}
+
+func (q *Queries) ReadCalendarProperties(ctx context.Context, path string) (ReadCalendarPropertiesRow, error) {
+	row := q.db.QueryRowContext(ctx, readCalendarProperties, path)
+	var i ReadCalendarPropertiesRow
+	err := row.Scan(&i.DisplayName, &i.Color)
+	return i, err
+}
+
+const readEvent = `-- name: ReadEvent :one
+select calendar_path, etag, ics from event_object
+where path = ?
+`
+
+type ReadEventRow struct {
+	CalendarPath string
+	Etag         sql.NullString
+	Ics          string
+}
+
+func (q *Queries) ReadEvent(ctx context.Context, path string) (ReadEventRow, error) {
+	row := q.db.QueryRowContext(ctx, readEvent, path)
+	var i ReadEventRow
+	err := row.Scan(&i.CalendarPath, &i.Etag, &i.Ics)
+	return i, err
+}
+
 const readMetadata = `-- name: ReadMetadata :one
 select version from metadata
 where id = 1
@@ -101,3 +409,157 @@ func (q *Queries) ReadMetadata(ctx context.Context) (int64, error) {
 	err := row.Scan(&version)
 	return version, err
 }
+
+const readObjectPendingOperation = `-- name: ReadObjectPendingOperation :one
+select id, kind, calendar_path, object_path, etag, ics, created_at
+from pending_operation
+where object_path = ?
+`
+
+func (q *Queries) ReadObjectPendingOperation(ctx context.Context, objectPath string) (PendingOperation, error) {
+	row := q.db.QueryRowContext(ctx, readObjectPendingOperation, objectPath)
+	var i PendingOperation
+	err := row.Scan(
+		&i.ID,
+		&i.Kind,
+		&i.CalendarPath,
+		&i.ObjectPath,
+		&i.Etag,
+		&i.Ics,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const readPendingOperations = `-- name: ReadPendingOperations :many
+select id, kind, calendar_path, object_path, etag, ics, created_at
+from pending_operation
+order by id
+`
+
+func (q *Queries) ReadPendingOperations(ctx context.Context) ([]PendingOperation, error) {
+	rows, err := q.db.QueryContext(ctx, readPendingOperations)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []PendingOperation
+	for rows.Next() {
+		var i PendingOperation
+		if err := rows.Scan(
+			&i.ID,
+			&i.Kind,
+			&i.CalendarPath,
+			&i.ObjectPath,
+			&i.Etag,
+			&i.Ics,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const readSubscription = `-- name: ReadSubscription :one
+select url, name, etag, last_modified, refreshed_at from subscription
+where url = ?
+`
+
+func (q *Queries) ReadSubscription(ctx context.Context, url string) (Subscription, error) {
+	row := q.db.QueryRowContext(ctx, readSubscription, url)
+	var i Subscription
+	err := row.Scan(
+		&i.Url,
+		&i.Name,
+		&i.Etag,
+		&i.LastModified,
+		&i.RefreshedAt,
+	)
+	return i, err
+}
+
+const readSubscriptions = `-- name: ReadSubscriptions :many
+select url, name, etag, last_modified, refreshed_at from subscription
+order by url
+`
+
+func (q *Queries) ReadSubscriptions(ctx context.Context) ([]Subscription, error) {
+	rows, err := q.db.QueryContext(ctx, readSubscriptions)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Subscription
+	for rows.Next() {
+		var i Subscription
+		if err := rows.Scan(
+			&i.Url,
+			&i.Name,
+			&i.Etag,
+			&i.LastModified,
+			&i.RefreshedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const readSyncFailures = `-- name: ReadSyncFailures :many
+select sync_failure.path, sync_failure.calendar_path, event_object.ics, sync_failure.error, sync_failure.failed_at
+from sync_failure
+join event_object on event_object.path = sync_failure.path
+order by sync_failure.calendar_path, sync_failure.path
+`
+
+type ReadSyncFailuresRow struct {
+	Path         string
+	CalendarPath string
+	Ics          string
+	Error        string
+	FailedAt     int64
+}
+
+func (q *Queries) ReadSyncFailures(ctx context.Context) ([]ReadSyncFailuresRow, error) {
+	rows, err := q.db.QueryContext(ctx, readSyncFailures)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ReadSyncFailuresRow
+	for rows.Next() {
+		var i ReadSyncFailuresRow
+		if err := rows.Scan(
+			&i.Path,
+			&i.CalendarPath,
+			&i.Ics,
+			&i.Error,
+			&i.FailedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
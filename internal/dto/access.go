@@ -0,0 +1,82 @@
+package dto
+
+import "github.com/LQR471814/nu_plugin_caldav/internal/dav"
+
+// AccessEntry is a principal's access to a calendar, either through sharing
+// or through the calendar's ACL.
+type AccessEntry struct {
+	// Principal is the path or address (ex. mailto:user@example.com) of the
+	// principal, ACL entries may also refer to one of the pseudo-principals:
+	// all, authenticated, unauthenticated, self, or a property in the form
+	// property:<name>.
+	Principal string
+	Name      *string
+	// Source is one of: share, acl.
+	Source string
+	// Privileges are the privileges granted to the principal (ex. read,
+	// write), sharees either have read or read and write.
+	Privileges []string
+	// DeniedPrivileges are the privileges denied to the principal by the ACL.
+	DeniedPrivileges []string
+	// Status is the status of a sharee's invite, one of: accepted, pending,
+	// declined, invalid.
+	Status *string
+	// Protected ACL entries cannot be changed.
+	Protected bool `default:"false"`
+	// Inherited is the path of the resource an ACL entry is inherited from.
+	Inherited *string
+}
+
+type AccessEntryList []AccessEntry
+
+func NewAccessEntryList(sharees []dav.Sharee, acl []dav.ACE) AccessEntryList {
+	out := make(AccessEntryList, 0, len(sharees)+len(acl))
+	for _, s := range sharees {
+		privileges := []string{"read"}
+		if s.ReadWrite {
+			privileges = append(privileges, "write")
+		}
+		out = append(out, AccessEntry{
+			Principal:  s.Href,
+			Name:       optionalString(s.CommonName),
+			Source:     "share",
+			Privileges: privileges,
+			Status:     optionalString(s.Status),
+		})
+	}
+	for _, ace := range acl {
+		out = append(out, AccessEntry{
+			Principal:        ace.Principal,
+			Source:           "acl",
+			Privileges:       dav.PrivilegeNames(ace.Grant),
+			DeniedPrivileges: dav.PrivilegeNames(ace.Deny),
+			Protected:        ace.Protected,
+			Inherited:        optionalString(ace.Inherited),
+		})
+	}
+	return out
+}
+
+type Principal struct {
+	Path string
+	Name *string
+	// Addresses are the calendar user addresses of the principal, usually of
+	// the form mailto:user@example.com.
+	Addresses       []string
+	CalendarHomeSet *string
+}
+
+type PrincipalList []Principal
+
+func NewPrincipalList(principals []dav.Principal) PrincipalList {
+	out := make(PrincipalList, len(principals))
+	for i, p := range principals {
+		out[i] = Principal{
+			Path:            p.Path,
+			Name:            optionalString(p.DisplayName),
+			Addresses:       p.Addresses,
+			CalendarHomeSet: optionalString(p.CalendarHomeSet),
+		}
+	}
+	return out
+}
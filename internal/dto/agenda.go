@@ -0,0 +1,30 @@
+package dto
+
+import "time"
+
+// AgendaDay is a day of an agenda with the events that take place on it.
+type AgendaDay struct {
+	// Date is the start of the day in the output timezone.
+	Date    time.Time
+	Weekday string `default:"\"\""`
+	Events  []AgendaEntry
+}
+
+// AgendaEntry is an event on a day of an agenda.
+type AgendaEntry struct {
+	// Time is the time range of the event on the day (ex. 09:00-10:30), or
+	// "all day". A range starting or ending with ... continues from the
+	// previous day or to the next one.
+	Time     string `default:"\"\""`
+	AllDay   bool   `default:"false"`
+	Summary  *string
+	Location *string
+	Calendar *string
+	// Start and End are the start and end of the whole event in the output
+	// timezone.
+	Start      time.Time
+	End        time.Time
+	ObjectPath *string
+}
+
+type Agenda []AgendaDay
@@ -0,0 +1,43 @@
+package dto
+
+import "time"
+
+// CacheStatus describes the plugin's cache file.
+type CacheStatus struct {
+	Path string
+	// Size is the size of the cache file (including its write-ahead log) in
+	// bytes.
+	Size              int64
+	Calendars         int64
+	Objects           int64
+	PendingOperations int64
+}
+
+// CachedCalendar describes the state of a single calendar in the cache.
+type CachedCalendar struct {
+	Path      string
+	SyncToken *string
+	// LastSync is when the calendar was last synced with the server.
+	LastSync *time.Time
+	Objects  int64
+	// ParseFailures is the amount of cached objects that could not be parsed
+	// as events, these are skipped by `caldav query events`, see
+	// `caldav query failures`.
+	ParseFailures int64
+}
+
+type CachedCalendarList []CachedCalendar
+
+// SyncFailure is an object received from the server that could not be parsed
+// as an event.
+type SyncFailure struct {
+	ObjectPath   string
+	CalendarPath string
+	// Ics is the raw iCalendar data of the object.
+	Ics   string
+	Error string
+	// FailedAt is when the object last failed to parse.
+	FailedAt time.Time
+}
+
+type SyncFailureList []SyncFailure
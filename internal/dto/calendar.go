@@ -0,0 +1,89 @@
+package dto
+
+import (
+	"strings"
+
+	"github.com/LQR471814/nu_plugin_caldav/internal/dav"
+	"github.com/emersion/go-ical"
+)
+
+type Calendar struct {
+	Path        string
+	Name        string
+	Description string
+	// Color is the color of the calendar, usually of the form #RRGGBB.
+	Color *string
+	// Order is the position of the calendar in a list of calendars.
+	Order *int
+	// Timezone is the TZID of the calendar's timezone.
+	Timezone        *string
+	MaxResourceSize int64
+	// SupportedComponentSet are the components (ex. VEVENT, VTODO) that can be
+	// stored in the calendar, empty if the server does not restrict them.
+	SupportedComponentSet []string
+	// CTag changes whenever the contents of the calendar change.
+	CTag      *string
+	SyncToken *string
+	// Privileges are the privileges (ex. read, write) that the current user
+	// has on the calendar.
+	Privileges []string
+	// Owner is the path of the principal that owns the calendar.
+	Owner *string
+	// ResourceTypes are the types of the collection, ex. calendar,
+	// subscribed, shared, schedule-inbox.
+	ResourceTypes []string
+}
+
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// timezoneID returns the TZID of the VTIMEZONE in an iCalendar object.
+func timezoneID(ics string) *string {
+	cal, err := ical.NewDecoder(strings.NewReader(ics)).Decode()
+	if err != nil {
+		return nil
+	}
+	for _, child := range cal.Children {
+		if child.Name != ical.CompTimezone {
+			continue
+		}
+		tzid, err := child.Props.Text(ical.PropTimezoneID)
+		if err != nil {
+			return nil
+		}
+		return optionalString(tzid)
+	}
+	return nil
+}
+
+func NewCalendar(cal dav.Calendar) Calendar {
+	return Calendar{
+		Path:                  cal.Path,
+		Name:                  cal.DisplayName,
+		Description:           cal.Description,
+		Color:                 optionalString(cal.Color),
+		Order:                 cal.Order,
+		Timezone:              timezoneID(cal.Timezone),
+		MaxResourceSize:       cal.MaxResourceSize,
+		SupportedComponentSet: cal.SupportedComponents,
+		CTag:                  optionalString(cal.CTag),
+		SyncToken:             optionalString(cal.SyncToken),
+		Privileges:            cal.Privileges,
+		Owner:                 optionalString(cal.Owner),
+		ResourceTypes:         cal.ResourceTypes,
+	}
+}
+
+type CalendarList []Calendar
+
+func NewCalendarList(calendars []dav.Calendar) CalendarList {
+	out := make(CalendarList, len(calendars))
+	for i, cal := range calendars {
+		out[i] = NewCalendar(cal)
+	}
+	return out
+}
@@ -0,0 +1,17 @@
+package dto
+
+import "time"
+
+// Conflict is a span of time in which more than one busy event takes place.
+type Conflict struct {
+	Start    time.Time
+	End      time.Time
+	Duration time.Duration
+	// Occurrences are the events that overlap during the conflict.
+	Occurrences []Occurrence
+	// Calendars are the names (or paths if they have no name) of the
+	// calendars of the events involved.
+	Calendars []string
+}
+
+type ConflictList []Conflict
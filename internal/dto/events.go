@@ -13,30 +13,20 @@ import (
 	"github.com/teambition/rrule-go"
 )
 
-// rrule.RRule but with gob encoding support
+// rrule.RRule but represented as its string form in nushell
 type RRule struct {
 	*rrule.RRule
 }
 
-func (r RRule) GobEncode() ([]byte, error) {
-	return []byte(r.String()), nil
-}
-
-func (r *RRule) GobDecode(s []byte) error {
-	rule, err := rrule.StrToRRule(string(s))
-	if err != nil {
-		return err
-	}
-	*r = RRule{RRule: rule}
-	return nil
-}
-
 type PropValueDto struct {
 	Value  string
 	Params map[string][]string
 }
 
 type Event struct {
+	// Kind is the component the event was read from: VEVENT, VTODO or
+	// VJOURNAL.
+	Kind          string `default:"\"VEVENT\""`
 	Uid           *string
 	Summary       *string
 	Location      *string
@@ -58,14 +48,51 @@ type Event struct {
 	Contact   *string
 	Organizer *url.URL
 	Start     events.Datetime
-	End       events.Datetime
-	// TODO: implement duration
-	RecurrenceRule           RRule
+	// End is derived from Start and Duration if the event has a duration.
+	End events.Datetime
+	// Duration is set if the event's end is defined by a duration rather
+	// than an end time, End is ignored when saving an event with a duration.
+	Duration *time.Duration
+	// Due is when a todo is due. Todos span from their DTSTART to their DUE,
+	// or take place at either if they only have one (both Start and End are
+	// zero if they have neither), journals take place at their DTSTART.
+	Due *events.Datetime
+	// StartFromDue is set for todos without a DTSTART, their Start is
+	// derived from their DUE and is not saved.
+	StartFromDue   bool `default:"false"`
+	RecurrenceRule RRule
+	// ExtraRecurrenceRules are the RRULEs after the first, RFC 5545
+	// deprecates them but older clients still write them.
+	ExtraRecurrenceRules []RRule
+	// ExceptionRules are the EXRULEs of RFC 2445.
+	ExceptionRules           []RRule
 	RecurrenceDates          []events.Datetime
+	RecurrencePeriods        []events.Period
 	RecurrenceExceptionDates []events.Datetime
 	RecurrenceInstance       *events.Datetime
-	Trigger                  *events.EventTrigger
-	Other                    map[string][]PropValueDto
+	// ThisAndFuture is set if the override also applies to all the later
+	// instances of the event (RANGE=THISANDFUTURE).
+	ThisAndFuture bool `default:"false"`
+	Trigger       *events.EventTrigger
+	Other         map[string][]PropValueDto
+}
+
+func newRRules(rules []*rrule.RRule) []RRule {
+	out := make([]RRule, len(rules))
+	for i, r := range rules {
+		out[i] = RRule{RRule: r}
+	}
+	return out
+}
+
+func rrules(rules []RRule) []*rrule.RRule {
+	out := make([]*rrule.RRule, 0, len(rules))
+	for _, r := range rules {
+		if r.RRule != nil {
+			out = append(out, r.RRule)
+		}
+	}
+	return out
 }
 
 func (e Event) String() string {
@@ -88,6 +115,18 @@ func (e Event) String() string {
 	if e.End.Floating {
 		fmt.Fprint(&sb, ",Floating")
 	}
+	if e.Duration != nil {
+		sb.WriteString(" Duration:")
+		fmt.Fprint(&sb, *e.Duration)
+	}
+	if e.Due != nil {
+		sb.WriteString(" Due:")
+		fmt.Fprint(&sb, e.Due.Stamp)
+	}
+	if e.Kind != "" && e.Kind != ical.CompEvent {
+		sb.WriteString(" Kind:")
+		sb.WriteString(e.Kind)
+	}
 
 	if e.Uid != nil {
 		sb.WriteString("Uid:")
@@ -188,11 +227,26 @@ func (e Event) String() string {
 		sb.WriteString("RecurrenceRule:")
 		fmt.Fprint(&sb, *e.RecurrenceRule.RRule)
 	}
+	if e.ExtraRecurrenceRules != nil {
+		sb.WriteString(" ")
+		sb.WriteString("ExtraRecurrenceRules:")
+		fmt.Fprint(&sb, e.ExtraRecurrenceRules)
+	}
+	if e.ExceptionRules != nil {
+		sb.WriteString(" ")
+		sb.WriteString("ExceptionRules:")
+		fmt.Fprint(&sb, e.ExceptionRules)
+	}
 	if e.RecurrenceDates != nil {
 		sb.WriteString(" ")
 		sb.WriteString("RecurrenceDates:")
 		fmt.Fprint(&sb, e.RecurrenceDates)
 	}
+	if e.RecurrencePeriods != nil {
+		sb.WriteString(" ")
+		sb.WriteString("RecurrencePeriods:")
+		fmt.Fprint(&sb, e.RecurrencePeriods)
+	}
 	if e.RecurrenceExceptionDates != nil {
 		sb.WriteString(" ")
 		sb.WriteString("RecurrenceExceptionDates:")
@@ -203,6 +257,9 @@ func (e Event) String() string {
 		sb.WriteString("RecurrenceInstance:")
 		fmt.Fprint(&sb, *e.RecurrenceInstance)
 	}
+	if e.ThisAndFuture {
+		sb.WriteString(" ThisAndFuture")
+	}
 	if e.Trigger != nil {
 		sb.WriteString(" ")
 		sb.WriteString("Trigger:")
@@ -238,6 +295,38 @@ func requireEventProp[T any](value T, err error) (T, error) {
 	return value, nil
 }
 
+// newTodoSpan sets the start and end of a todo from its DTSTART, DUE and
+// DURATION.
+func newTodoSpan(e events.Event, out *Event) error {
+	start, hasStart, err := optionalEventProp(e.GetStart())
+	if err != nil {
+		return err
+	}
+	due, hasDue, err := optionalEventProp(e.GetDue())
+	if err != nil {
+		return err
+	}
+	switch {
+	case hasStart && hasDue:
+		out.Start = start
+		out.End = due
+	case hasStart:
+		out.Start = start
+		out.End = start
+		if out.Duration != nil {
+			out.End.Stamp = start.Stamp.Add(*out.Duration)
+		}
+	case hasDue:
+		out.Start = due
+		out.End = due
+		out.StartFromDue = true
+	}
+	if hasDue {
+		out.Due = &due
+	}
+	return nil
+}
+
 func NewEvent(e events.Event) (out Event, err error) {
 	uid, err := requireEventProp(e.GetUID())
 	if err != nil {
@@ -336,28 +425,67 @@ func NewEvent(e events.Event) (out Event, err error) {
 		out.Organizer = res
 	}
 
-	start, err := requireEventProp(e.GetStart())
-	if err != nil {
-		return
+	out.Kind = ical.CompEvent
+	if e.Component != nil && e.Name != "" {
+		out.Kind = e.Name
+	}
+	if res, ok, err := optionalEventProp(e.GetDuration()); err != nil {
+		return out, err
+	} else if ok {
+		out.Duration = &res
+	}
+	switch out.Kind {
+	case ical.CompToDo:
+		err = newTodoSpan(e, &out)
+	case ical.CompJournal:
+		if res, ok, err := optionalEventProp(e.GetStart()); err != nil {
+			return out, err
+		} else if ok {
+			out.Start = res
+			out.End = res
+		}
+	default:
+		var start, end events.Datetime
+		start, err = requireEventProp(e.GetStart())
+		if err != nil {
+			return
+		}
+		out.Start = start
+		end, err = e.GetEffectiveEnd(start)
+		if err != nil {
+			return
+		}
+		out.End = end
 	}
-	out.Start = start
-
-	end, err := requireEventProp(e.GetEnd())
 	if err != nil {
 		return
 	}
-	out.End = end
 
 	if res, ok, err := optionalEventProp(e.GetRecurrenceRule()); err != nil {
 		return out, err
 	} else if ok {
 		out.RecurrenceRule.RRule = res
 	}
+	if res, ok, err := optionalEventProp(e.GetExtraRecurrenceRules()); err != nil {
+		return out, err
+	} else if ok {
+		out.ExtraRecurrenceRules = newRRules(res)
+	}
+	if res, ok, err := optionalEventProp(e.GetExceptionRules()); err != nil {
+		return out, err
+	} else if ok {
+		out.ExceptionRules = newRRules(res)
+	}
 	if res, ok, err := optionalEventProp(e.GetRecurrenceDates()); err != nil {
 		return out, err
 	} else if ok {
 		out.RecurrenceDates = res
 	}
+	if res, ok, err := optionalEventProp(e.GetRecurrencePeriods()); err != nil {
+		return out, err
+	} else if ok {
+		out.RecurrencePeriods = res
+	}
 	if res, ok, err := optionalEventProp(e.GetRecurrenceExceptionDates()); err != nil {
 		return out, err
 	} else if ok {
@@ -367,6 +495,7 @@ func NewEvent(e events.Event) (out Event, err error) {
 		return out, err
 	} else if ok {
 		out.RecurrenceInstance = &res
+		out.ThisAndFuture = e.GetThisAndFuture()
 	}
 	if res, ok, err := optionalEventProp(e.GetTrigger()); err != nil {
 		return out, err
@@ -444,19 +573,52 @@ func (o Event) Apply(e events.Event) error {
 	if o.Organizer != nil {
 		e.SetOrganizer(o.Organizer)
 	}
-	e.SetStart(o.Start)
-	e.SetEnd(o.End)
+	switch o.Kind {
+	case ical.CompToDo:
+		// todos end at their DUE (or after their DURATION), the start of a
+		// todo that only has a DUE is derived from it
+		if o.Due != nil {
+			e.SetDue(o.Due)
+		}
+		if !o.StartFromDue && !o.Start.Stamp.IsZero() {
+			e.SetStart(o.Start)
+			if o.Due == nil && o.Duration != nil {
+				e.SetDuration(o.Duration)
+			}
+		}
+	case ical.CompJournal:
+		if !o.Start.Stamp.IsZero() {
+			e.SetStart(o.Start)
+		}
+	default:
+		e.SetStart(o.Start)
+		if o.Duration != nil {
+			e.SetDuration(o.Duration)
+		} else {
+			e.SetEnd(o.End)
+		}
+	}
 	if o.RecurrenceRule.RRule != nil {
 		e.SetRecurrenceRule(o.RecurrenceRule.RRule)
 	}
+	if o.ExtraRecurrenceRules != nil {
+		e.SetExtraRecurrenceRules(rrules(o.ExtraRecurrenceRules))
+	}
+	if o.ExceptionRules != nil {
+		e.SetExceptionRules(rrules(o.ExceptionRules))
+	}
 	if o.RecurrenceDates != nil {
 		e.SetRecurrenceDates(o.RecurrenceDates)
 	}
+	if o.RecurrencePeriods != nil {
+		e.SetRecurrencePeriods(o.RecurrencePeriods)
+	}
 	if o.RecurrenceExceptionDates != nil {
 		e.SetRecurrenceExceptionDates(o.RecurrenceExceptionDates)
 	}
 	if o.RecurrenceInstance != nil {
 		e.SetRecurrenceInstance(o.RecurrenceInstance)
+		e.SetThisAndFuture(o.ThisAndFuture)
 	}
 	if o.Trigger != nil {
 		if o.Trigger.Relative == nil && o.Trigger.Absolute == nil {
@@ -472,6 +634,9 @@ func (o Event) Apply(e events.Event) error {
 		e.SetTrigger(o.Trigger)
 	}
 	for key, values := range o.Other {
+		// replace rather than append to the existing values so that applying
+		// an event read from the server does not duplicate them
+		e.Props.Del(key)
 		props := make([]ical.Prop, len(values))
 		for i, v := range values {
 			props[i] = ical.Prop{
@@ -492,6 +657,15 @@ func (o Event) Apply(e events.Event) error {
 type EventObject struct {
 	// ObjectPath is the event's calendar object path.
 	ObjectPath *string
+	// CalendarPath is the path of the calendar the event object was read
+	// from.
+	CalendarPath *string
+	// CalendarName is the display name of the calendar the event object was
+	// read from.
+	CalendarName *string
+	// CalendarColor is the color of the calendar the event object was read
+	// from (usually of the form #RRGGBB).
+	CalendarColor *string
 	// Main contains the main event for which the Overrides override.
 	Main Event
 	// Overrides contains all the recurrence overrides of the recurring event,
@@ -500,15 +674,21 @@ type EventObject struct {
 	Overrides []Event
 }
 
-func NewEventObject(obj caldav.CalendarObject) (EventObject, error) {
+// NewEventObject converts a calendar object, floating times are resolved in
+// the given location.
+func NewEventObject(obj caldav.CalendarObject, floating *time.Location) (EventObject, error) {
 	dtoObj := EventObject{ObjectPath: &obj.Path}
+	tzs := events.FindTimezones(obj.Data)
 	for _, component := range obj.Data.Children {
-		if component.Name != ical.CompEvent {
+		switch component.Name {
+		case ical.CompEvent, ical.CompToDo, ical.CompJournal:
+		default:
 			continue
 		}
 		event := events.Event{
-			Event:    ical.Event{Component: component},
-			Timezone: time.Local,
+			Event:     ical.Event{Component: component},
+			Timezone:  floating,
+			Timezones: tzs,
 		}
 		prop := component.Props.Get(ical.PropRecurrenceID)
 		if prop != nil {
@@ -530,7 +710,7 @@ func NewEventObject(obj caldav.CalendarObject) (EventObject, error) {
 
 type EventObjectList []EventObject
 
-func NewEventObjectList(objects []caldav.CalendarObject) (EventObjectList, error) {
+func NewEventObjectList(objects []caldav.CalendarObject, floating *time.Location) (EventObjectList, error) {
 	dtoObjects := make([]EventObject, len(objects))
 	// each calendar object only ever stores one unique VEVENT object.
 	//
@@ -538,7 +718,7 @@ func NewEventObjectList(objects []caldav.CalendarObject) (EventObjectList, error
 	// if the VEVENT has recurrence overrides, the recurrence overrides will
 	// come with the original VEVENT as separate VEVENT components.
 	for i, obj := range objects {
-		dtoObj, err := NewEventObject(obj)
+		dtoObj, err := NewEventObject(obj, floating)
 		if err != nil {
 			return dtoObjects, err
 		}
@@ -546,5 +726,3 @@ func NewEventObjectList(objects []caldav.CalendarObject) (EventObjectList, error
 	}
 	return dtoObjects, nil
 }
-
-type CalendarList []caldav.Calendar
@@ -63,3 +63,142 @@ func TestEventApplyReturnsErrorForInvalidTrigger(t *testing.T) {
 		t.Fatalf("expected trigger error, got %v", err)
 	}
 }
+
+func TestNewEventDerivesEndFromDuration(t *testing.T) {
+	event := newTestEvent()
+	dur := 90 * time.Minute
+	event.SetDuration(&dur)
+	if event.Props.Get(ical.PropDateTimeEnd) != nil {
+		t.Fatal("expected DTEND to be replaced by DURATION")
+	}
+
+	dtoEvent, err := NewEvent(event)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dtoEvent.Duration == nil || *dtoEvent.Duration != dur {
+		t.Fatalf("unexpected duration %v", dtoEvent.Duration)
+	}
+	if !dtoEvent.End.Stamp.Equal(time.Date(2026, 1, 1, 10, 30, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected end %v", dtoEvent.End.Stamp)
+	}
+
+	// saving keeps the duration
+	saved := newTestEvent()
+	err = dtoEvent.Apply(saved)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.Props.Get(ical.PropDateTimeEnd) != nil {
+		t.Fatal("expected DTEND to be removed")
+	}
+	if prop := saved.Props.Get(ical.PropDuration); prop == nil || prop.Value != "PT1H30M" {
+		t.Fatalf("unexpected DURATION %+v", prop)
+	}
+}
+
+func TestNewEventWithoutEnd(t *testing.T) {
+	event := newTestEvent()
+	event.Props.Del(ical.PropDateTimeEnd)
+	event.SetStart(events.Datetime{
+		Stamp:    time.Date(2026, 3, 7, 0, 0, 0, 0, time.UTC),
+		AllDay:   true,
+		Floating: true,
+	})
+
+	dtoEvent, err := NewEvent(event)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !dtoEvent.End.AllDay || !dtoEvent.End.Stamp.Equal(time.Date(2026, 3, 8, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected an all-day event without end to last a day, got %+v", dtoEvent.End)
+	}
+}
+
+func TestNewEventTodoSpan(t *testing.T) {
+	todo := events.Event{
+		Event:    ical.Event{Component: ical.NewComponent(ical.CompToDo)},
+		Timezone: time.UTC,
+	}
+	todo.SetUID("todo")
+	due := events.Datetime{Stamp: time.Date(2026, 1, 2, 17, 0, 0, 0, time.UTC)}
+	todo.SetDue(&due)
+
+	dtoEvent, err := NewEvent(todo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dtoEvent.Kind != ical.CompToDo {
+		t.Fatalf("unexpected kind %q", dtoEvent.Kind)
+	}
+	if !dtoEvent.Start.Stamp.Equal(due.Stamp) || !dtoEvent.End.Stamp.Equal(due.Stamp) {
+		t.Fatalf("expected a todo with only a due to take place at it, got %v-%v", dtoEvent.Start.Stamp, dtoEvent.End.Stamp)
+	}
+
+	// saving does not derive a DTSTART from the due
+	saved := events.Event{
+		Event:    ical.Event{Component: ical.NewComponent(ical.CompToDo)},
+		Timezone: time.UTC,
+	}
+	err = dtoEvent.Apply(saved)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.Props.Get(ical.PropDateTimeStart) != nil || saved.Props.Get(ical.PropDateTimeEnd) != nil {
+		t.Fatal("expected no DTSTART or DTEND on a todo with only a due")
+	}
+	if saved.Props.Get(ical.PropDue) == nil {
+		t.Fatal("expected DUE to be saved")
+	}
+
+	// nor from the due it had when it was read
+	dtoEvent.Due = &events.Datetime{Stamp: due.Stamp.Add(-time.Hour)}
+	err = dtoEvent.Apply(saved)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.Props.Get(ical.PropDateTimeStart) != nil {
+		t.Fatal("expected no DTSTART after changing the due of a todo with only a due")
+	}
+
+	start := events.Datetime{Stamp: time.Date(2026, 1, 2, 9, 0, 0, 0, time.UTC)}
+	todo.SetStart(start)
+	dtoEvent, err = NewEvent(todo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !dtoEvent.Start.Stamp.Equal(start.Stamp) || !dtoEvent.End.Stamp.Equal(due.Stamp) {
+		t.Fatalf("expected a todo to span from its start to its due, got %v-%v", dtoEvent.Start.Stamp, dtoEvent.End.Stamp)
+	}
+}
+
+func TestEventApplyReplacesOtherProps(t *testing.T) {
+	event := newTestEvent()
+	prop := ical.NewProp("X-TEST")
+	prop.Value = "old"
+	event.Props.Add(prop)
+	kept := ical.NewProp("X-KEPT")
+	kept.Value = "kept"
+	event.Props.Add(kept)
+
+	dtoEvent, err := NewEvent(event)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dtoEvent.Other = map[string][]PropValueDto{"X-TEST": {{Value: "new"}}}
+
+	// applying the event read from the object back onto it must not
+	// duplicate its other properties
+	err = dtoEvent.Apply(event)
+	if err != nil {
+		t.Fatal(err)
+	}
+	values := event.Props.Values("X-TEST")
+	if len(values) != 1 || values[0].Value != "new" {
+		t.Fatalf("expected X-TEST to be replaced, got %+v", values)
+	}
+	// other properties missing from the event are left alone
+	if values := event.Props.Values("X-KEPT"); len(values) != 1 {
+		t.Fatalf("expected X-KEPT to be kept, got %+v", values)
+	}
+}
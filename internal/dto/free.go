@@ -0,0 +1,12 @@
+package dto
+
+import "time"
+
+// FreeSlot is a span of time without any busy events.
+type FreeSlot struct {
+	Start    time.Time
+	End      time.Time
+	Duration time.Duration
+}
+
+type FreeSlotList []FreeSlot
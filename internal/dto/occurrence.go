@@ -0,0 +1,29 @@
+package dto
+
+import "time"
+
+// Occurrence is a single occurrence of an event object.
+type Occurrence struct {
+	// ObjectPath is the path of the event object the occurrence is of.
+	ObjectPath *string
+	// CalendarPath is the path of the calendar the event object was read
+	// from.
+	CalendarPath *string
+	// CalendarName is the display name of the calendar the event object was
+	// read from.
+	CalendarName *string
+	Start        time.Time
+	End          time.Time
+	AllDay       bool `default:"false"`
+	// RecurrenceID identifies the occurrence within the recurring event, it
+	// is nil if the event does not recur.
+	RecurrenceID *time.Time
+	// Override is set if the occurrence is defined by a recurrence override
+	// rather than the main event.
+	Override bool `default:"false"`
+	// Event is the main event (or override) of the occurrence, with its start
+	// and end set to the occurrence's.
+	Event Event
+}
+
+type OccurrenceList []Occurrence
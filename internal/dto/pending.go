@@ -0,0 +1,23 @@
+package dto
+
+import "time"
+
+// PushOutcome is the result of replaying a pending operation on the server.
+type PushOutcome struct {
+	// Operation is the kind of operation that was replayed, one of: put,
+	// delete.
+	Operation    string
+	CalendarPath string
+	ObjectPath   string
+	// QueuedAt is when the operation was made.
+	QueuedAt time.Time
+	// Outcome is one of: pushed, conflict, failed, skipped.
+	//
+	// Operations that conflict or fail are kept and retried on the next push,
+	// the operations after a failure are skipped (and kept as well).
+	Outcome string
+	// Error is the reason the operation was not pushed.
+	Error *string
+}
+
+type PushOutcomeList []PushOutcome
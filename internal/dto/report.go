@@ -0,0 +1,21 @@
+package dto
+
+import "time"
+
+// HoursReport is the time spent on the events of a group.
+type HoursReport struct {
+	// Group is the category, calendar or summary of the events, it is empty
+	// for the events that do not have one.
+	Group string `default:"\"\""`
+	Total time.Duration
+	// Periods are the totals of each day (or week) with events, in order.
+	Periods []HoursPeriod
+}
+
+// HoursPeriod is the time spent on the events of a group in a day or week.
+type HoursPeriod struct {
+	Start    time.Time
+	Duration time.Duration
+}
+
+type HoursReportList []HoursReport
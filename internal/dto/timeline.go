@@ -8,6 +8,28 @@ type TimeSegment struct {
 	Now          time.Time
 	Duration     time.Duration
 	ActiveEvents []Event
+	// Busy is the fraction of the segment during which at least one busy
+	// (not transparent or cancelled) event is active.
+	Busy float64
+	// Warnings describes the events that were skipped because they are
+	// invalid or could not be expanded.
+	Warnings []string
+	// ImpossibleTransitions are the transitions between events at different
+	// locations that do not leave enough time to travel, they are only
+	// found with --travel.
+	ImpossibleTransitions []Transition
+}
+
+// Transition is a move from the location of an event to the location of the
+// next one.
+type Transition struct {
+	From Event
+	To   Event
+	// Travel is the time needed to get from one location to the other.
+	Travel time.Duration
+	// Available is the time between the end of From and the start of To, it
+	// is negative if they overlap.
+	Available time.Duration
 }
 
 type Timeline []TimeSegment
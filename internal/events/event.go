@@ -1,6 +1,7 @@
 package events
 
 import (
+	"slices"
 	"time"
 
 	"github.com/emersion/go-ical"
@@ -11,6 +12,16 @@ type Datetime struct {
 	Stamp    time.Time
 	AllDay   bool `default:"false"`
 	Floating bool `default:"false"`
+	// Timezone is the TZID the datetime was read with (if any), as nushell
+	// only keeps the UTC offset of datetimes.
+	Timezone string `default:"\"\""`
+}
+
+// Period defines a PERIOD value, its end is either an explicit end or derived
+// from a duration.
+type Period struct {
+	Start Datetime
+	End   Datetime
 }
 
 type EventTriggerRelative int
@@ -56,7 +67,12 @@ const (
 )
 
 type Event struct {
+	// Timezone is the location of floating times.
 	Timezone *time.Location
+	// Timezones are the VTIMEZONE components of the calendar object the
+	// event was read from, used to resolve TZIDs that are not in the
+	// timezone database.
+	Timezones Timezones
 	ical.Event
 }
 
@@ -67,7 +83,9 @@ type EventObject struct {
 	Overrides  []Event
 }
 
-func (obj EventObject) ToCalendar() *ical.Calendar {
+// NewCalendar creates an empty VCALENDAR with the properties required by RFC
+// 5545.
+func NewCalendar() *ical.Calendar {
 	cal := ical.NewCalendar()
 
 	version := ical.NewProp(ical.PropVersion)
@@ -78,9 +96,54 @@ func (obj EventObject) ToCalendar() *ical.Calendar {
 	productId.Value = "-//LQR471814//Nushell CalDav Plugin 0.1//EN"
 	cal.Props.Set(productId)
 
+	return cal
+}
+
+// ToCalendar creates the calendar object of the event, with a VTIMEZONE for
+// every TZID referenced by the event so that servers do not have to guess the
+// timezones.
+func (obj EventObject) ToCalendar() *ical.Calendar {
+	cal := NewCalendar()
+	cal.Children = append(cal.Children, obj.timezones()...)
 	cal.Children = append(cal.Children, obj.Main.Component)
 	for _, ov := range obj.Overrides {
 		cal.Children = append(cal.Children, ov.Event.Component)
 	}
 	return cal
 }
+
+// timezones generates the VTIMEZONE components for the TZIDs referenced by
+// the event, covering the years of the values that reference them (and 10
+// more years for recurring events). TZIDs that cannot be resolved are
+// skipped.
+func (obj EventObject) timezones() (out []*ical.Component) {
+	years := map[string][2]int{}
+	referencedTimezones(obj.Main.Component, years)
+	for _, ov := range obj.Overrides {
+		referencedTimezones(ov.Component, years)
+	}
+	recurring := obj.Main.Props.Get(ical.PropRecurrenceRule) != nil
+
+	tzids := make([]string, 0, len(years))
+	for tzid := range years {
+		tzids = append(tzids, tzid)
+	}
+	slices.Sort(tzids)
+	for _, tzid := range tzids {
+		loc, err := LoadTimezone(tzid, obj.Main.Timezones)
+		if err != nil {
+			continue
+		}
+		span := years[tzid]
+		if recurring {
+			span[1] += 10
+		}
+		from := time.Date(span[0], 1, 1, 0, 0, 0, 0, time.UTC)
+		to := time.Date(span[1]+1, 1, 1, 0, 0, 0, 0, time.UTC)
+		tz := NewTimezone(loc, from, to)
+		// the location may have been resolved from a different name
+		tz.Props.SetText(ical.PropTimezoneID, tzid)
+		out = append(out, tz)
+	}
+	return
+}
@@ -0,0 +1,433 @@
+package events
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/LQR471814/nu_plugin_caldav/internal/recurrence"
+	"github.com/emersion/go-ical"
+	"github.com/teambition/rrule-go"
+)
+
+// recurrenceProps are the properties that define the recurrence set of the
+// main event, overrides do not have them.
+var recurrenceProps = []string{
+	ical.PropRecurrenceRule,
+	PropExceptionRule,
+	ical.PropRecurrenceDates,
+	ical.PropExceptionDates,
+}
+
+func cloneComponent(comp *ical.Component) *ical.Component {
+	out := ical.NewComponent(comp.Name)
+	for name, props := range comp.Props {
+		cloned := make([]ical.Prop, len(props))
+		for i, p := range props {
+			cloned[i] = ical.Prop{
+				Name:   p.Name,
+				Value:  p.Value,
+				Params: make(ical.Params, len(p.Params)),
+			}
+			for k, v := range p.Params {
+				cloned[i].Params[k] = slices.Clone(v)
+			}
+		}
+		out.Props[name] = cloned
+	}
+	for _, child := range comp.Children {
+		out.Children = append(out.Children, cloneComponent(child))
+	}
+	return out
+}
+
+// instanceDatetime returns the datetime that identifies an instance of the
+// event, in the same format as its DTSTART.
+func (obj EventObject) instanceDatetime(rid time.Time) (Datetime, error) {
+	start, err := obj.Main.GetStart()
+	if err != nil {
+		return Datetime{}, err
+	}
+	id := start
+	id.Stamp = rid.In(start.Stamp.Location())
+	return id, nil
+}
+
+// sameInstance reports whether two datetimes identify the same instance,
+// all-day instances are compared by their date.
+func sameInstance(a, b Datetime) bool {
+	if a.AllDay || b.AllDay {
+		a.Stamp = a.Stamp.In(b.Stamp.Location())
+		return a.Stamp.Year() == b.Stamp.Year() && a.Stamp.YearDay() == b.Stamp.YearDay()
+	}
+	return a.Stamp.Equal(b.Stamp)
+}
+
+// optionalDatetimes returns the stamps of a list of datetimes, a missing
+// property is an empty list.
+func optionalDatetimes(list []Datetime, err error) ([]time.Time, error) {
+	if errors.Is(err, ErrPropertyNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	out := make([]time.Time, len(list))
+	for i, d := range list {
+		out[i] = d.Stamp
+	}
+	return out, nil
+}
+
+// recurrenceSet returns the recurrence set of the main event.
+func (obj EventObject) recurrenceSet() (set recurrence.Set, err error) {
+	start, err := obj.Main.GetStart()
+	if err != nil {
+		return
+	}
+	set.Start = start.Stamp
+	set.AllDay = start.AllDay
+	for _, name := range []string{ical.PropRecurrenceRule, PropExceptionRule} {
+		var rules []*rrule.RRule
+		rules, err = obj.Main.getRecurrenceRules(name)
+		if errors.Is(err, ErrPropertyNotFound) {
+			err = nil
+			continue
+		}
+		if err != nil {
+			return
+		}
+		for _, rule := range rules {
+			if name == PropExceptionRule {
+				set.ExRules = append(set.ExRules, rule.OrigOptions)
+				continue
+			}
+			set.RRules = append(set.RRules, rule.OrigOptions)
+		}
+	}
+	set.RDates, err = optionalDatetimes(obj.Main.GetRecurrenceDates())
+	if err != nil {
+		return
+	}
+	set.ExDates, err = optionalDatetimes(obj.Main.GetRecurrenceExceptionDates())
+	if err != nil {
+		return
+	}
+	periods, err := obj.Main.GetRecurrencePeriods()
+	if err != nil && !errors.Is(err, ErrPropertyNotFound) {
+		return
+	}
+	err = nil
+	for _, p := range periods {
+		set.RPeriods = append(set.RPeriods, recurrence.Period{Start: p.Start.Stamp, End: p.End.Stamp})
+	}
+	return
+}
+
+// checkInstance returns an error if the event does not have an instance that
+// starts at rid.
+func (obj EventObject) checkInstance(rid time.Time) error {
+	set, err := obj.recurrenceSet()
+	if err != nil {
+		return err
+	}
+	ok, err := set.Contains(rid)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("%v is not an instance of the event", rid)
+	}
+	return nil
+}
+
+// FindOverride returns the index of the override of an instance, or -1 if
+// the instance is not overridden.
+func (obj EventObject) FindOverride(rid time.Time) (int, error) {
+	id, err := obj.instanceDatetime(rid)
+	if err != nil {
+		return -1, err
+	}
+	for i, ov := range obj.Overrides {
+		instance, err := ov.GetRecurrenceInstance()
+		if err != nil {
+			return -1, fmt.Errorf("get recurrence instance of override %d: %w", i, err)
+		}
+		if sameInstance(instance, id) {
+			return i, nil
+		}
+	}
+	return -1, nil
+}
+
+// moveEvent sets the start of an event, keeping its duration.
+func moveEvent(e Event, from, to Datetime) error {
+	end, err := e.GetEnd()
+	switch {
+	case errors.Is(err, ErrPropertyNotFound):
+		// the end is derived from the start
+	case err != nil:
+		return err
+	default:
+		if from.AllDay {
+			days := int(end.Stamp.Sub(from.Stamp).Round(day) / day)
+			end.Stamp = to.Stamp.AddDate(0, 0, days)
+		} else {
+			end.Stamp = to.Stamp.Add(end.Stamp.Sub(from.Stamp))
+		}
+		e.SetEnd(end)
+	}
+	e.SetStart(to)
+	return nil
+}
+
+// Override returns the override of an instance of the event, creating it from
+// the main event if the instance is not overridden yet.
+func (obj *EventObject) Override(rid time.Time) (*Event, error) {
+	i, err := obj.FindOverride(rid)
+	if err != nil {
+		return nil, err
+	}
+	if i >= 0 {
+		return &obj.Overrides[i], nil
+	}
+	err = obj.checkInstance(rid)
+	if err != nil {
+		return nil, err
+	}
+
+	start, err := obj.Main.GetStart()
+	if err != nil {
+		return nil, err
+	}
+	id, err := obj.instanceDatetime(rid)
+	if err != nil {
+		return nil, err
+	}
+	ov := Event{
+		Timezone:  obj.Main.Timezone,
+		Timezones: obj.Main.Timezones,
+		Event:     ical.Event{Component: cloneComponent(obj.Main.Component)},
+	}
+	for _, name := range recurrenceProps {
+		ov.Props.Del(name)
+	}
+	err = moveEvent(ov, start, id)
+	if err != nil {
+		return nil, err
+	}
+	ov.SetRecurrenceInstance(&id)
+	obj.Overrides = append(obj.Overrides, ov)
+	return &obj.Overrides[len(obj.Overrides)-1], nil
+}
+
+// Exclude removes an instance from the event with an EXDATE, along with its
+// override if it has one.
+func (obj *EventObject) Exclude(rid time.Time) error {
+	i, err := obj.FindOverride(rid)
+	if err != nil {
+		return err
+	}
+	if i >= 0 {
+		obj.Overrides = slices.Delete(obj.Overrides, i, i+1)
+	} else {
+		err = obj.checkInstance(rid)
+		if err != nil {
+			return err
+		}
+	}
+	id, err := obj.instanceDatetime(rid)
+	if err != nil {
+		return err
+	}
+	exdates, err := obj.Main.GetRecurrenceExceptionDates()
+	if err != nil && !errors.Is(err, ErrPropertyNotFound) {
+		return err
+	}
+	obj.Main.SetRecurrenceExceptionDates(append(exdates, id))
+	return nil
+}
+
+// splitRule splits a recurrence rule into the rule of the instances before
+// an instance and the rule of the instances from it on.
+func splitRule(rule *rrule.RRule, at time.Time) (before, after *rrule.RRule, err error) {
+	beforeOpts := rule.OrigOptions
+	afterOpts := rule.OrigOptions
+	// the rule does not generate any instance before the split if DTSTART
+	// is not one of its instances, a COUNT of 0 would not limit it so it
+	// ends with an UNTIL instead
+	n := 0
+	if beforeOpts.Count > 0 {
+		n = len(rule.Between(rule.OrigOptions.Dtstart.Add(-time.Second), at, false))
+		if n >= beforeOpts.Count {
+			return nil, nil, fmt.Errorf("instance %v is after the last instance of the recurrence rule", at)
+		}
+		afterOpts.Count -= n
+	}
+	if n > 0 {
+		beforeOpts.Count = n
+	} else {
+		beforeOpts.Count = 0
+		// UNTIL is inclusive, an earlier UNTIL is kept as the rule may not
+		// generate the instance (ex. it is a RDATE)
+		until := at.Add(-time.Second)
+		if beforeOpts.Until.IsZero() || until.Before(beforeOpts.Until) {
+			beforeOpts.Until = until
+		}
+	}
+	afterOpts.Dtstart = at
+	before, err = rrule.NewRRule(beforeOpts)
+	if err != nil {
+		return
+	}
+	after, err = rrule.NewRRule(afterOpts)
+	return
+}
+
+// splitDatetimes splits datetimes into the ones before a time and the ones
+// from it on.
+func splitDatetimes(list []Datetime, at time.Time) (before, after []Datetime) {
+	for _, d := range list {
+		if d.Stamp.Before(at) {
+			before = append(before, d)
+			continue
+		}
+		after = append(after, d)
+	}
+	return
+}
+
+// Split ends the recurrence of the event before an instance and returns a new
+// event object (with the given UID) for the instances from it on. Recurrence
+// dates, exceptions and overrides are moved to the object they belong to.
+func (obj *EventObject) Split(rid time.Time, uid string) (next EventObject, err error) {
+	start, err := obj.Main.GetStart()
+	if err != nil {
+		return
+	}
+	id, err := obj.instanceDatetime(rid)
+	if err != nil {
+		return
+	}
+	if !id.Stamp.After(start.Stamp) {
+		err = fmt.Errorf("cannot split the series at its first instance %v", rid)
+		return
+	}
+	err = obj.checkInstance(rid)
+	if err != nil {
+		return
+	}
+
+	next.Main = Event{
+		Timezone:  obj.Main.Timezone,
+		Timezones: obj.Main.Timezones,
+		Event:     ical.Event{Component: cloneComponent(obj.Main.Component)},
+	}
+	next.Main.SetUID(uid)
+	err = moveEvent(next.Main, start, id)
+	if err != nil {
+		return
+	}
+
+	rules, err := obj.Main.getRecurrenceRules(ical.PropRecurrenceRule)
+	if err != nil && !errors.Is(err, ErrPropertyNotFound) {
+		return
+	}
+	obj.Main.Props.Del(ical.PropRecurrenceRule)
+	next.Main.Props.Del(ical.PropRecurrenceRule)
+	for _, rule := range rules {
+		var before, after *rrule.RRule
+		before, after, err = splitRule(rule, id.Stamp)
+		if err != nil {
+			return
+		}
+		obj.Main.addRecurrenceRules(ical.PropRecurrenceRule, []*rrule.RRule{before})
+		next.Main.addRecurrenceRules(ical.PropRecurrenceRule, []*rrule.RRule{after})
+	}
+
+	for _, list := range []struct {
+		get func() ([]Datetime, error)
+		set func(Event, []Datetime)
+	}{
+		{get: obj.Main.GetRecurrenceDates, set: Event.SetRecurrenceDates},
+		{get: obj.Main.GetRecurrenceExceptionDates, set: Event.SetRecurrenceExceptionDates},
+	} {
+		var dates []Datetime
+		dates, err = list.get()
+		if errors.Is(err, ErrPropertyNotFound) {
+			err = nil
+			continue
+		}
+		if err != nil {
+			return
+		}
+		before, after := splitDatetimes(dates, id.Stamp)
+		list.set(obj.Main, before)
+		list.set(next.Main, after)
+	}
+
+	periods, err := obj.Main.GetRecurrencePeriods()
+	if err != nil && !errors.Is(err, ErrPropertyNotFound) {
+		return
+	}
+	err = nil
+	var before, after []Period
+	for _, p := range periods {
+		if p.Start.Stamp.Before(id.Stamp) {
+			before = append(before, p)
+			continue
+		}
+		after = append(after, p)
+	}
+	obj.Main.SetRecurrencePeriods(before)
+	next.Main.SetRecurrencePeriods(after)
+
+	var kept []Event
+	for _, ov := range obj.Overrides {
+		var instance Datetime
+		instance, err = ov.GetRecurrenceInstance()
+		if err != nil {
+			return
+		}
+		if instance.Stamp.Before(id.Stamp) {
+			kept = append(kept, ov)
+			continue
+		}
+		ov.SetUID(uid)
+		next.Overrides = append(next.Overrides, ov)
+	}
+	obj.Overrides = kept
+	return
+}
+
+// ShiftInstances moves the RECURRENCE-IDs of the overrides and the EXDATEs of
+// the event, as instances are identified by their start which changes when
+// the start of the event is moved.
+func (obj EventObject) ShiftInstances(delta time.Duration) error {
+	if delta == 0 {
+		return nil
+	}
+	for i, ov := range obj.Overrides {
+		instance, err := ov.GetRecurrenceInstance()
+		if err != nil {
+			return fmt.Errorf("get recurrence instance of override %d: %w", i, err)
+		}
+		thisAndFuture := ov.GetThisAndFuture()
+		instance.Stamp = addDuration(instance.Stamp, delta)
+		ov.SetRecurrenceInstance(&instance)
+		ov.SetThisAndFuture(thisAndFuture)
+	}
+	exdates, err := obj.Main.GetRecurrenceExceptionDates()
+	if errors.Is(err, ErrPropertyNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	for i := range exdates {
+		exdates[i].Stamp = addDuration(exdates[i].Stamp, delta)
+	}
+	obj.Main.SetRecurrenceExceptionDates(exdates)
+	return nil
+}
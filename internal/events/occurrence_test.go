@@ -0,0 +1,227 @@
+package events
+
+import (
+	"testing"
+	"time"
+
+	"github.com/emersion/go-ical"
+)
+
+func newRecurringObject(t *testing.T, rrule string) EventObject {
+	t.Helper()
+	main := newTestEvent()
+	for _, p := range []struct{ name, value string }{
+		{ical.PropUID, "standup"},
+		{ical.PropSummary, "Standup"},
+		{ical.PropDateTimeStart, "20251020T090000"},
+		{ical.PropDateTimeEnd, "20251020T093000"},
+		{ical.PropRecurrenceRule, rrule},
+	} {
+		prop := ical.NewProp(p.name)
+		prop.Value = p.value
+		if p.name == ical.PropDateTimeStart || p.name == ical.PropDateTimeEnd {
+			prop.Params.Set(ical.PropTimezoneID, "America/New_York")
+		}
+		main.Props.Set(prop)
+	}
+	return EventObject{Main: main}
+}
+
+func TestOverrideCreatesInstance(t *testing.T) {
+	obj := newRecurringObject(t, "FREQ=DAILY;COUNT=5")
+	ny, _ := time.LoadLocation("America/New_York")
+	rid := time.Date(2025, 10, 22, 9, 0, 0, 0, ny)
+
+	ov, err := obj.Override(rid.UTC())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ov.Props.Get(ical.PropRecurrenceRule) != nil {
+		t.Fatal("override should not recur")
+	}
+	summary, _ := ov.GetSummary()
+	instance, _ := ov.GetRecurrenceInstance()
+	end, _ := ov.GetEnd()
+	if summary != "Standup" || !instance.Stamp.Equal(rid) ||
+		ov.Props.Get(ical.PropRecurrenceID).Params.Get(ical.PropTimezoneID) != "America/New_York" ||
+		!end.Stamp.Equal(rid.Add(30*time.Minute)) {
+		t.Fatalf("unexpected override %+v", ov.Props)
+	}
+
+	// the same override is returned for the instance
+	again, err := obj.Override(rid)
+	if err != nil || len(obj.Overrides) != 1 || again != &obj.Overrides[0] {
+		t.Fatalf("expected the existing override, got %d overrides (%v)", len(obj.Overrides), err)
+	}
+}
+
+func TestExcludeRemovesOverride(t *testing.T) {
+	obj := newRecurringObject(t, "FREQ=DAILY;COUNT=5")
+	ny, _ := time.LoadLocation("America/New_York")
+	rid := time.Date(2025, 10, 22, 9, 0, 0, 0, ny)
+	if _, err := obj.Override(rid); err != nil {
+		t.Fatal(err)
+	}
+	if err := obj.Exclude(rid); err != nil {
+		t.Fatal(err)
+	}
+	exdates, err := obj.Main.GetRecurrenceExceptionDates()
+	if err != nil || len(exdates) != 1 || !exdates[0].Stamp.Equal(rid) || len(obj.Overrides) != 0 {
+		t.Fatalf("unexpected exception dates %v (%v) with %d overrides", exdates, err, len(obj.Overrides))
+	}
+}
+
+func TestSplit(t *testing.T) {
+	ny, _ := time.LoadLocation("America/New_York")
+	rid := time.Date(2025, 10, 23, 9, 0, 0, 0, ny)
+
+	cases := []struct {
+		rrule  string
+		before string
+		after  string
+	}{
+		{"FREQ=DAILY;COUNT=5", "FREQ=DAILY;COUNT=3", "FREQ=DAILY;COUNT=2"},
+		{"FREQ=DAILY;UNTIL=20251101T000000Z", "FREQ=DAILY;UNTIL=20251023T125959Z", "FREQ=DAILY;UNTIL=20251101T000000Z"},
+	}
+	for _, c := range cases {
+		obj := newRecurringObject(t, c.rrule)
+		if _, err := obj.Override(rid.AddDate(0, 0, -2)); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := obj.Override(rid.AddDate(0, 0, 1)); err != nil {
+			t.Fatal(err)
+		}
+
+		next, err := obj.Split(rid, "standup-2")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v := obj.Main.Props.Get(ical.PropRecurrenceRule).Value; v != c.before {
+			t.Fatalf("%s: unexpected truncated rule %q", c.rrule, v)
+		}
+		if v := next.Main.Props.Get(ical.PropRecurrenceRule).Value; v != c.after {
+			t.Fatalf("%s: unexpected new rule %q", c.rrule, v)
+		}
+		uid, _ := next.Main.GetUID()
+		start, _ := next.Main.GetStart()
+		if uid != "standup-2" || !start.Stamp.Equal(rid) {
+			t.Fatalf("%s: unexpected new series %s at %v", c.rrule, uid, start.Stamp)
+		}
+		if len(obj.Overrides) != 1 || len(next.Overrides) != 1 {
+			t.Fatalf("%s: unexpected overrides %d, %d", c.rrule, len(obj.Overrides), len(next.Overrides))
+		}
+		if uid, _ := next.Overrides[0].GetUID(); uid != "standup-2" {
+			t.Fatalf("%s: moved override has UID %q", c.rrule, uid)
+		}
+
+		if err := next.ShiftInstances(time.Hour); err != nil {
+			t.Fatal(err)
+		}
+		instance, _ := next.Overrides[0].GetRecurrenceInstance()
+		if !instance.Stamp.Equal(rid.AddDate(0, 0, 1).Add(time.Hour)) {
+			t.Fatalf("%s: unexpected shifted instance %v", c.rrule, instance.Stamp)
+		}
+	}
+
+	// DTSTART (a monday) is not an instance of the rule, so no instance of
+	// the rule comes before the split
+	obj := newRecurringObject(t, "FREQ=WEEKLY;BYDAY=WE;COUNT=3")
+	next, err := obj.Split(time.Date(2025, 10, 22, 9, 0, 0, 0, ny), "standup-2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := obj.Main.Props.Get(ical.PropRecurrenceRule).Value; v != "FREQ=WEEKLY;UNTIL=20251022T125959Z;BYDAY=WE" {
+		t.Fatalf("unexpected truncated rule %q", v)
+	}
+	if v := next.Main.Props.Get(ical.PropRecurrenceRule).Value; v != "FREQ=WEEKLY;COUNT=3;BYDAY=WE" {
+		t.Fatalf("unexpected new rule %q", v)
+	}
+
+	obj = newRecurringObject(t, "FREQ=DAILY;COUNT=5")
+	if _, err := obj.Split(time.Date(2025, 10, 20, 9, 0, 0, 0, ny), "standup-2"); err == nil {
+		t.Fatal("expected an error when splitting at the first instance")
+	}
+}
+
+func TestRejectsUnknownInstances(t *testing.T) {
+	ny, _ := time.LoadLocation("America/New_York")
+	for _, rid := range []time.Time{
+		// between instances
+		time.Date(2025, 10, 22, 9, 30, 0, 0, ny),
+		// after the last instance
+		time.Date(2025, 10, 30, 9, 0, 0, 0, ny),
+	} {
+		obj := newRecurringObject(t, "FREQ=DAILY;COUNT=5")
+		if _, err := obj.Override(rid); err == nil {
+			t.Errorf("%v: expected Override to fail", rid)
+		}
+		if err := obj.Exclude(rid); err == nil {
+			t.Errorf("%v: expected Exclude to fail", rid)
+		}
+		if _, err := obj.Split(rid, "standup-2"); err == nil {
+			t.Errorf("%v: expected Split to fail", rid)
+		}
+		if len(obj.Overrides) != 0 || obj.Main.Props.Get(ical.PropExceptionDates) != nil {
+			t.Errorf("%v: expected the event to be unchanged", rid)
+		}
+	}
+
+	// excluded instances are not instances anymore
+	obj := newRecurringObject(t, "FREQ=DAILY;COUNT=5")
+	rid := time.Date(2025, 10, 22, 9, 0, 0, 0, ny)
+	if err := obj.Exclude(rid); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := obj.Override(rid); err == nil {
+		t.Error("expected Override of an excluded instance to fail")
+	}
+}
+
+func TestSplitKeepsEarlierUntil(t *testing.T) {
+	ny, _ := time.LoadLocation("America/New_York")
+	obj := newRecurringObject(t, "FREQ=DAILY;UNTIL=20251022T130000Z")
+	rdate := ical.NewProp(ical.PropRecurrenceDates)
+	rdate.Value = "20251025T090000"
+	rdate.Params.Set(ical.PropTimezoneID, "America/New_York")
+	obj.Main.Props.Set(rdate)
+
+	_, err := obj.Split(time.Date(2025, 10, 25, 9, 0, 0, 0, ny), "standup-2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := obj.Main.Props.Get(ical.PropRecurrenceRule).Value; v != "FREQ=DAILY;UNTIL=20251022T130000Z" {
+		t.Fatalf("unexpected truncated rule %q", v)
+	}
+}
+
+func TestSplitAllDay(t *testing.T) {
+	main := newTestEvent()
+	for _, p := range []struct{ name, value string }{
+		{ical.PropUID, "trip"},
+		{ical.PropDateTimeStart, "20251020"},
+		{ical.PropRecurrenceRule, "FREQ=DAILY;UNTIL=20251030"},
+	} {
+		prop := ical.NewProp(p.name)
+		prop.Value = p.value
+		if p.name == ical.PropDateTimeStart {
+			prop.Params.Set(ical.ParamValue, "DATE")
+		}
+		main.Props.Set(prop)
+	}
+	obj := EventObject{Main: main}
+	start, err := obj.Main.GetStart()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	next, err := obj.Split(start.Stamp.AddDate(0, 0, 3), "trip-2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := obj.Main.Props.Get(ical.PropRecurrenceRule).Value; v != "FREQ=DAILY;UNTIL=20251022" {
+		t.Fatalf("unexpected truncated rule %q", v)
+	}
+	if v := next.Main.Props.Get(ical.PropRecurrenceRule).Value; v != "FREQ=DAILY;UNTIL=20251030" {
+		t.Fatalf("unexpected new rule %q", v)
+	}
+}
@@ -93,7 +93,7 @@ func (e Event) getDuration(name string) (time.Duration, error) {
 }
 func (e Event) setDuration(name string, value time.Duration) {
 	prop := ical.NewProp(name)
-	prop.SetDuration(value)
+	prop.Value = formatDuration(value)
 	e.Props.Set(prop)
 }
 
@@ -108,11 +108,11 @@ func (e Event) getDatetime(name string) (Datetime, error) {
 	if prop == nil {
 		return Datetime{}, propertyNotFoundError(name)
 	}
-	tz, err := getTzidParam(prop, e.Timezone)
+	tz, err := getTzidParam(prop, e.Timezones)
 	if err != nil {
 		return Datetime{}, fmt.Errorf("%s: load timezone: %w", name, err)
 	}
-	d, err := parseDateText(prop.Value, tz)
+	d, err := e.parseDatetime(prop.Value, prop.Params.Get(ical.PropTimezoneID), tz)
 	if err != nil {
 		return Datetime{}, fmt.Errorf("%s: parse datetime %q: %w", name, prop.Value, err)
 	}
@@ -120,58 +120,120 @@ func (e Event) getDatetime(name string) (Datetime, error) {
 }
 func (e Event) setDatetime(name string, datetime Datetime) {
 	prop := ical.NewProp(name)
-	prop.Value = serializeDateText(datetime)
-	if !datetime.Floating {
-		setTzidParam(prop, datetime.Stamp.Location())
+	switch {
+	case datetime.AllDay:
+		prop.Params.Set(ical.ParamValue, "DATE")
+	case !datetime.Floating:
+		tz, tzid := e.datetimeZone(name, datetime)
+		datetime.Stamp = datetime.Stamp.In(tz)
+		if tzid != "" {
+			prop.Params.Set(ical.PropTimezoneID, tzid)
+		}
 	}
+	prop.Value = serializeDateText(datetime)
 	e.Props.Set(prop)
 }
 
-func (e Event) getDatetimeList(name string) ([]Datetime, error) {
-	prop := e.Props.Get(name)
-	if prop == nil {
-		return nil, propertyNotFoundError(name)
-	}
-	tz, err := getTzidParam(prop, e.Timezone)
+// parseDatetime parses the datetime value of a property with the given TZID
+// (tz is nil if it does not have one).
+func (e Event) parseDatetime(s, tzid string, tz *time.Location) (Datetime, error) {
+	d, err := parseDateText(s, e.zone(tz))
 	if err != nil {
-		return nil, fmt.Errorf("%s: load timezone: %w", name, err)
+		return Datetime{}, err
+	}
+	// times with a TZID are not floating
+	if tz != nil {
+		d.Floating = false
+		d.Timezone = tzid
 	}
+	return d, nil
+}
 
-	segments := strings.Split(prop.Value, ",")
-	dates := make([]Datetime, len(segments))
+// zone returns the location of a TZID, or the location floating times are
+// resolved in if there is no TZID.
+func (e Event) zone(tz *time.Location) *time.Location {
+	if tz != nil {
+		return tz
+	}
+	return e.Timezone
+}
 
-	for i, s := range segments {
-		parsed, err := parseDateText(s, tz)
+// isPeriodList reports whether a property is a list of periods rather than
+// datetimes (ex. RDATE;VALUE=PERIOD).
+func isPeriodList(prop ical.Prop) bool {
+	return prop.Params.Get(ical.ParamValue) == string(ical.ValuePeriod)
+}
+
+// getDatetimeList reads the values of all the properties of a name, as a
+// property (ex. EXDATE) may be repeated.
+func (e Event) getDatetimeList(name string) ([]Datetime, error) {
+	var dates []Datetime
+	found := false
+	for _, prop := range e.Props.Values(name) {
+		if isPeriodList(prop) {
+			continue
+		}
+		found = true
+		tz, err := getTzidParam(&prop, e.Timezones)
 		if err != nil {
-			return nil, fmt.Errorf("%s: parse datetime item %d %q: %w", name, i, s, err)
+			return nil, fmt.Errorf("%s: load timezone: %w", name, err)
 		}
-		if parsed.Floating && tz != nil {
-			parsed.Floating = false
+		tzid := prop.Params.Get(ical.PropTimezoneID)
+		for i, s := range strings.Split(prop.Value, ",") {
+			parsed, err := e.parseDatetime(s, tzid, tz)
+			if err != nil {
+				return nil, fmt.Errorf("%s: parse datetime item %d %q: %w", name, i, s, err)
+			}
+			dates = append(dates, parsed)
 		}
-		dates[i] = parsed
+	}
+	if !found {
+		return nil, propertyNotFoundError(name)
 	}
 	return dates, nil
 }
+
+// setDatetimeList adds a property with the given datetimes, the existing
+// properties of the name must be deleted by the caller.
 func (e Event) setDatetimeList(name string, datetimes []Datetime) {
 	prop := ical.NewProp(name)
 
-	var specifytz *time.Location
+	allday := true
+	floating := true
+	var zoned *Datetime
 	for _, d := range datetimes {
-		if d.Floating || d.Stamp.Location() == time.UTC {
-			continue
+		if !d.AllDay {
+			allday = false
+		}
+		if !d.Floating {
+			floating = false
+			if zoned == nil {
+				zoned = &d
+			}
 		}
-		specifytz = d.Stamp.Location()
-		break
 	}
-	if specifytz != nil {
-		setTzidParam(prop, specifytz)
+
+	// all the values of a property share the same TZID
+	tz := time.UTC
+	if !allday && zoned != nil {
+		var tzid string
+		tz, tzid = e.datetimeZone(name, *zoned)
+		if tzid != "" {
+			prop.Params.Set(ical.PropTimezoneID, tzid)
+		}
 	}
 
-	allday := true
 	datestr := make([]string, len(datetimes))
 	for i, d := range datetimes {
-		if !d.AllDay {
-			allday = false
+		switch {
+		case allday:
+			d.AllDay = true
+		case floating:
+			d.AllDay = false
+		default:
+			d.AllDay = false
+			d.Floating = false
+			d.Stamp = d.Stamp.In(tz)
 		}
 		datestr[i] = serializeDateText(d)
 	}
@@ -183,5 +245,104 @@ func (e Event) setDatetimeList(name string, datetimes []Datetime) {
 	}
 
 	prop.Value = strings.Join(datestr, ",")
-	e.Props.Set(prop)
+	e.Props.Add(prop)
+}
+
+// getPeriodList reads the values of all the period properties of a name.
+func (e Event) getPeriodList(name string) ([]Period, error) {
+	var periods []Period
+	found := false
+	for _, prop := range e.Props.Values(name) {
+		if !isPeriodList(prop) {
+			continue
+		}
+		found = true
+		tz, err := getTzidParam(&prop, e.Timezones)
+		if err != nil {
+			return nil, fmt.Errorf("%s: load timezone: %w", name, err)
+		}
+		tzid := prop.Params.Get(ical.PropTimezoneID)
+		for i, s := range strings.Split(prop.Value, ",") {
+			start, end, ok := strings.Cut(s, "/")
+			if !ok {
+				return nil, fmt.Errorf("%s: parse period item %d %q: missing \"/\"", name, i, s)
+			}
+			var p Period
+			p.Start, err = e.parseDatetime(start, tzid, tz)
+			if err != nil {
+				return nil, fmt.Errorf("%s: parse period item %d start %q: %w", name, i, start, err)
+			}
+			if strings.ContainsRune(end, 'P') {
+				dur := ical.NewProp(ical.PropDuration)
+				dur.Value = end
+				d, err := dur.Duration()
+				if err != nil {
+					return nil, fmt.Errorf("%s: parse period item %d duration %q: %w", name, i, end, err)
+				}
+				p.End = p.Start
+				p.End.Stamp = addDuration(p.Start.Stamp, d)
+			} else {
+				p.End, err = e.parseDatetime(end, tzid, tz)
+				if err != nil {
+					return nil, fmt.Errorf("%s: parse period item %d end %q: %w", name, i, end, err)
+				}
+			}
+			periods = append(periods, p)
+		}
+	}
+	if !found {
+		return nil, propertyNotFoundError(name)
+	}
+	return periods, nil
+}
+
+// setPeriodList adds a property with the given periods (written with
+// explicit ends), the existing period properties of the name must be deleted
+// by the caller.
+func (e Event) setPeriodList(name string, periods []Period) {
+	prop := ical.NewProp(name)
+	prop.Params.Set(ical.ParamValue, string(ical.ValuePeriod))
+
+	floating := true
+	tz := time.UTC
+	for _, p := range periods {
+		if !p.Start.Floating {
+			floating = false
+			var tzid string
+			tz, tzid = e.datetimeZone(name, p.Start)
+			if tzid != "" {
+				prop.Params.Set(ical.PropTimezoneID, tzid)
+			}
+			break
+		}
+	}
+
+	values := make([]string, len(periods))
+	for i, p := range periods {
+		for _, d := range []*Datetime{&p.Start, &p.End} {
+			d.AllDay = false
+			d.Floating = floating
+			if !floating {
+				d.Stamp = d.Stamp.In(tz)
+			}
+		}
+		values[i] = serializeDateText(p.Start) + "/" + serializeDateText(p.End)
+	}
+	prop.Value = strings.Join(values, ",")
+	e.Props.Add(prop)
+}
+
+// delProps deletes the properties of a name that match a predicate.
+func (e Event) delProps(name string, match func(ical.Prop) bool) {
+	var kept []ical.Prop
+	for _, prop := range e.Props.Values(name) {
+		if !match(prop) {
+			kept = append(kept, prop)
+		}
+	}
+	if len(kept) == 0 {
+		e.Props.Del(name)
+		return
+	}
+	e.Props[name] = kept
 }
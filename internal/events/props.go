@@ -1,6 +1,7 @@
 package events
 
 import (
+	"errors"
 	"fmt"
 	"net/url"
 	"strconv"
@@ -353,60 +354,211 @@ func (e Event) SetStart(start Datetime) {
 func (e Event) GetEnd() (Datetime, error) {
 	return e.getDatetime(ical.PropDateTimeEnd)
 }
-func (e Event) SetEnd(start Datetime) {
-	e.setDatetime(ical.PropDateTimeEnd, start)
+func (e Event) SetEnd(end Datetime) {
+	// an event cannot have both DTEND and DURATION
+	e.Props.Del(ical.PropDuration)
+	e.setDatetime(ical.PropDateTimeEnd, end)
 }
 
-// Duration defines the event's duration.
+// Due defines when a todo is due, todos use it instead of End.
+//
+// VTODO Property: DUE
+func (e Event) GetDue() (Datetime, error) {
+	return e.getDatetime(ical.PropDue)
+}
+func (e Event) SetDue(due *Datetime) {
+	if due == nil {
+		e.Props.Del(ical.PropDue)
+		return
+	}
+	e.setDatetime(ical.PropDue, *due)
+}
+
+// Duration defines the event's duration, it is used instead of End.
 func (e Event) GetDuration() (time.Duration, error) {
 	return e.getDuration(ical.PropDuration)
 }
-func (e Event) SetDuration(duration time.Duration) {
-	e.setDuration(ical.PropDuration, duration)
+func (e Event) SetDuration(duration *time.Duration) {
+	if duration == nil {
+		e.Props.Del(ical.PropDuration)
+		return
+	}
+	e.Props.Del(ical.PropDateTimeEnd)
+	e.setDuration(ical.PropDuration, *duration)
 }
 
-func (e Event) GetRecurrenceRule() (*rrule.RRule, error) {
-	// parse RRULE (does not support tzid)
-	rruleProp := e.Props.Get(ical.PropRecurrenceRule)
-	if rruleProp == nil {
-		return nil, propertyNotFoundError(ical.PropRecurrenceRule)
+// GetEffectiveEnd returns the end of the event: DTEND, or DTSTART + DURATION
+// if the event has a duration. Events with neither end when they start, or
+// after a day if they are all-day events (RFC 5545 3.6.1).
+func (e Event) GetEffectiveEnd(start Datetime) (Datetime, error) {
+	end, err := e.GetEnd()
+	if !errors.Is(err, ErrPropertyNotFound) {
+		return end, err
+	}
+	dur, err := e.GetDuration()
+	switch {
+	case errors.Is(err, ErrPropertyNotFound):
+		dur = 0
+		if start.AllDay {
+			dur = day
+		}
+	case err != nil:
+		return Datetime{}, err
 	}
-	ropts, err := rrule.StrToROption(rruleProp.Value)
+	end = start
+	end.Stamp = addDuration(start.Stamp, dur)
+	return end, nil
+}
+
+// PropExceptionRule is the EXRULE property of RFC 2445, it is deprecated by
+// RFC 5545 but still written by some clients.
+const PropExceptionRule = "EXRULE"
+
+// parseRecurrenceRule parses a RRULE or EXRULE value, its DTSTART is the
+// event's start. An UNTIL without a timezone is in the location of the
+// event's start.
+func (e Event) parseRecurrenceRule(name string, prop ical.Prop) (*rrule.RRule, error) {
+	start, err := e.GetStart()
+	if err != nil {
+		return nil, fmt.Errorf("%s: read DTSTART for default recurrence start: %w", name, err)
+	}
+	ropts, err := rrule.StrToROptionInLocation(prop.Value, start.Stamp.Location())
 	if err != nil {
-		return nil, fmt.Errorf("%s: parse recurrence rule %q: %w", ical.PropRecurrenceRule, rruleProp.Value, err)
+		return nil, fmt.Errorf("%s: parse recurrence rule %q: %w", name, prop.Value, err)
 	}
 	if ropts == nil {
-		return nil, fmt.Errorf("%s: recurrence rule parser returned nil options", ical.PropRecurrenceRule)
+		return nil, fmt.Errorf("%s: recurrence rule parser returned nil options", name)
 	}
 	if ropts.Dtstart.Equal(time.Time{}) {
-		dt, err := e.GetStart()
-		if err != nil {
-			return nil, fmt.Errorf("%s: read DTSTART for default recurrence start: %w", ical.PropRecurrenceRule, err)
-		}
 		// set default dtstart to original event's starting time
-		ropts.Dtstart = dt.Stamp
+		ropts.Dtstart = start.Stamp
 	}
 	rule, err := rrule.NewRRule(*ropts)
 	if err != nil {
-		return nil, fmt.Errorf("%s: build recurrence rule: %w", ical.PropRecurrenceRule, err)
+		return nil, fmt.Errorf("%s: build recurrence rule: %w", name, err)
 	}
 	return rule, nil
 }
+
+func (e Event) getRecurrenceRules(name string) ([]*rrule.RRule, error) {
+	props := e.Props.Values(name)
+	if len(props) == 0 {
+		return nil, propertyNotFoundError(name)
+	}
+	rules := make([]*rrule.RRule, len(props))
+	for i, prop := range props {
+		rule, err := e.parseRecurrenceRule(name, prop)
+		if err != nil {
+			return nil, err
+		}
+		rules[i] = rule
+	}
+	return rules, nil
+}
+
+// addRecurrenceRules adds a property for each rule, the DTSTART of the rules
+// is the DTSTART of the event.
+func (e Event) addRecurrenceRules(name string, rules []*rrule.RRule) {
+	start, err := e.GetStart()
+	for _, rule := range rules {
+		prop := ical.NewProp(name)
+		prop.Value = rule.OrigOptions.RRuleString()
+		if err == nil {
+			prop.Value = formatRecurrenceRule(rule.OrigOptions, start)
+		}
+		e.Props.Add(prop)
+	}
+}
+
+// formatRecurrenceRule formats a recurrence rule with its UNTIL in the value
+// type of DTSTART, as RFC 5545 requires a DATE for all-day events and a local
+// time for floating ones (rrule-go always writes UTC).
+func formatRecurrenceRule(opts rrule.ROption, start Datetime) string {
+	value := opts.RRuleString()
+	if opts.Until.IsZero() || (!start.AllDay && !start.Floating) {
+		return value
+	}
+	until := opts.Until.In(start.Stamp.Location())
+	layout := "20060102T150405"
+	if start.AllDay {
+		layout = "20060102"
+	}
+	return strings.Replace(
+		value,
+		"UNTIL="+opts.Until.UTC().Format("20060102T150405Z"),
+		"UNTIL="+until.Format(layout),
+		1,
+	)
+}
+
+// Recurrence rule defines the rule the event repeats with.
+//
+// VEVENT Property: RRULE
+func (e Event) GetRecurrenceRule() (*rrule.RRule, error) {
+	prop := e.Props.Get(ical.PropRecurrenceRule)
+	if prop == nil {
+		return nil, propertyNotFoundError(ical.PropRecurrenceRule)
+	}
+	return e.parseRecurrenceRule(ical.PropRecurrenceRule, *prop)
+}
 func (e Event) SetRecurrenceRule(rule *rrule.RRule) {
 	if rule == nil {
 		e.Props.Del(ical.PropRecurrenceRule)
 		return
 	}
-	prop := ical.NewProp(ical.PropRecurrenceRule)
-	prop.Value = rule.String()
-	e.Props.Set(prop)
+	// keep the extra recurrence rules
+	var extra []ical.Prop
+	if props := e.Props.Values(ical.PropRecurrenceRule); len(props) > 1 {
+		extra = props[1:]
+	}
+	e.Props.Del(ical.PropRecurrenceRule)
+	e.addRecurrenceRules(ical.PropRecurrenceRule, []*rrule.RRule{rule})
+	for _, prop := range extra {
+		e.Props.Add(&prop)
+	}
+}
+
+// Extra recurrence rules are the RRULEs after the first, RFC 5545 deprecates
+// more than one RRULE but older clients still write them.
+//
+// VEVENT Property: RRULE
+func (e Event) GetExtraRecurrenceRules() ([]*rrule.RRule, error) {
+	rules, err := e.getRecurrenceRules(ical.PropRecurrenceRule)
+	if err != nil {
+		return nil, err
+	}
+	if len(rules) < 2 {
+		return nil, propertyNotFoundError(ical.PropRecurrenceRule)
+	}
+	return rules[1:], nil
+}
+func (e Event) SetExtraRecurrenceRules(rules []*rrule.RRule) {
+	props := e.Props.Values(ical.PropRecurrenceRule)
+	if len(props) > 1 {
+		e.Props[ical.PropRecurrenceRule] = props[:1]
+	}
+	e.addRecurrenceRules(ical.PropRecurrenceRule, rules)
+}
+
+// Exception rules exclude the occurrences they generate from the recurrence
+// set.
+//
+// VEVENT Property: EXRULE
+func (e Event) GetExceptionRules() ([]*rrule.RRule, error) {
+	return e.getRecurrenceRules(PropExceptionRule)
+}
+func (e Event) SetExceptionRules(rules []*rrule.RRule) {
+	e.Props.Del(PropExceptionRule)
+	e.addRecurrenceRules(PropExceptionRule, rules)
 }
 
 func (e Event) GetRecurrenceDates() ([]Datetime, error) {
 	return e.getDatetimeList(ical.PropRecurrenceDates)
 }
 func (e Event) SetRecurrenceDates(dates []Datetime) {
-	e.Props.Del(ical.PropRecurrenceDates)
+	e.delProps(ical.PropRecurrenceDates, func(prop ical.Prop) bool {
+		return !isPeriodList(prop)
+	})
 	if len(dates) > 0 {
 		e.setDatetimeList(
 			ical.PropRecurrenceDates,
@@ -415,6 +567,19 @@ func (e Event) SetRecurrenceDates(dates []Datetime) {
 	}
 }
 
+// Recurrence periods are the RDATEs that define the end of their occurrence.
+//
+// VEVENT Property: RDATE;VALUE=PERIOD
+func (e Event) GetRecurrencePeriods() ([]Period, error) {
+	return e.getPeriodList(ical.PropRecurrenceDates)
+}
+func (e Event) SetRecurrencePeriods(periods []Period) {
+	e.delProps(ical.PropRecurrenceDates, isPeriodList)
+	if len(periods) > 0 {
+		e.setPeriodList(ical.PropRecurrenceDates, periods)
+	}
+}
+
 func (e Event) GetRecurrenceExceptionDates() ([]Datetime, error) {
 	return e.getDatetimeList(ical.PropExceptionDates)
 }
@@ -446,6 +611,26 @@ func (e Event) SetRecurrenceInstance(instance *Datetime) {
 	e.setDatetime(ical.PropRecurrenceID, *instance)
 }
 
+// This and future is set if a recurrence override also applies to all the
+// instances after the one it overrides.
+//
+// VEVENT Property: RECURID;RANGE=THISANDFUTURE
+func (e Event) GetThisAndFuture() bool {
+	prop := e.Props.Get(ical.PropRecurrenceID)
+	return prop != nil && strings.EqualFold(prop.Params.Get(ical.ParamRange), "THISANDFUTURE")
+}
+func (e Event) SetThisAndFuture(thisAndFuture bool) {
+	prop := e.Props.Get(ical.PropRecurrenceID)
+	if prop == nil {
+		return
+	}
+	if thisAndFuture {
+		prop.Params.Set(ical.ParamRange, "THISANDFUTURE")
+		return
+	}
+	prop.Params.Del(ical.ParamRange)
+}
+
 // Trigger defines the notification trigger time (if any) for the event.
 //
 // VEVENT -> VALARM Property: TRIGGER
@@ -542,9 +727,10 @@ func (e Event) GetOtherProps() (out []KeyValues) {
 			// resources
 			ical.PropDateTimeStart,
 			ical.PropDateTimeEnd,
-			// TODO: implement
-			// ical.PropDuration,
+			ical.PropDue,
+			ical.PropDuration,
 			ical.PropRecurrenceRule,
+			PropExceptionRule,
 			ical.PropRecurrenceDates,
 			ical.PropExceptionDates,
 			ical.PropRecurrenceID:
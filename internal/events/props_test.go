@@ -54,3 +54,214 @@ func TestGetInvalidGeoReturnsParseError(t *testing.T) {
 		t.Fatalf("expected property name in error, got %v", err)
 	}
 }
+
+func TestDatetimeRoundTrip(t *testing.T) {
+	cases := []struct {
+		name     string
+		value    string
+		tzid     string
+		param    string
+		floating bool
+		allDay   bool
+	}{
+		{"all-day", "20250601", "", "DATE", true, true},
+		{"floating", "20250601T100000", "", "", true, false},
+		{"utc", "20250601T100000Z", "", "", false, false},
+		{"zoned", "20250601T100000", "America/New_York", "", false, false},
+	}
+	for _, c := range cases {
+		event := newTestEvent()
+		prop := ical.NewProp(ical.PropDateTimeStart)
+		prop.Value = c.value
+		if c.tzid != "" {
+			prop.Params.Set(ical.PropTimezoneID, c.tzid)
+		}
+		if c.param != "" {
+			prop.Params.Set(ical.ParamValue, c.param)
+		}
+		event.Props.Set(prop)
+
+		start, err := event.GetStart()
+		if err != nil {
+			t.Fatalf("%s: %v", c.name, err)
+		}
+		if start.Floating != c.floating || start.AllDay != c.allDay {
+			t.Fatalf("%s: unexpected flags %+v", c.name, start)
+		}
+
+		// nushell only keeps the UTC offset of datetimes
+		_, offset := start.Stamp.Zone()
+		start.Stamp = start.Stamp.In(time.FixedZone("", offset))
+		event.SetStart(start)
+
+		written := event.Props.Get(ical.PropDateTimeStart)
+		if written.Value != c.value ||
+			written.Params.Get(ical.PropTimezoneID) != c.tzid ||
+			written.Params.Get(ical.ParamValue) != c.param {
+			t.Fatalf("%s: unexpected property %+v", c.name, written)
+		}
+	}
+}
+
+func TestSetRecurrenceRuleOmitsDtstart(t *testing.T) {
+	event := newTestEvent()
+	start := ical.NewProp(ical.PropDateTimeStart)
+	start.Value = "20250601T100000Z"
+	event.Props.Set(start)
+	rrule := ical.NewProp(ical.PropRecurrenceRule)
+	rrule.Value = "FREQ=WEEKLY;COUNT=3"
+	event.Props.Set(rrule)
+
+	rule, err := event.GetRecurrenceRule()
+	if err != nil {
+		t.Fatal(err)
+	}
+	event.SetRecurrenceRule(rule)
+	if value := event.Props.Get(ical.PropRecurrenceRule).Value; value != "FREQ=WEEKLY;COUNT=3" {
+		t.Fatalf("unexpected RRULE %q", value)
+	}
+}
+
+func TestSetRecurrenceRuleUntilMatchesDtstart(t *testing.T) {
+	cases := []struct {
+		name  string
+		start string
+		param string
+		rule  string
+	}{
+		{"all-day", "20250601", "DATE", "FREQ=DAILY;UNTIL=20250630"},
+		{"floating", "20250601T100000", "", "FREQ=DAILY;UNTIL=20250630T100000"},
+		{"utc", "20250601T100000Z", "", "FREQ=DAILY;UNTIL=20250630T100000Z"},
+	}
+	for _, c := range cases {
+		event := newTestEvent()
+		start := ical.NewProp(ical.PropDateTimeStart)
+		start.Value = c.start
+		if c.param != "" {
+			start.Params.Set(ical.ParamValue, c.param)
+		}
+		event.Props.Set(start)
+		rrule := ical.NewProp(ical.PropRecurrenceRule)
+		rrule.Value = c.rule
+		event.Props.Set(rrule)
+
+		rule, err := event.GetRecurrenceRule()
+		if err != nil {
+			t.Fatalf("%s: %v", c.name, err)
+		}
+		event.SetRecurrenceRule(rule)
+		if value := event.Props.Get(ical.PropRecurrenceRule).Value; value != c.rule {
+			t.Errorf("%s: expected RRULE %q, got %q", c.name, c.rule, value)
+		}
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	cases := map[time.Duration]string{
+		0:                              "PT0S",
+		24 * time.Hour:                 "P1D",
+		26*time.Hour + 30*time.Second:  "P1DT2H30S",
+		-(15 * time.Minute):            "-PT15M",
+		7*24*time.Hour + 3*time.Minute: "P7DT3M",
+	}
+	for d, expected := range cases {
+		if got := formatDuration(d); got != expected {
+			t.Errorf("%v: expected %s, got %s", d, expected, got)
+		}
+		prop := ical.NewProp(ical.PropDuration)
+		prop.Value = formatDuration(d)
+		parsed, err := prop.Duration()
+		if err != nil || parsed != d {
+			t.Errorf("%v: parsed back as %v (%v)", d, parsed, err)
+		}
+	}
+}
+
+func TestRecurrenceProperties(t *testing.T) {
+	event := newTestEvent()
+	add := func(name, value string, params map[string]string) {
+		prop := ical.NewProp(name)
+		prop.Value = value
+		for k, v := range params {
+			prop.Params.Set(k, v)
+		}
+		event.Props.Add(prop)
+	}
+	ny := map[string]string{ical.PropTimezoneID: "America/New_York"}
+	add(ical.PropDateTimeStart, "19970902T090000", ny)
+	add(ical.PropRecurrenceRule, "FREQ=DAILY;UNTIL=19970910T090000", nil)
+	add(ical.PropRecurrenceRule, "FREQ=WEEKLY;COUNT=2", nil)
+	add(PropExceptionRule, "FREQ=WEEKLY;BYDAY=SA,SU", nil)
+	add(ical.PropRecurrenceDates, "19970920T090000", ny)
+	add(ical.PropRecurrenceDates, "19970921T020000Z/PT3H,19970922T020000Z/19970922T030000Z", map[string]string{
+		ical.ParamValue: "PERIOD",
+	})
+	add(ical.PropExceptionDates, "19970903T090000", ny)
+	add(ical.PropExceptionDates, "19970904T090000", ny)
+
+	rule, err := event.GetRecurrenceRule()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the UNTIL without a timezone is in the timezone of the start
+	if until := rule.OrigOptions.Until; until.Hour() != 9 || until.Location().String() != "America/New_York" {
+		t.Fatalf("unexpected UNTIL %v", until)
+	}
+	extra, err := event.GetExtraRecurrenceRules()
+	if err != nil || len(extra) != 1 {
+		t.Fatalf("unexpected extra recurrence rules %v (%v)", extra, err)
+	}
+	exrules, err := event.GetExceptionRules()
+	if err != nil || len(exrules) != 1 {
+		t.Fatalf("unexpected exception rules %v (%v)", exrules, err)
+	}
+	dates, err := event.GetRecurrenceDates()
+	if err != nil || len(dates) != 1 || dates[0].Timezone != "America/New_York" {
+		t.Fatalf("unexpected recurrence dates %v (%v)", dates, err)
+	}
+	periods, err := event.GetRecurrencePeriods()
+	if err != nil || len(periods) != 2 {
+		t.Fatalf("unexpected recurrence periods %v (%v)", periods, err)
+	}
+	for i, d := range []time.Duration{3 * time.Hour, time.Hour} {
+		if got := periods[i].End.Stamp.Sub(periods[i].Start.Stamp); got != d {
+			t.Fatalf("period %d: expected %v, got %v", i, d, got)
+		}
+	}
+	exdates, err := event.GetRecurrenceExceptionDates()
+	if err != nil || len(exdates) != 2 {
+		t.Fatalf("unexpected exception dates %v (%v)", exdates, err)
+	}
+
+	// setting the dates keeps the periods and the other way around
+	event.SetRecurrenceDates(dates)
+	event.SetRecurrencePeriods(periods)
+	event.SetRecurrenceExceptionDates(exdates)
+	event.SetRecurrenceRule(rule)
+	if n := len(event.Props.Values(ical.PropRecurrenceDates)); n != 2 {
+		t.Fatalf("expected 2 RDATE properties, got %d", n)
+	}
+	if n := len(event.Props.Values(ical.PropRecurrenceRule)); n != 2 {
+		t.Fatalf("expected 2 RRULE properties, got %d", n)
+	}
+	reread, err := event.GetRecurrencePeriods()
+	if err != nil || len(reread) != 2 || !reread[0].End.Stamp.Equal(periods[0].End.Stamp) {
+		t.Fatalf("unexpected periods after setting them %v (%v)", reread, err)
+	}
+	exdates, err = event.GetRecurrenceExceptionDates()
+	if err != nil || len(exdates) != 2 {
+		t.Fatalf("unexpected exception dates after setting them %v (%v)", exdates, err)
+	}
+}
+
+func TestThisAndFuture(t *testing.T) {
+	event := newTestEvent()
+	event.SetRecurrenceInstance(&Datetime{Stamp: time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC)})
+	if event.GetThisAndFuture() {
+		t.Fatal("expected no range")
+	}
+	event.SetThisAndFuture(true)
+	if !event.GetThisAndFuture() || event.Props.Get(ical.PropRecurrenceID).Params.Get(ical.ParamRange) != "THISANDFUTURE" {
+		t.Fatalf("unexpected RECURRENCE-ID %+v", event.Props.Get(ical.PropRecurrenceID))
+	}
+}
@@ -0,0 +1,472 @@
+package events
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/emersion/go-ical"
+	"github.com/teambition/rrule-go"
+)
+
+// transition is a change of the UTC offset (or abbreviation) of a location.
+type transition struct {
+	at         time.Time
+	offsetFrom int
+	offsetTo   int
+	name       string
+	dst        bool
+}
+
+// wall returns the wall clock time just before the transition.
+func (t transition) wall() time.Time {
+	return t.at.Add(time.Duration(t.offsetFrom) * time.Second).UTC()
+}
+
+// kind identifies transitions that belong to the same observance.
+type transitionKind struct {
+	offsetFrom int
+	offsetTo   int
+	name       string
+	dst        bool
+}
+
+func (t transition) kind() transitionKind {
+	return transitionKind{t.offsetFrom, t.offsetTo, t.name, t.dst}
+}
+
+// findTransitions finds all the transitions of a location between from and to
+// with a precision of a second.
+func findTransitions(loc *time.Location, from, to time.Time) (out []transition) {
+	const step = 24 * time.Hour
+
+	prev := from.In(loc)
+	prevName, prevOffset := prev.Zone()
+	for cur := from.Add(step); !prev.After(to); cur = cur.Add(step) {
+		curLocal := cur.In(loc)
+		name, offset := curLocal.Zone()
+		if name == prevName && offset == prevOffset {
+			prev = curLocal
+			continue
+		}
+
+		// binary search for the first second with the new zone
+		lo, hi := prev.Unix(), cur.Unix()
+		for hi-lo > 1 {
+			mid := lo + (hi-lo)/2
+			n, o := time.Unix(mid, 0).In(loc).Zone()
+			if n == prevName && o == prevOffset {
+				lo = mid
+			} else {
+				hi = mid
+			}
+		}
+		at := time.Unix(hi, 0).In(loc)
+		name, offset = at.Zone()
+		out = append(out, transition{
+			at:         at,
+			offsetFrom: prevOffset,
+			offsetTo:   offset,
+			name:       name,
+			dst:        at.IsDST(),
+		})
+		prev, prevName, prevOffset = at, name, offset
+	}
+	return
+}
+
+func formatUTCOffset(offset int) string {
+	sign := '+'
+	if offset < 0 {
+		sign = '-'
+		offset = -offset
+	}
+	s := fmt.Sprintf("%c%02d%02d", sign, offset/3600, offset/60%60)
+	if offset%60 != 0 {
+		s += fmt.Sprintf("%02d", offset%60)
+	}
+	return s
+}
+
+var weekdays = []string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// yearlyRule returns the RRULE that produces the given transitions (ordered
+// chronologically), or an empty string if they do not follow a yearly rule of
+// the form "nth weekday of a month".
+func yearlyRule(transitions []transition) string {
+	if len(transitions) < 2 {
+		return ""
+	}
+	first := transitions[0].wall()
+	// the week of the month counted from its start and whether it is the last
+	// week of the month, for all transitions
+	fromStart := (first.Day()-1)/7 + 1
+	lastWeek := true
+
+	for i, t := range transitions {
+		wall := t.wall()
+		if wall.Year() != first.Year()+i ||
+			wall.Month() != first.Month() ||
+			wall.Weekday() != first.Weekday() ||
+			wall.Hour() != first.Hour() ||
+			wall.Minute() != first.Minute() ||
+			wall.Second() != first.Second() {
+			return ""
+		}
+		if (wall.Day()-1)/7+1 != fromStart {
+			fromStart = 0
+		}
+		daysInMonth := time.Date(wall.Year(), wall.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+		if wall.Day()+7 <= daysInMonth {
+			lastWeek = false
+		}
+	}
+
+	var nth int
+	switch {
+	case fromStart > 0 && fromStart < 5:
+		nth = fromStart
+	case lastWeek:
+		nth = -1
+	default:
+		return ""
+	}
+	return fmt.Sprintf(
+		"FREQ=YEARLY;BYMONTH=%d;BYDAY=%d%s",
+		first.Month(), nth, weekdays[first.Weekday()],
+	)
+}
+
+func newObservance(dst bool, offsetFrom, offsetTo int, name string, start time.Time) *ical.Component {
+	compName := ical.CompTimezoneStandard
+	if dst {
+		compName = ical.CompTimezoneDaylight
+	}
+	comp := ical.NewComponent(compName)
+
+	dtstart := ical.NewProp(ical.PropDateTimeStart)
+	dtstart.Value = start.Format(datetime_format)
+	comp.Props.Set(dtstart)
+
+	from := ical.NewProp(ical.PropTimezoneOffsetFrom)
+	from.Value = formatUTCOffset(offsetFrom)
+	comp.Props.Set(from)
+
+	to := ical.NewProp(ical.PropTimezoneOffsetTo)
+	to.Value = formatUTCOffset(offsetTo)
+	comp.Props.Set(to)
+
+	if name != "" {
+		comp.Props.SetText(ical.PropTimezoneName, name)
+	}
+	return comp
+}
+
+// NewTimezone generates a VTIMEZONE component describing the offsets of a
+// location between from and to.
+//
+// Transitions that happen on the same weekday of the same month every year
+// are described with an RRULE, all others are listed with RDATE.
+func NewTimezone(loc *time.Location, from, to time.Time) *ical.Component {
+	tz := ical.NewComponent(ical.CompTimezone)
+	tz.Props.SetText(ical.PropTimezoneID, loc.String())
+
+	transitions := findTransitions(loc, from, to)
+	if len(transitions) == 0 {
+		start := from.In(loc)
+		name, offset := start.Zone()
+		tz.Children = append(tz.Children, newObservance(
+			start.IsDST(), offset, offset, name,
+			time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC),
+		))
+		return tz
+	}
+
+	// group transitions by observance, keeping the order of their first
+	// occurrence
+	var kinds []transitionKind
+	groups := map[transitionKind][]transition{}
+	for _, t := range transitions {
+		k := t.kind()
+		if _, ok := groups[k]; !ok {
+			kinds = append(kinds, k)
+		}
+		groups[k] = append(groups[k], t)
+	}
+
+	for _, k := range kinds {
+		group := groups[k]
+		comp := newObservance(k.dst, k.offsetFrom, k.offsetTo, k.name, group[0].wall())
+
+		rule := yearlyRule(group)
+		switch {
+		case rule != "":
+			last := group[len(group)-1]
+			// the observance stopped before the end of the range
+			if last.at.Year() < to.Year()-1 {
+				rule += ";UNTIL=" + last.at.UTC().Format(datetime_utc_format)
+			}
+			rrule := ical.NewProp(ical.PropRecurrenceRule)
+			rrule.Value = rule
+			comp.Props.Set(rrule)
+		case len(group) > 1:
+			rdates := make([]string, len(group)-1)
+			for i, t := range group[1:] {
+				rdates[i] = t.wall().Format(datetime_format)
+			}
+			rdate := ical.NewProp(ical.PropRecurrenceDates)
+			rdate.Value = strings.Join(rdates, ",")
+			comp.Props.Set(rdate)
+		}
+		tz.Children = append(tz.Children, comp)
+	}
+	return tz
+}
+
+func parseUTCOffset(s string) (offset int, err error) {
+	if len(s) != 5 && len(s) != 7 || s[0] != '+' && s[0] != '-' {
+		err = fmt.Errorf("invalid UTC offset %q", s)
+		return
+	}
+	var h, m, sec int
+	_, err = fmt.Sscanf(s[1:5], "%02d%02d", &h, &m)
+	if err != nil {
+		err = fmt.Errorf("invalid UTC offset %q: %w", s, err)
+		return
+	}
+	if len(s) == 7 {
+		_, err = fmt.Sscanf(s[5:], "%02d", &sec)
+		if err != nil {
+			err = fmt.Errorf("invalid UTC offset %q: %w", s, err)
+			return
+		}
+	}
+	offset = h*3600 + m*60 + sec
+	if s[0] == '-' {
+		offset = -offset
+	}
+	return
+}
+
+func utcOffsetProp(comp *ical.Component, name string) (offset int, err error) {
+	prop := comp.Props.Get(name)
+	if prop == nil {
+		err = propertyNotFoundError(name)
+		return
+	}
+	offset, err = parseUTCOffset(prop.Value)
+	if err != nil {
+		err = fmt.Errorf("%s: %w", name, err)
+	}
+	return
+}
+
+// observanceTransitions expands the onsets of a STANDARD or DAYLIGHT
+// component until the given time.
+func observanceTransitions(comp *ical.Component, until time.Time) (out []transition, err error) {
+	offsetFrom, err := utcOffsetProp(comp, ical.PropTimezoneOffsetFrom)
+	if err != nil {
+		return
+	}
+	offsetTo, err := utcOffsetProp(comp, ical.PropTimezoneOffsetTo)
+	if err != nil {
+		return
+	}
+	name, _ := comp.Props.Text(ical.PropTimezoneName)
+
+	// onsets are wall clock times in the offset before the transition, they
+	// are handled as UTC times and shifted afterwards
+	prop := comp.Props.Get(ical.PropDateTimeStart)
+	if prop == nil {
+		err = propertyNotFoundError(ical.PropDateTimeStart)
+		return
+	}
+	start, err := parseDateText(prop.Value, time.UTC)
+	if err != nil {
+		err = fmt.Errorf("%s: %w", ical.PropDateTimeStart, err)
+		return
+	}
+	onsets := []time.Time{start.Stamp}
+
+	for _, prop := range comp.Props.Values(ical.PropRecurrenceRule) {
+		var opts *rrule.ROption
+		opts, err = rrule.StrToROption(prop.Value)
+		if err != nil {
+			err = fmt.Errorf("%s: %w", ical.PropRecurrenceRule, err)
+			return
+		}
+		opts.Dtstart = start.Stamp
+		// rrule-go cannot expand more than ~290 years past DTSTART (the range
+		// of a time.Duration), but Outlook starts observances in 1601, yearly
+		// rules are moved forward by whole years as this does not change
+		// their occurrences
+		if opts.Freq == rrule.YEARLY && opts.Count == 0 && start.Stamp.Year() < ruleEpoch {
+			opts.Dtstart = start.Stamp.AddDate(ruleEpoch-start.Stamp.Year(), 0, 0)
+		}
+		var rule *rrule.RRule
+		rule, err = rrule.NewRRule(*opts)
+		if err != nil {
+			err = fmt.Errorf("%s: %w", ical.PropRecurrenceRule, err)
+			return
+		}
+		// the first occurrence is DTSTART
+		onsets = append(onsets, rule.Between(start.Stamp, until, false)...)
+	}
+	for _, prop := range comp.Props.Values(ical.PropRecurrenceDates) {
+		for _, value := range strings.Split(prop.Value, ",") {
+			// only the start of a PERIOD is an onset
+			value, _, _ = strings.Cut(value, "/")
+			var d Datetime
+			d, err = parseDateText(value, time.UTC)
+			if err != nil {
+				err = fmt.Errorf("%s: %w", ical.PropRecurrenceDates, err)
+				return
+			}
+			onsets = append(onsets, d.Stamp)
+		}
+	}
+
+	for _, onset := range onsets {
+		if onset.After(until) {
+			continue
+		}
+		out = append(out, transition{
+			at:         onset.Add(-time.Duration(offsetFrom) * time.Second),
+			offsetFrom: offsetFrom,
+			offsetTo:   offsetTo,
+			name:       name,
+			dst:        comp.Name == ical.CompTimezoneDaylight,
+		})
+	}
+	return
+}
+
+// ruleEpoch is the year from which the yearly rules of observances are
+// expanded.
+const ruleEpoch = 1900
+
+// tzifEnd is the time until which the observances of a VTIMEZONE are
+// expanded, the last observance applies after it.
+var tzifEnd = time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// zoneType is a local time type of a TZif file.
+type zoneType struct {
+	offset int
+	dst    bool
+	name   string
+}
+
+// encodeTZif encodes chronologically ordered transitions as a version 2 TZif
+// file (RFC 8536), the initial type is the offset before the first
+// transition.
+func encodeTZif(transitions []transition) (data []byte, err error) {
+	initial := zoneType{offset: transitions[0].offsetFrom, name: formatUTCOffset(transitions[0].offsetFrom)}
+	for _, t := range transitions {
+		if t.offsetTo == initial.offset && !t.dst {
+			initial.name = t.name
+			break
+		}
+	}
+	// the first type is used before the first transition as long as no
+	// transition refers to it
+	types := []zoneType{initial}
+	indices := make([]byte, len(transitions))
+	for i, t := range transitions {
+		zt := zoneType{offset: t.offsetTo, dst: t.dst, name: t.name}
+		if zt.name == "" {
+			zt.name = formatUTCOffset(t.offsetTo)
+		}
+		idx := slices.Index(types[1:], zt)
+		if idx < 0 {
+			types = append(types, zt)
+			idx = len(types) - 1
+		} else {
+			idx++
+		}
+		if idx > math.MaxUint8 {
+			err = fmt.Errorf("too many observances")
+			return
+		}
+		indices[i] = byte(idx)
+	}
+
+	var chars bytes.Buffer
+	desig := make([]byte, len(types))
+	for i, zt := range types {
+		desig[i] = byte(chars.Len())
+		chars.WriteString(zt.name)
+		chars.WriteByte(0)
+	}
+
+	var buf bytes.Buffer
+	header := func(timecnt, typecnt, charcnt int) {
+		buf.WriteString("TZif2")
+		buf.Write(make([]byte, 15))
+		// isutcnt, isstdcnt, leapcnt, timecnt, typecnt, charcnt
+		for _, n := range []int{0, 0, 0, timecnt, typecnt, charcnt} {
+			binary.Write(&buf, binary.BigEndian, uint32(n))
+		}
+	}
+	// the version 1 data block is left empty, readers of version 2 files
+	// skip it
+	header(0, 1, 1)
+	buf.Write([]byte{0, 0, 0, 0, 0, 0, 0})
+
+	header(len(transitions), len(types), chars.Len())
+	for _, t := range transitions {
+		binary.Write(&buf, binary.BigEndian, t.at.Unix())
+	}
+	buf.Write(indices)
+	for i, zt := range types {
+		binary.Write(&buf, binary.BigEndian, int32(zt.offset))
+		isdst := byte(0)
+		if zt.dst {
+			isdst = 1
+		}
+		buf.Write([]byte{isdst, desig[i]})
+	}
+	buf.Write(chars.Bytes())
+	// empty footer, the last type applies after the last transition
+	buf.WriteString("\n\n")
+	data = buf.Bytes()
+	return
+}
+
+// ParseTimezone converts a VTIMEZONE component to a location named after its
+// TZID, observances are expanded until 2100.
+func ParseTimezone(comp *ical.Component) (*time.Location, error) {
+	tzid, err := comp.Props.Text(ical.PropTimezoneID)
+	if err != nil {
+		return nil, err
+	}
+	if tzid == "" {
+		return nil, propertyNotFoundError(ical.PropTimezoneID)
+	}
+
+	var transitions []transition
+	for _, child := range comp.Children {
+		if child.Name != ical.CompTimezoneStandard && child.Name != ical.CompTimezoneDaylight {
+			continue
+		}
+		ts, err := observanceTransitions(child, tzifEnd)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", child.Name, err)
+		}
+		transitions = append(transitions, ts...)
+	}
+	if len(transitions) == 0 {
+		return nil, fmt.Errorf("no STANDARD or DAYLIGHT observance")
+	}
+	slices.SortStableFunc(transitions, func(a, b transition) int {
+		return a.at.Compare(b.at)
+	})
+	data, err := encodeTZif(transitions)
+	if err != nil {
+		return nil, err
+	}
+	return time.LoadLocationFromTZData(tzid, data)
+}
@@ -0,0 +1,252 @@
+package events
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/emersion/go-ical"
+)
+
+func observances(t *testing.T, tz *ical.Component) map[string]*ical.Component {
+	out := map[string]*ical.Component{}
+	for _, child := range tz.Children {
+		name, err := child.Props.Text(ical.PropTimezoneName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		out[name] = child
+	}
+	return out
+}
+
+func TestNewTimezoneYearlyRule(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	tz := NewTimezone(loc, from, from.AddDate(5, 0, 0))
+
+	obs := observances(t, tz)
+	cases := []struct {
+		name, comp, dtstart, rrule, from, to string
+	}{
+		{"EDT", ical.CompTimezoneDaylight, "20250309T020000", "FREQ=YEARLY;BYMONTH=3;BYDAY=2SU", "-0500", "-0400"},
+		{"EST", ical.CompTimezoneStandard, "20251102T020000", "FREQ=YEARLY;BYMONTH=11;BYDAY=1SU", "-0400", "-0500"},
+	}
+	if len(obs) != len(cases) {
+		t.Fatalf("expected %d observances, got %d", len(cases), len(obs))
+	}
+	for _, c := range cases {
+		comp, ok := obs[c.name]
+		if !ok {
+			t.Fatalf("missing observance %s", c.name)
+		}
+		if comp.Name != c.comp {
+			t.Errorf("%s: expected %s, got %s", c.name, c.comp, comp.Name)
+		}
+		for prop, expected := range map[string]string{
+			ical.PropDateTimeStart:      c.dtstart,
+			ical.PropRecurrenceRule:     c.rrule,
+			ical.PropTimezoneOffsetFrom: c.from,
+			ical.PropTimezoneOffsetTo:   c.to,
+		} {
+			p := comp.Props.Get(prop)
+			if p == nil || p.Value != expected {
+				t.Errorf("%s: expected %s %q, got %+v", c.name, prop, expected, p)
+			}
+		}
+	}
+}
+
+func TestNewTimezoneLastWeekday(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	tz := NewTimezone(loc, from, from.AddDate(5, 0, 0))
+
+	rrule := observances(t, tz)["CEST"].Props.Get(ical.PropRecurrenceRule)
+	if rrule == nil || rrule.Value != "FREQ=YEARLY;BYMONTH=3;BYDAY=-1SU" {
+		t.Fatalf("unexpected rrule %+v", rrule)
+	}
+}
+
+func TestNewTimezoneWithoutTransitions(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	tz := NewTimezone(loc, from, from.AddDate(5, 0, 0))
+
+	if len(tz.Children) != 1 || tz.Children[0].Name != ical.CompTimezoneStandard {
+		t.Fatalf("expected a single standard observance, got %+v", tz.Children)
+	}
+	offset := tz.Children[0].Props.Get(ical.PropTimezoneOffsetTo)
+	if offset == nil || offset.Value != "+0900" {
+		t.Fatalf("unexpected offset %+v", offset)
+	}
+}
+
+// customTimezoneIcs is a VTIMEZONE as written by Outlook, with a TZID that is
+// not in the timezone database.
+const customTimezoneIcs = "BEGIN:VCALENDAR\r\n" +
+	"VERSION:2.0\r\n" +
+	"PRODID:-//test//test//EN\r\n" +
+	"BEGIN:VTIMEZONE\r\n" +
+	"TZID:Custom Eastern\r\n" +
+	"BEGIN:STANDARD\r\n" +
+	"DTSTART:16010101T020000\r\n" +
+	"TZOFFSETFROM:-0400\r\n" +
+	"TZOFFSETTO:-0500\r\n" +
+	"RRULE:FREQ=YEARLY;BYMONTH=11;BYDAY=1SU\r\n" +
+	"END:STANDARD\r\n" +
+	"BEGIN:DAYLIGHT\r\n" +
+	"DTSTART:16010101T020000\r\n" +
+	"TZOFFSETFROM:-0500\r\n" +
+	"TZOFFSETTO:-0400\r\n" +
+	"RRULE:FREQ=YEARLY;BYMONTH=3;BYDAY=2SU\r\n" +
+	"END:DAYLIGHT\r\n" +
+	"END:VTIMEZONE\r\n" +
+	"END:VCALENDAR\r\n"
+
+func decodeCalendar(t *testing.T, ics string) *ical.Calendar {
+	cal, err := ical.NewDecoder(strings.NewReader(ics)).Decode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cal
+}
+
+func TestParseTimezone(t *testing.T) {
+	tzs := FindTimezones(decodeCalendar(t, customTimezoneIcs))
+	loc, err := ParseTimezone(tzs["Custom Eastern"].Component)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loc.String() != "Custom Eastern" {
+		t.Fatalf("unexpected location name %q", loc.String())
+	}
+	cases := []struct {
+		at     time.Time
+		offset int
+	}{
+		{time.Date(2025, 3, 9, 6, 59, 59, 0, time.UTC), -5 * 3600},
+		{time.Date(2025, 3, 9, 7, 0, 0, 0, time.UTC), -4 * 3600},
+		{time.Date(2025, 11, 2, 5, 59, 59, 0, time.UTC), -4 * 3600},
+		{time.Date(2025, 11, 2, 6, 0, 0, 0, time.UTC), -5 * 3600},
+	}
+	for _, c := range cases {
+		_, offset := c.at.In(loc).Zone()
+		if offset != c.offset {
+			t.Errorf("%v: expected offset %d, got %d", c.at, c.offset, offset)
+		}
+	}
+}
+
+func TestLoadTimezoneCachesParsedComponents(t *testing.T) {
+	tzs := FindTimezones(decodeCalendar(t, customTimezoneIcs))
+	first, err := LoadTimezone("Custom Eastern", tzs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := LoadTimezone("Custom Eastern", tzs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first != second {
+		t.Fatal("expected the parsed VTIMEZONE to be reused")
+	}
+}
+
+func TestParseTimezoneRoundTrip(t *testing.T) {
+	for _, name := range []string{"America/New_York", "Europe/Berlin", "Australia/Sydney", "Asia/Tokyo"} {
+		loc, err := time.LoadLocation(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		to := from.AddDate(3, 0, 0)
+		parsed, err := ParseTimezone(NewTimezone(loc, from, to))
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		for at := from; at.Before(to); at = at.Add(6 * time.Hour) {
+			_, expected := at.In(loc).Zone()
+			_, offset := at.In(parsed).Zone()
+			if offset != expected {
+				t.Fatalf("%s at %v: expected offset %d, got %d", name, at, expected, offset)
+			}
+		}
+	}
+}
+
+func TestLoadTimezone(t *testing.T) {
+	tzs := FindTimezones(decodeCalendar(t, customTimezoneIcs))
+	cases := []struct {
+		tzid     string
+		expected string
+	}{
+		{"Europe/Paris", "Europe/Paris"},
+		{"Pacific Standard Time", "America/Los_Angeles"},
+		{"/mozilla.org/20050126_1/America/New_York", "America/New_York"},
+		{"/citadel.org/20190914_1/America/Argentina/Salta", "America/Argentina/Salta"},
+		{"Custom Eastern", "Custom Eastern"},
+	}
+	for _, c := range cases {
+		loc, err := LoadTimezone(c.tzid, tzs)
+		if err != nil {
+			t.Errorf("%s: %v", c.tzid, err)
+			continue
+		}
+		if loc.String() != c.expected {
+			t.Errorf("%s: expected %s, got %s", c.tzid, c.expected, loc.String())
+		}
+	}
+	_, err := LoadTimezone("Nowhere Standard Time", tzs)
+	if err == nil {
+		t.Fatal("expected an error for an unknown timezone")
+	}
+}
+
+func TestToCalendarEmitsTimezones(t *testing.T) {
+	cal := decodeCalendar(t, "BEGIN:VCALENDAR\r\n"+
+		"VERSION:2.0\r\n"+
+		"PRODID:-//test//test//EN\r\n"+
+		"BEGIN:VEVENT\r\n"+
+		"UID:test\r\n"+
+		"DTSTAMP:20250101T000000Z\r\n"+
+		"DTSTART;TZID=Pacific Standard Time:20250601T100000\r\n"+
+		"DTEND;TZID=Europe/Berlin:20250601T200000\r\n"+
+		"END:VEVENT\r\n"+
+		"END:VCALENDAR\r\n")
+	obj := EventObject{Main: Event{Event: ical.Event{Component: cal.Children[0]}}}
+
+	start, err := obj.Main.GetStart()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !start.Stamp.Equal(time.Date(2025, 6, 1, 17, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected start %v", start.Stamp)
+	}
+
+	var tzids []string
+	for _, child := range obj.ToCalendar().Children {
+		if child.Name != ical.CompTimezone {
+			continue
+		}
+		tzid, err := child.Props.Text(ical.PropTimezoneID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tzids = append(tzids, tzid)
+	}
+	if !slices.Equal(tzids, []string{"Europe/Berlin", "Pacific Standard Time"}) {
+		t.Fatalf("unexpected VTIMEZONE components %v", tzids)
+	}
+}
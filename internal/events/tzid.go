@@ -0,0 +1,123 @@
+package events
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/emersion/go-ical"
+)
+
+// Timezones are the VTIMEZONE components of a calendar object, keyed by TZID.
+type Timezones map[string]*Timezone
+
+// Timezone is a VTIMEZONE component, it is parsed the first time it is loaded.
+type Timezone struct {
+	Component *ical.Component
+
+	once sync.Once
+	loc  *time.Location
+	err  error
+}
+
+// Location parses the VTIMEZONE component into a location, the result is
+// cached.
+func (tz *Timezone) Location() (*time.Location, error) {
+	tz.once.Do(func() {
+		tz.loc, tz.err = ParseTimezone(tz.Component)
+	})
+	return tz.loc, tz.err
+}
+
+// FindTimezones returns the VTIMEZONE components of a calendar object.
+func FindTimezones(cal *ical.Calendar) Timezones {
+	tzs := Timezones{}
+	for _, child := range cal.Children {
+		if child.Name != ical.CompTimezone {
+			continue
+		}
+		tzid, err := child.Props.Text(ical.PropTimezoneID)
+		if err != nil || tzid == "" {
+			continue
+		}
+		tzs[tzid] = &Timezone{Component: child}
+	}
+	return tzs
+}
+
+// LoadTimezone resolves a TZID to a location, trying in order:
+//
+//  1. the IANA timezone database
+//  2. the Windows timezone names used by Outlook and Exchange
+//  3. the IANA name at the end of prefixed ids (ex. /mozilla.org/20050126_1/America/New_York)
+//  4. the VTIMEZONE component with the same TZID in the calendar object
+func LoadTimezone(tzid string, tzs Timezones) (*time.Location, error) {
+	// time.LoadLocation treats "" as UTC and "Local" as the system timezone
+	if tzid != "" && tzid != "Local" {
+		loc, err := time.LoadLocation(tzid)
+		if err == nil {
+			return loc, nil
+		}
+	}
+	name, ok := windowsZones[tzid]
+	if ok {
+		loc, err := time.LoadLocation(name)
+		if err == nil {
+			return loc, nil
+		}
+	}
+	if strings.HasPrefix(tzid, "/") {
+		// IANA names have at most 3 segments (ex. America/Argentina/Salta)
+		segments := strings.Split(tzid, "/")
+		for n := min(3, len(segments)-1); n > 0; n-- {
+			suffix := strings.Join(segments[len(segments)-n:], "/")
+			loc, err := time.LoadLocation(suffix)
+			if err == nil {
+				return loc, nil
+			}
+		}
+	}
+	// VTIMEZONE components are only parsed when needed as they are usually
+	// redundant with the timezone database
+	tz, ok := tzs[tzid]
+	if ok {
+		loc, err := tz.Location()
+		if err != nil {
+			return nil, fmt.Errorf("parse VTIMEZONE %q: %w", tzid, err)
+		}
+		return loc, nil
+	}
+	return nil, fmt.Errorf("unknown timezone %q", tzid)
+}
+
+// referencedTimezones returns the TZIDs referenced by the properties of a
+// component and its children, along with the years of the values of those
+// properties.
+func referencedTimezones(comp *ical.Component, years map[string][2]int) {
+	for _, props := range comp.Props {
+		for _, prop := range props {
+			tzid := prop.Params.Get(ical.PropTimezoneID)
+			if tzid == "" {
+				continue
+			}
+			for _, value := range strings.Split(prop.Value, ",") {
+				var year int
+				_, err := fmt.Sscanf(value, "%4d", &year)
+				if err != nil {
+					continue
+				}
+				span, ok := years[tzid]
+				if !ok {
+					span = [2]int{year, year}
+				}
+				span[0] = min(span[0], year)
+				span[1] = max(span[1], year)
+				years[tzid] = span
+			}
+		}
+	}
+	for _, child := range comp.Children {
+		referencedTimezones(child, years)
+	}
+}
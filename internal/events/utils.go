@@ -2,6 +2,7 @@ package events
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/emersion/go-ical"
@@ -33,28 +34,67 @@ func parseDateText(s string, tz *time.Location) (d Datetime, err error) {
 }
 
 func serializeDateText(d Datetime) string {
-	if d.AllDay {
+	switch {
+	case d.AllDay:
 		return d.Stamp.Format(date_format)
-	}
-	if d.Stamp.Location() == time.UTC {
+	case d.Floating:
+		return d.Stamp.Format(datetime_format)
+	case d.Stamp.Location() == time.UTC:
 		return d.Stamp.Format(datetime_utc_format)
 	}
 	return d.Stamp.Format(datetime_format)
 }
 
-func getTzidParam(prop *ical.Prop, defaultzone *time.Location) (tz *time.Location, err error) {
-	if prop.Params == nil {
-		return
+const day = 24 * time.Hour
+
+// formatDuration formats a duration as an iCalendar DURATION, whole days are
+// written as days as RFC 5545 requires them for all-day events.
+func formatDuration(d time.Duration) string {
+	var sb strings.Builder
+	if d < 0 {
+		sb.WriteByte('-')
+		d = -d
+	}
+	sb.WriteByte('P')
+	days := d / day
+	d -= days * day
+	if days > 0 {
+		fmt.Fprintf(&sb, "%dD", days)
+	}
+	if d == 0 && days > 0 {
+		return sb.String()
+	}
+	sb.WriteByte('T')
+	h := d / time.Hour
+	m := d % time.Hour / time.Minute
+	sec := d % time.Minute / time.Second
+	if h > 0 {
+		fmt.Fprintf(&sb, "%dH", h)
+	}
+	if m > 0 {
+		fmt.Fprintf(&sb, "%dM", m)
+	}
+	if sec > 0 || h == 0 && m == 0 {
+		fmt.Fprintf(&sb, "%dS", sec)
 	}
-	tz = defaultzone
+	return sb.String()
+}
+
+// addDuration adds a duration to a time, whole days are nominal (they keep
+// the wall clock time across DST transitions) as in RFC 5545.
+func addDuration(t time.Time, d time.Duration) time.Time {
+	days := d / day
+	return t.AddDate(0, 0, int(days)).Add(d - days*day)
+}
+
+// getTzidParam returns the location of the TZID parameter of a property, or
+// nil if it does not have one.
+func getTzidParam(prop *ical.Prop, tzs Timezones) (tz *time.Location, err error) {
 	tzid := prop.Params.Get(ical.PropTimezoneID)
 	if tzid == "" {
 		return
 	}
-	tz, err = time.LoadLocation(tzid)
-	if err != nil {
-		return
-	}
+	tz, err = LoadTimezone(tzid, tzs)
 	return
 }
 
@@ -68,17 +108,45 @@ func init() {
 	}
 }
 
-func setTzidParam(prop *ical.Prop, tz *time.Location) {
-	if prop.Params == nil {
-		prop.Params = make(ical.Params)
-	}
-	if tz == nil {
-		prop.Params.Del(ical.PropTimezoneID)
-		return
-	}
+// tzidName returns the TZID of a location, or an empty string if it cannot be
+// written as a TZID (ex. the fixed offsets of the dates nushell sends).
+func tzidName(tz *time.Location, tzs Timezones) string {
 	name := tz.String()
 	if name == "Local" {
 		name = tzname
 	}
-	prop.Params.Set(ical.PropTimezoneID, name)
+	if name == "" || name == "UTC" {
+		return ""
+	}
+	_, err := LoadTimezone(name, tzs)
+	if err != nil {
+		return ""
+	}
+	return name
+}
+
+// datetimeZone returns the location a property should be written in along
+// with its TZID (empty for UTC). The TZID the datetime was read with (or the
+// property already has) is kept, as datetimes that round trip through nushell
+// only keep their UTC offset.
+func (e Event) datetimeZone(name string, d Datetime) (tz *time.Location, tzid string) {
+	tzid = d.Timezone
+	if tzid == "" {
+		existing := e.Props.Get(name)
+		if existing != nil {
+			tzid = existing.Params.Get(ical.PropTimezoneID)
+		}
+	}
+	if tzid != "" {
+		var err error
+		tz, err = LoadTimezone(tzid, e.Timezones)
+		if err == nil {
+			return
+		}
+	}
+	tzid = tzidName(d.Stamp.Location(), e.Timezones)
+	if tzid == "" {
+		return time.UTC, ""
+	}
+	return d.Stamp.Location(), tzid
 }
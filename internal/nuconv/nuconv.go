@@ -8,41 +8,98 @@ import "github.com/ainvaltin/nu-plugin/types"
 import "github.com/LQR471814/nu_plugin_caldav/internal/events"
 import "github.com/LQR471814/nu_plugin_caldav/internal/dto"
 import "github.com/teambition/rrule-go"
-import "github.com/emersion/go-webdav/caldav"
 
-var type_15560982419391353847 = types.Int()
+var type_11848278600427152947 = types.Table(type_17659905764585210020)
 
-func type_15560982419391353847_FromNu(v nu.Value) (out events.EventTriggerRelative, err error) {
+func type_11848278600427152947_FromNu(v nu.Value) (out []dto.HoursPeriod, err error) {
 	defer func() {
 		if err != nil {
-			err = fmt.Errorf("events.EventTriggerRelative: %w", err)
+			err = fmt.Errorf("[]dto.HoursPeriod: %w", err)
 		}
 	}()
-	casted, ok := v.Value.(int64)
-	converted := events.EventTriggerRelative(casted)
+	if v.Value == nil {
+		return nil, nil
+	}
+	arr, ok := v.Value.([]nu.Value)
 	if !ok {
-		return converted, fmt.Errorf("expected int64 got %v", v.Value)
+		return nil, fmt.Errorf("expected []nu.Value got %T", v.Value)
 	}
-	return converted, nil
+	out = make([]dto.HoursPeriod, len(arr))
+	for i, e := range arr {
+		out[i], err = type_17659905764585210020_FromNu(e)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
 }
-func type_15560982419391353847_ToNu(v events.EventTriggerRelative) (out nu.Value, err error) {
+func type_11848278600427152947_ToNu(v []dto.HoursPeriod) (out nu.Value, err error) {
 	defer func() {
 		if err != nil {
-			err = fmt.Errorf("events.EventTriggerRelative: %w", err)
+			err = fmt.Errorf("[]dto.HoursPeriod: %w", err)
 		}
 	}()
-	return nu.ToValue(v), nil
+	list := make([]nu.Value, len(v))
+	for i, e := range v {
+		list[i], err = type_17659905764585210020_ToNu(e)
+		if err != nil {
+			return nu.Value{}, err
+		}
+	}
+	return nu.Value{Value: list}, nil
 }
 
-var type_15963329845892192617 = types.RecordDef{
-	"value":  type_15613163272824911089,
-	"params": type_14293658896741725053,
+var type_223612926626247449 = types.Table(type_13182519863719325967)
+
+func type_223612926626247449_FromNu(v nu.Value) (out dto.PushOutcomeList, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("dto.PushOutcomeList: %w", err)
+		}
+	}()
+	if v.Value == nil {
+		return nil, nil
+	}
+	arr, ok := v.Value.([]nu.Value)
+	if !ok {
+		return nil, fmt.Errorf("expected []nu.Value got %T", v.Value)
+	}
+	out = make(dto.PushOutcomeList, len(arr))
+	for i, e := range arr {
+		out[i], err = type_13182519863719325967_FromNu(e)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+func type_223612926626247449_ToNu(v dto.PushOutcomeList) (out nu.Value, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("dto.PushOutcomeList: %w", err)
+		}
+	}()
+	list := make([]nu.Value, len(v))
+	for i, e := range v {
+		list[i], err = type_13182519863719325967_ToNu(e)
+		if err != nil {
+			return nu.Value{}, err
+		}
+	}
+	return nu.Value{Value: list}, nil
 }
 
-func type_15963329845892192617_FromNu(v nu.Value) (out dto.PropValueDto, err error) {
+var type_5454485661162817076 = types.RecordDef{
+	"stamp":    type_8047992331715851194,
+	"all_day":  type_729807561129781588,
+	"floating": type_729807561129781588,
+	"timezone": type_15613163272824911089,
+}
+
+func type_5454485661162817076_FromNu(v nu.Value) (out events.Datetime, err error) {
 	defer func() {
 		if err != nil {
-			err = fmt.Errorf("dto.PropValueDto: %w", err)
+			err = fmt.Errorf("events.Datetime: %w", err)
 		}
 	}()
 	record, ok := v.Value.(nu.Record)
@@ -50,139 +107,162 @@ func type_15963329845892192617_FromNu(v nu.Value) (out dto.PropValueDto, err err
 		return out, fmt.Errorf("expected nu.Record got %T", v.Value)
 	}
 	var val nu.Value
-	val, _ = record["value"]
-	out.Value, err = type_15613163272824911089_FromNu(val)
+	val, _ = record["stamp"]
+	out.Stamp, err = type_8047992331715851194_FromNu(val)
 	if err != nil {
 		return out, err
 	}
-	val, _ = record["params"]
-	out.Params, err = type_14293658896741725053_FromNu(val)
-	if err != nil {
-		return out, err
+	val, ok = record["all_day"]
+	if !ok {
+		out.AllDay = false
+	} else {
+		out.AllDay, err = type_729807561129781588_FromNu(val)
+		if err != nil {
+			return out, err
+		}
+	}
+	val, ok = record["floating"]
+	if !ok {
+		out.Floating = false
+	} else {
+		out.Floating, err = type_729807561129781588_FromNu(val)
+		if err != nil {
+			return out, err
+		}
+	}
+	val, ok = record["timezone"]
+	if !ok {
+		out.Timezone = ""
+	} else {
+		out.Timezone, err = type_15613163272824911089_FromNu(val)
+		if err != nil {
+			return out, err
+		}
 	}
 	return out, nil
 }
-func type_15963329845892192617_ToNu(v dto.PropValueDto) (out nu.Value, err error) {
+func type_5454485661162817076_ToNu(v events.Datetime) (out nu.Value, err error) {
 	defer func() {
 		if err != nil {
-			err = fmt.Errorf("dto.PropValueDto: %w", err)
+			err = fmt.Errorf("events.Datetime: %w", err)
 		}
 	}()
 	rec := nu.Record{}
-	rec["value"], err = type_15613163272824911089_ToNu(v.Value)
+	rec["stamp"], err = type_8047992331715851194_ToNu(v.Stamp)
 	if err != nil {
 		return nu.Value{}, err
 	}
-	rec["params"], err = type_14293658896741725053_ToNu(v.Params)
+	rec["all_day"], err = type_729807561129781588_ToNu(v.AllDay)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["floating"], err = type_729807561129781588_ToNu(v.Floating)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["timezone"], err = type_15613163272824911089_ToNu(v.Timezone)
 	if err != nil {
 		return nu.Value{}, err
 	}
 	return nu.Value{Value: rec}, nil
 }
 
-var type_14293658896741725053 = types.Any()
+var type_7406295723486674371 = types.String()
 
-func type_14293658896741725053_FromNu(v nu.Value) (out map[string][]string, err error) {
+func type_7406295723486674371_FromNu(v nu.Value) (out dto.RRule, err error) {
 	defer func() {
 		if err != nil {
-			err = fmt.Errorf("map[string][]string: %w", err)
+			err = fmt.Errorf("dto.RRule: %w", err)
 		}
 	}()
 	if v.Value == nil {
-		return nil, nil
-	}
-	dict, ok := v.Value.(nu.Record)
-	if !ok {
-		return nil, fmt.Errorf("expected nu.Record got %T", v.Value)
+		return dto.RRule{}, nil
 	}
-	out = make(map[string][]string, len(dict))
-	for k, v := range dict {
-		out[k], err = type_11669970230249425419_FromNu(v)
-		if err != nil {
-			return nil, err
-		}
+	parsed, err := rrule.StrToRRule(v.Value.(string))
+	if err != nil {
+		return dto.RRule{}, err
 	}
-	return out, nil
+	return dto.RRule{RRule: parsed}, nil
 }
-func type_14293658896741725053_ToNu(v map[string][]string) (out nu.Value, err error) {
+func type_7406295723486674371_ToNu(v dto.RRule) (out nu.Value, err error) {
 	defer func() {
 		if err != nil {
-			err = fmt.Errorf("map[string][]string: %w", err)
+			err = fmt.Errorf("dto.RRule: %w", err)
 		}
 	}()
-	dict := make(nu.Record, len(v))
-	for k, v := range v {
-		dict[k], err = type_11669970230249425419_ToNu(v)
-		if err != nil {
-			return nu.Value{}, err
-		}
+	if v.RRule == nil {
+		return nu.Value{Value: nil}, nil
 	}
-	return nu.Value{Value: dict}, nil
+	return nu.ToValue(v.String()), nil
 }
 
-var type_8971279483973357571 = type_7057708295081751301
+var type_13545470577293064413 = types.RecordDef{
+	"relative":    type_5863190983406162214,
+	"relative_to": type_15560982419391353847,
+	"absolute":    type_15050730807189225719,
+}
 
-func type_8971279483973357571_FromNu(v nu.Value) (out *events.EventTransparency, err error) {
+func type_13545470577293064413_FromNu(v nu.Value) (out events.EventTrigger, err error) {
 	defer func() {
 		if err != nil {
-			err = fmt.Errorf("*events.EventTransparency: %w", err)
+			err = fmt.Errorf("events.EventTrigger: %w", err)
 		}
 	}()
-	if v.Value == nil {
-		return nil, nil
+	record, ok := v.Value.(nu.Record)
+	if !ok {
+		return out, fmt.Errorf("expected nu.Record got %T", v.Value)
 	}
-	res, err := type_7057708295081751301_FromNu(v)
+	var val nu.Value
+	val, _ = record["relative"]
+	out.Relative, err = type_5863190983406162214_FromNu(val)
 	if err != nil {
-		return nil, err
+		return out, err
 	}
-	return &res, nil
+	val, _ = record["relative_to"]
+	out.RelativeTo, err = type_15560982419391353847_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["absolute"]
+	out.Absolute, err = type_15050730807189225719_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	return out, nil
 }
-func type_8971279483973357571_ToNu(v *events.EventTransparency) (out nu.Value, err error) {
+func type_13545470577293064413_ToNu(v events.EventTrigger) (out nu.Value, err error) {
 	defer func() {
 		if err != nil {
-			err = fmt.Errorf("*events.EventTransparency: %w", err)
+			err = fmt.Errorf("events.EventTrigger: %w", err)
 		}
 	}()
-	if v == nil {
-		return nu.Value{}, nil
+	rec := nu.Record{}
+	rec["relative"], err = type_5863190983406162214_ToNu(v.Relative)
+	if err != nil {
+		return nu.Value{}, err
 	}
-	return type_7057708295081751301_ToNu(*v)
+	rec["relative_to"], err = type_15560982419391353847_ToNu(v.RelativeTo)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["absolute"], err = type_15050730807189225719_ToNu(v.Absolute)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	return nu.Value{Value: rec}, nil
 }
 
-var type_8814170927480347350 = types.RecordDef{
-	"uid":                        type_17862013815172309399,
-	"summary":                    type_17862013815172309399,
-	"location":                   type_17862013815172309399,
-	"description":                type_17862013815172309399,
-	"categories":                 type_11669970230249425419,
-	"datetime_stamp":             type_12480522309550428545,
-	"created":                    type_12480522309550428545,
-	"last_modified":              type_12480522309550428545,
-	"class":                      type_9664538759823739797,
-	"geo":                        type_7163250051298988498,
-	"priority":                   type_2584899110032584934,
-	"sequence":                   type_2584899110032584934,
-	"status":                     type_784588192188755836,
-	"transparency":               type_8971279483973357571,
-	"url":                        type_5363327835607766502,
-	"comment":                    type_17862013815172309399,
-	"attach":                     type_5363327835607766502,
-	"contact":                    type_17862013815172309399,
-	"organizer":                  type_5363327835607766502,
-	"start":                      types.Record(type_5454485661162817076),
-	"end":                        types.Record(type_5454485661162817076),
-	"recurrence_rule":            type_7406295723486674371,
-	"recurrence_dates":           type_3931126380996215332,
-	"recurrence_exception_dates": type_3931126380996215332,
-	"recurrence_instance":        type_12480522309550428545,
-	"trigger":                    type_9520111014888170891,
-	"other":                      type_12604977785371100614,
+var type_17059734539181546788 = types.RecordDef{
+	"from":      types.Record(type_8814170927480347350),
+	"to":        types.Record(type_8814170927480347350),
+	"travel":    type_16589689216511618220,
+	"available": type_16589689216511618220,
 }
 
-func type_8814170927480347350_FromNu(v nu.Value) (out dto.Event, err error) {
+func type_17059734539181546788_FromNu(v nu.Value) (out dto.Transition, err error) {
 	defer func() {
 		if err != nil {
-			err = fmt.Errorf("dto.Event: %w", err)
+			err = fmt.Errorf("dto.Transition: %w", err)
 		}
 	}()
 	record, ok := v.Value.(nu.Record)
@@ -190,480 +270,574 @@ func type_8814170927480347350_FromNu(v nu.Value) (out dto.Event, err error) {
 		return out, fmt.Errorf("expected nu.Record got %T", v.Value)
 	}
 	var val nu.Value
-	val, _ = record["uid"]
-	out.Uid, err = type_17862013815172309399_FromNu(val)
+	val, _ = record["from"]
+	out.From, err = type_8814170927480347350_FromNu(val)
 	if err != nil {
 		return out, err
 	}
-	val, _ = record["summary"]
-	out.Summary, err = type_17862013815172309399_FromNu(val)
+	val, _ = record["to"]
+	out.To, err = type_8814170927480347350_FromNu(val)
 	if err != nil {
 		return out, err
 	}
-	val, _ = record["location"]
-	out.Location, err = type_17862013815172309399_FromNu(val)
+	val, _ = record["travel"]
+	out.Travel, err = type_16589689216511618220_FromNu(val)
 	if err != nil {
 		return out, err
 	}
-	val, _ = record["description"]
-	out.Description, err = type_17862013815172309399_FromNu(val)
+	val, _ = record["available"]
+	out.Available, err = type_16589689216511618220_FromNu(val)
 	if err != nil {
 		return out, err
 	}
-	val, _ = record["categories"]
-	out.Categories, err = type_11669970230249425419_FromNu(val)
+	return out, nil
+}
+func type_17059734539181546788_ToNu(v dto.Transition) (out nu.Value, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("dto.Transition: %w", err)
+		}
+	}()
+	rec := nu.Record{}
+	rec["from"], err = type_8814170927480347350_ToNu(v.From)
 	if err != nil {
-		return out, err
+		return nu.Value{}, err
 	}
-	val, _ = record["datetime_stamp"]
-	out.DatetimeStamp, err = type_12480522309550428545_FromNu(val)
-	if err != nil {
-		return out, err
-	}
-	val, _ = record["created"]
-	out.Created, err = type_12480522309550428545_FromNu(val)
-	if err != nil {
-		return out, err
-	}
-	val, _ = record["last_modified"]
-	out.LastModified, err = type_12480522309550428545_FromNu(val)
-	if err != nil {
-		return out, err
-	}
-	val, _ = record["class"]
-	out.Class, err = type_9664538759823739797_FromNu(val)
-	if err != nil {
-		return out, err
-	}
-	val, _ = record["geo"]
-	out.Geo, err = type_7163250051298988498_FromNu(val)
-	if err != nil {
-		return out, err
-	}
-	val, _ = record["priority"]
-	out.Priority, err = type_2584899110032584934_FromNu(val)
-	if err != nil {
-		return out, err
-	}
-	val, _ = record["sequence"]
-	out.Sequence, err = type_2584899110032584934_FromNu(val)
+	rec["to"], err = type_8814170927480347350_ToNu(v.To)
 	if err != nil {
-		return out, err
+		return nu.Value{}, err
 	}
-	val, _ = record["status"]
-	out.Status, err = type_784588192188755836_FromNu(val)
+	rec["travel"], err = type_16589689216511618220_ToNu(v.Travel)
 	if err != nil {
-		return out, err
+		return nu.Value{}, err
 	}
-	val, _ = record["transparency"]
-	out.Transparency, err = type_8971279483973357571_FromNu(val)
+	rec["available"], err = type_16589689216511618220_ToNu(v.Available)
 	if err != nil {
-		return out, err
+		return nu.Value{}, err
 	}
-	val, _ = record["url"]
-	out.URL, err = type_5363327835607766502_FromNu(val)
-	if err != nil {
-		return out, err
+	return nu.Value{Value: rec}, nil
+}
+
+var type_2442914427180427065 = types.Table(type_17059734539181546788)
+
+func type_2442914427180427065_FromNu(v nu.Value) (out []dto.Transition, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("[]dto.Transition: %w", err)
+		}
+	}()
+	if v.Value == nil {
+		return nil, nil
 	}
-	val, _ = record["comment"]
-	out.Comment, err = type_17862013815172309399_FromNu(val)
-	if err != nil {
-		return out, err
+	arr, ok := v.Value.([]nu.Value)
+	if !ok {
+		return nil, fmt.Errorf("expected []nu.Value got %T", v.Value)
 	}
-	val, _ = record["attach"]
-	out.Attach, err = type_5363327835607766502_FromNu(val)
-	if err != nil {
-		return out, err
+	out = make([]dto.Transition, len(arr))
+	for i, e := range arr {
+		out[i], err = type_17059734539181546788_FromNu(e)
+		if err != nil {
+			return nil, err
+		}
 	}
-	val, _ = record["contact"]
-	out.Contact, err = type_17862013815172309399_FromNu(val)
-	if err != nil {
-		return out, err
+	return out, nil
+}
+func type_2442914427180427065_ToNu(v []dto.Transition) (out nu.Value, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("[]dto.Transition: %w", err)
+		}
+	}()
+	list := make([]nu.Value, len(v))
+	for i, e := range v {
+		list[i], err = type_17059734539181546788_ToNu(e)
+		if err != nil {
+			return nu.Value{}, err
+		}
 	}
-	val, _ = record["organizer"]
-	out.Organizer, err = type_5363327835607766502_FromNu(val)
-	if err != nil {
-		return out, err
+	return nu.Value{Value: list}, nil
+}
+
+var type_12917729306268329132 = types.RecordDef{
+	"start":       type_8047992331715851194,
+	"end":         type_8047992331715851194,
+	"duration":    type_16589689216511618220,
+	"occurrences": type_10491132004141824964,
+	"calendars":   type_11669970230249425419,
+}
+
+func type_12917729306268329132_FromNu(v nu.Value) (out dto.Conflict, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("dto.Conflict: %w", err)
+		}
+	}()
+	record, ok := v.Value.(nu.Record)
+	if !ok {
+		return out, fmt.Errorf("expected nu.Record got %T", v.Value)
 	}
+	var val nu.Value
 	val, _ = record["start"]
-	out.Start, err = type_5454485661162817076_FromNu(val)
+	out.Start, err = type_8047992331715851194_FromNu(val)
 	if err != nil {
 		return out, err
 	}
 	val, _ = record["end"]
-	out.End, err = type_5454485661162817076_FromNu(val)
-	if err != nil {
-		return out, err
-	}
-	val, _ = record["recurrence_rule"]
-	out.RecurrenceRule, err = type_7406295723486674371_FromNu(val)
-	if err != nil {
-		return out, err
-	}
-	val, _ = record["recurrence_dates"]
-	out.RecurrenceDates, err = type_3931126380996215332_FromNu(val)
-	if err != nil {
-		return out, err
-	}
-	val, _ = record["recurrence_exception_dates"]
-	out.RecurrenceExceptionDates, err = type_3931126380996215332_FromNu(val)
+	out.End, err = type_8047992331715851194_FromNu(val)
 	if err != nil {
 		return out, err
 	}
-	val, _ = record["recurrence_instance"]
-	out.RecurrenceInstance, err = type_12480522309550428545_FromNu(val)
+	val, _ = record["duration"]
+	out.Duration, err = type_16589689216511618220_FromNu(val)
 	if err != nil {
 		return out, err
 	}
-	val, _ = record["trigger"]
-	out.Trigger, err = type_9520111014888170891_FromNu(val)
+	val, _ = record["occurrences"]
+	out.Occurrences, err = type_10491132004141824964_FromNu(val)
 	if err != nil {
 		return out, err
 	}
-	val, _ = record["other"]
-	out.Other, err = type_12604977785371100614_FromNu(val)
+	val, _ = record["calendars"]
+	out.Calendars, err = type_11669970230249425419_FromNu(val)
 	if err != nil {
 		return out, err
 	}
 	return out, nil
 }
-func type_8814170927480347350_ToNu(v dto.Event) (out nu.Value, err error) {
+func type_12917729306268329132_ToNu(v dto.Conflict) (out nu.Value, err error) {
 	defer func() {
 		if err != nil {
-			err = fmt.Errorf("dto.Event: %w", err)
+			err = fmt.Errorf("dto.Conflict: %w", err)
 		}
 	}()
 	rec := nu.Record{}
-	rec["uid"], err = type_17862013815172309399_ToNu(v.Uid)
-	if err != nil {
-		return nu.Value{}, err
-	}
-	rec["summary"], err = type_17862013815172309399_ToNu(v.Summary)
-	if err != nil {
-		return nu.Value{}, err
-	}
-	rec["location"], err = type_17862013815172309399_ToNu(v.Location)
-	if err != nil {
-		return nu.Value{}, err
-	}
-	rec["description"], err = type_17862013815172309399_ToNu(v.Description)
-	if err != nil {
-		return nu.Value{}, err
-	}
-	rec["categories"], err = type_11669970230249425419_ToNu(v.Categories)
-	if err != nil {
-		return nu.Value{}, err
-	}
-	rec["datetime_stamp"], err = type_12480522309550428545_ToNu(v.DatetimeStamp)
-	if err != nil {
-		return nu.Value{}, err
-	}
-	rec["created"], err = type_12480522309550428545_ToNu(v.Created)
+	rec["start"], err = type_8047992331715851194_ToNu(v.Start)
 	if err != nil {
 		return nu.Value{}, err
 	}
-	rec["last_modified"], err = type_12480522309550428545_ToNu(v.LastModified)
+	rec["end"], err = type_8047992331715851194_ToNu(v.End)
 	if err != nil {
 		return nu.Value{}, err
 	}
-	rec["class"], err = type_9664538759823739797_ToNu(v.Class)
+	rec["duration"], err = type_16589689216511618220_ToNu(v.Duration)
 	if err != nil {
 		return nu.Value{}, err
 	}
-	rec["geo"], err = type_7163250051298988498_ToNu(v.Geo)
+	rec["occurrences"], err = type_10491132004141824964_ToNu(v.Occurrences)
 	if err != nil {
 		return nu.Value{}, err
 	}
-	rec["priority"], err = type_2584899110032584934_ToNu(v.Priority)
+	rec["calendars"], err = type_11669970230249425419_ToNu(v.Calendars)
 	if err != nil {
 		return nu.Value{}, err
 	}
-	rec["sequence"], err = type_2584899110032584934_ToNu(v.Sequence)
-	if err != nil {
-		return nu.Value{}, err
+	return nu.Value{Value: rec}, nil
+}
+
+var type_17659905764585210020 = types.RecordDef{
+	"start":    type_8047992331715851194,
+	"duration": type_16589689216511618220,
+}
+
+func type_17659905764585210020_FromNu(v nu.Value) (out dto.HoursPeriod, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("dto.HoursPeriod: %w", err)
+		}
+	}()
+	record, ok := v.Value.(nu.Record)
+	if !ok {
+		return out, fmt.Errorf("expected nu.Record got %T", v.Value)
 	}
-	rec["status"], err = type_784588192188755836_ToNu(v.Status)
+	var val nu.Value
+	val, _ = record["start"]
+	out.Start, err = type_8047992331715851194_FromNu(val)
 	if err != nil {
-		return nu.Value{}, err
+		return out, err
 	}
-	rec["transparency"], err = type_8971279483973357571_ToNu(v.Transparency)
+	val, _ = record["duration"]
+	out.Duration, err = type_16589689216511618220_FromNu(val)
 	if err != nil {
-		return nu.Value{}, err
+		return out, err
 	}
-	rec["url"], err = type_5363327835607766502_ToNu(v.URL)
+	return out, nil
+}
+func type_17659905764585210020_ToNu(v dto.HoursPeriod) (out nu.Value, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("dto.HoursPeriod: %w", err)
+		}
+	}()
+	rec := nu.Record{}
+	rec["start"], err = type_8047992331715851194_ToNu(v.Start)
 	if err != nil {
 		return nu.Value{}, err
 	}
-	rec["comment"], err = type_17862013815172309399_ToNu(v.Comment)
+	rec["duration"], err = type_16589689216511618220_ToNu(v.Duration)
 	if err != nil {
 		return nu.Value{}, err
 	}
-	rec["attach"], err = type_5363327835607766502_ToNu(v.Attach)
-	if err != nil {
-		return nu.Value{}, err
+	return nu.Value{Value: rec}, nil
+}
+
+var type_4541999656362150689 = types.RecordDef{
+	"object_path":   type_15613163272824911089,
+	"calendar_path": type_15613163272824911089,
+	"ics":           type_15613163272824911089,
+	"error":         type_15613163272824911089,
+	"failed_at":     type_8047992331715851194,
+}
+
+func type_4541999656362150689_FromNu(v nu.Value) (out dto.SyncFailure, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("dto.SyncFailure: %w", err)
+		}
+	}()
+	record, ok := v.Value.(nu.Record)
+	if !ok {
+		return out, fmt.Errorf("expected nu.Record got %T", v.Value)
 	}
-	rec["contact"], err = type_17862013815172309399_ToNu(v.Contact)
+	var val nu.Value
+	val, _ = record["object_path"]
+	out.ObjectPath, err = type_15613163272824911089_FromNu(val)
 	if err != nil {
-		return nu.Value{}, err
+		return out, err
 	}
-	rec["organizer"], err = type_5363327835607766502_ToNu(v.Organizer)
+	val, _ = record["calendar_path"]
+	out.CalendarPath, err = type_15613163272824911089_FromNu(val)
 	if err != nil {
-		return nu.Value{}, err
+		return out, err
 	}
-	rec["start"], err = type_5454485661162817076_ToNu(v.Start)
+	val, _ = record["ics"]
+	out.Ics, err = type_15613163272824911089_FromNu(val)
 	if err != nil {
-		return nu.Value{}, err
+		return out, err
 	}
-	rec["end"], err = type_5454485661162817076_ToNu(v.End)
+	val, _ = record["error"]
+	out.Error, err = type_15613163272824911089_FromNu(val)
 	if err != nil {
-		return nu.Value{}, err
+		return out, err
 	}
-	rec["recurrence_rule"], err = type_7406295723486674371_ToNu(v.RecurrenceRule)
+	val, _ = record["failed_at"]
+	out.FailedAt, err = type_8047992331715851194_FromNu(val)
 	if err != nil {
-		return nu.Value{}, err
+		return out, err
 	}
-	rec["recurrence_dates"], err = type_3931126380996215332_ToNu(v.RecurrenceDates)
+	return out, nil
+}
+func type_4541999656362150689_ToNu(v dto.SyncFailure) (out nu.Value, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("dto.SyncFailure: %w", err)
+		}
+	}()
+	rec := nu.Record{}
+	rec["object_path"], err = type_15613163272824911089_ToNu(v.ObjectPath)
 	if err != nil {
 		return nu.Value{}, err
 	}
-	rec["recurrence_exception_dates"], err = type_3931126380996215332_ToNu(v.RecurrenceExceptionDates)
+	rec["calendar_path"], err = type_15613163272824911089_ToNu(v.CalendarPath)
 	if err != nil {
 		return nu.Value{}, err
 	}
-	rec["recurrence_instance"], err = type_12480522309550428545_ToNu(v.RecurrenceInstance)
+	rec["ics"], err = type_15613163272824911089_ToNu(v.Ics)
 	if err != nil {
 		return nu.Value{}, err
 	}
-	rec["trigger"], err = type_9520111014888170891_ToNu(v.Trigger)
+	rec["error"], err = type_15613163272824911089_ToNu(v.Error)
 	if err != nil {
 		return nu.Value{}, err
 	}
-	rec["other"], err = type_12604977785371100614_ToNu(v.Other)
+	rec["failed_at"], err = type_8047992331715851194_ToNu(v.FailedAt)
 	if err != nil {
 		return nu.Value{}, err
 	}
 	return nu.Value{Value: rec}, nil
 }
 
-var type_12480522309550428545 = types.Record(type_5454485661162817076)
+var type_17862013815172309399 = type_15613163272824911089
 
-func type_12480522309550428545_FromNu(v nu.Value) (out *events.Datetime, err error) {
+func type_17862013815172309399_FromNu(v nu.Value) (out *string, err error) {
 	defer func() {
 		if err != nil {
-			err = fmt.Errorf("*events.Datetime: %w", err)
+			err = fmt.Errorf("*string: %w", err)
 		}
 	}()
 	if v.Value == nil {
 		return nil, nil
 	}
-	res, err := type_5454485661162817076_FromNu(v)
+	res, err := type_15613163272824911089_FromNu(v)
 	if err != nil {
 		return nil, err
 	}
 	return &res, nil
 }
-func type_12480522309550428545_ToNu(v *events.Datetime) (out nu.Value, err error) {
+func type_17862013815172309399_ToNu(v *string) (out nu.Value, err error) {
 	defer func() {
 		if err != nil {
-			err = fmt.Errorf("*events.Datetime: %w", err)
+			err = fmt.Errorf("*string: %w", err)
 		}
 	}()
 	if v == nil {
 		return nu.Value{}, nil
 	}
-	return type_5454485661162817076_ToNu(*v)
+	return type_15613163272824911089_ToNu(*v)
 }
 
-var type_2493169154543297135 = types.String()
+var type_15050730807189225719 = type_8047992331715851194
 
-func type_2493169154543297135_FromNu(v nu.Value) (out events.EventClass, err error) {
+func type_15050730807189225719_FromNu(v nu.Value) (out *time.Time, err error) {
 	defer func() {
 		if err != nil {
-			err = fmt.Errorf("events.EventClass: %w", err)
+			err = fmt.Errorf("*time.Time: %w", err)
 		}
 	}()
-	casted, ok := v.Value.(string)
-	converted := events.EventClass(casted)
-	if !ok {
-		return converted, fmt.Errorf("expected string got %v", v.Value)
+	if v.Value == nil {
+		return nil, nil
 	}
-	return converted, nil
+	res, err := type_8047992331715851194_FromNu(v)
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
 }
-func type_2493169154543297135_ToNu(v events.EventClass) (out nu.Value, err error) {
+func type_15050730807189225719_ToNu(v *time.Time) (out nu.Value, err error) {
 	defer func() {
 		if err != nil {
-			err = fmt.Errorf("events.EventClass: %w", err)
+			err = fmt.Errorf("*time.Time: %w", err)
 		}
 	}()
-	return nu.ToValue(v), nil
+	if v == nil {
+		return nu.Value{}, nil
+	}
+	return type_8047992331715851194_ToNu(*v)
 }
 
-var type_7161572108068222122 = types.RecordDef{
-	"latitude":  type_17860233973098560385,
-	"longitude": type_17860233973098560385,
-}
+var type_9049281093675579929 = types.Table(type_18439826349963270388)
 
-func type_7161572108068222122_FromNu(v nu.Value) (out events.EventGeo, err error) {
+func type_9049281093675579929_FromNu(v nu.Value) (out dto.EventObjectList, err error) {
 	defer func() {
 		if err != nil {
-			err = fmt.Errorf("events.EventGeo: %w", err)
+			err = fmt.Errorf("dto.EventObjectList: %w", err)
 		}
 	}()
-	record, ok := v.Value.(nu.Record)
-	if !ok {
-		return out, fmt.Errorf("expected nu.Record got %T", v.Value)
+	if v.Value == nil {
+		return nil, nil
 	}
-	var val nu.Value
-	val, _ = record["latitude"]
-	out.Latitude, err = type_17860233973098560385_FromNu(val)
-	if err != nil {
-		return out, err
+	arr, ok := v.Value.([]nu.Value)
+	if !ok {
+		return nil, fmt.Errorf("expected []nu.Value got %T", v.Value)
 	}
-	val, _ = record["longitude"]
-	out.Longitude, err = type_17860233973098560385_FromNu(val)
-	if err != nil {
-		return out, err
+	out = make(dto.EventObjectList, len(arr))
+	for i, e := range arr {
+		out[i], err = type_18439826349963270388_FromNu(e)
+		if err != nil {
+			return nil, err
+		}
 	}
 	return out, nil
 }
-func type_7161572108068222122_ToNu(v events.EventGeo) (out nu.Value, err error) {
+func type_9049281093675579929_ToNu(v dto.EventObjectList) (out nu.Value, err error) {
 	defer func() {
 		if err != nil {
-			err = fmt.Errorf("events.EventGeo: %w", err)
+			err = fmt.Errorf("dto.EventObjectList: %w", err)
 		}
 	}()
-	rec := nu.Record{}
-	rec["latitude"], err = type_17860233973098560385_ToNu(v.Latitude)
-	if err != nil {
-		return nu.Value{}, err
-	}
-	rec["longitude"], err = type_17860233973098560385_ToNu(v.Longitude)
-	if err != nil {
-		return nu.Value{}, err
+	list := make([]nu.Value, len(v))
+	for i, e := range v {
+		list[i], err = type_18439826349963270388_ToNu(e)
+		if err != nil {
+			return nu.Value{}, err
+		}
 	}
-	return nu.Value{Value: rec}, nil
+	return nu.Value{Value: list}, nil
 }
 
-var type_7406295723486674371 = types.String()
+var type_3158728863752183869 = types.Table(type_681218608244565547)
 
-func type_7406295723486674371_FromNu(v nu.Value) (out dto.RRule, err error) {
+func type_3158728863752183869_FromNu(v nu.Value) (out []dto.AgendaEntry, err error) {
 	defer func() {
 		if err != nil {
-			err = fmt.Errorf("dto.RRule: %w", err)
+			err = fmt.Errorf("[]dto.AgendaEntry: %w", err)
 		}
 	}()
 	if v.Value == nil {
-		return dto.RRule{}, nil
+		return nil, nil
 	}
-	parsed, err := rrule.StrToRRule(v.Value.(string))
-	if err != nil {
-		return dto.RRule{}, err
+	arr, ok := v.Value.([]nu.Value)
+	if !ok {
+		return nil, fmt.Errorf("expected []nu.Value got %T", v.Value)
 	}
-	return dto.RRule{RRule: parsed}, nil
+	out = make([]dto.AgendaEntry, len(arr))
+	for i, e := range arr {
+		out[i], err = type_681218608244565547_FromNu(e)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
 }
-func type_7406295723486674371_ToNu(v dto.RRule) (out nu.Value, err error) {
+func type_3158728863752183869_ToNu(v []dto.AgendaEntry) (out nu.Value, err error) {
 	defer func() {
 		if err != nil {
-			err = fmt.Errorf("dto.RRule: %w", err)
+			err = fmt.Errorf("[]dto.AgendaEntry: %w", err)
 		}
 	}()
-	if v.RRule == nil {
-		return nu.Value{Value: nil}, nil
+	list := make([]nu.Value, len(v))
+	for i, e := range v {
+		list[i], err = type_681218608244565547_ToNu(e)
+		if err != nil {
+			return nu.Value{}, err
+		}
 	}
-	return nu.ToValue(v.String()), nil
+	return nu.Value{Value: list}, nil
 }
 
-var type_16589689216511618220 = types.Duration()
+var type_14982353511810887690 = types.Table(type_606227063665950724)
 
-func type_16589689216511618220_FromNu(v nu.Value) (out time.Duration, err error) {
+func type_14982353511810887690_FromNu(v nu.Value) (out dto.AccessEntryList, err error) {
 	defer func() {
 		if err != nil {
-			err = fmt.Errorf("time.Duration: %w", err)
+			err = fmt.Errorf("dto.AccessEntryList: %w", err)
 		}
 	}()
-	out, ok := v.Value.(time.Duration)
+	if v.Value == nil {
+		return nil, nil
+	}
+	arr, ok := v.Value.([]nu.Value)
 	if !ok {
-		return out, fmt.Errorf("expected time.Duration got %T", v.Value)
+		return nil, fmt.Errorf("expected []nu.Value got %T", v.Value)
 	}
-	return
+	out = make(dto.AccessEntryList, len(arr))
+	for i, e := range arr {
+		out[i], err = type_606227063665950724_FromNu(e)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
 }
-func type_16589689216511618220_ToNu(v time.Duration) (out nu.Value, err error) {
+func type_14982353511810887690_ToNu(v dto.AccessEntryList) (out nu.Value, err error) {
 	defer func() {
 		if err != nil {
-			err = fmt.Errorf("time.Duration: %w", err)
+			err = fmt.Errorf("dto.AccessEntryList: %w", err)
 		}
 	}()
-	return nu.ToValue(v), nil
+	list := make([]nu.Value, len(v))
+	for i, e := range v {
+		list[i], err = type_606227063665950724_ToNu(e)
+		if err != nil {
+			return nu.Value{}, err
+		}
+	}
+	return nu.Value{Value: list}, nil
 }
 
-var type_1233005477764658533 = types.RecordDef{
-	"now":           type_8047992331715851194,
-	"duration":      type_16589689216511618220,
-	"active_events": type_601306316528950762,
-}
+var type_4344875875351294888 = types.Table(type_16952031748209517406)
 
-func type_1233005477764658533_FromNu(v nu.Value) (out dto.TimeSegment, err error) {
+func type_4344875875351294888_FromNu(v nu.Value) (out dto.PrincipalList, err error) {
 	defer func() {
 		if err != nil {
-			err = fmt.Errorf("dto.TimeSegment: %w", err)
+			err = fmt.Errorf("dto.PrincipalList: %w", err)
 		}
 	}()
-	record, ok := v.Value.(nu.Record)
-	if !ok {
-		return out, fmt.Errorf("expected nu.Record got %T", v.Value)
-	}
-	var val nu.Value
-	val, _ = record["now"]
-	out.Now, err = type_8047992331715851194_FromNu(val)
-	if err != nil {
-		return out, err
+	if v.Value == nil {
+		return nil, nil
 	}
-	val, _ = record["duration"]
-	out.Duration, err = type_16589689216511618220_FromNu(val)
-	if err != nil {
-		return out, err
+	arr, ok := v.Value.([]nu.Value)
+	if !ok {
+		return nil, fmt.Errorf("expected []nu.Value got %T", v.Value)
 	}
-	val, _ = record["active_events"]
-	out.ActiveEvents, err = type_601306316528950762_FromNu(val)
-	if err != nil {
-		return out, err
+	out = make(dto.PrincipalList, len(arr))
+	for i, e := range arr {
+		out[i], err = type_16952031748209517406_FromNu(e)
+		if err != nil {
+			return nil, err
+		}
 	}
 	return out, nil
 }
-func type_1233005477764658533_ToNu(v dto.TimeSegment) (out nu.Value, err error) {
+func type_4344875875351294888_ToNu(v dto.PrincipalList) (out nu.Value, err error) {
 	defer func() {
 		if err != nil {
-			err = fmt.Errorf("dto.TimeSegment: %w", err)
+			err = fmt.Errorf("dto.PrincipalList: %w", err)
 		}
 	}()
-	rec := nu.Record{}
-	rec["now"], err = type_8047992331715851194_ToNu(v.Now)
-	if err != nil {
-		return nu.Value{}, err
+	list := make([]nu.Value, len(v))
+	for i, e := range v {
+		list[i], err = type_16952031748209517406_ToNu(e)
+		if err != nil {
+			return nu.Value{}, err
+		}
 	}
-	rec["duration"], err = type_16589689216511618220_ToNu(v.Duration)
-	if err != nil {
-		return nu.Value{}, err
+	return nu.Value{Value: list}, nil
+}
+
+var type_784588192188755836 = type_15385297846572725340
+
+func type_784588192188755836_FromNu(v nu.Value) (out *events.EventStatus, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("*events.EventStatus: %w", err)
+		}
+	}()
+	if v.Value == nil {
+		return nil, nil
 	}
-	rec["active_events"], err = type_601306316528950762_ToNu(v.ActiveEvents)
+	res, err := type_15385297846572725340_FromNu(v)
 	if err != nil {
-		return nu.Value{}, err
+		return nil, err
 	}
-	return nu.Value{Value: rec}, nil
+	return &res, nil
+}
+func type_784588192188755836_ToNu(v *events.EventStatus) (out nu.Value, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("*events.EventStatus: %w", err)
+		}
+	}()
+	if v == nil {
+		return nu.Value{}, nil
+	}
+	return type_15385297846572725340_ToNu(*v)
 }
 
-var type_18369289839240265122 = types.RecordDef{
-	"path":                    type_15613163272824911089,
-	"name":                    type_15613163272824911089,
-	"description":             type_15613163272824911089,
-	"max_resource_size":       type_15139881813094606131,
-	"supported_component_set": type_11669970230249425419,
+var type_15139881813094606131 = types.Int()
+
+func type_15139881813094606131_FromNu(v nu.Value) (out int64, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("int64: %w", err)
+		}
+	}()
+	casted, ok := v.Value.(int64)
+	converted := int64(casted)
+	if !ok {
+		return converted, fmt.Errorf("expected int64 got %v", v.Value)
+	}
+	return converted, nil
+}
+func type_15139881813094606131_ToNu(v int64) (out nu.Value, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("int64: %w", err)
+		}
+	}()
+	return nu.ToValue(v), nil
+}
+
+var type_16952031748209517406 = types.RecordDef{
+	"path":              type_15613163272824911089,
+	"name":              type_17862013815172309399,
+	"addresses":         type_11669970230249425419,
+	"calendar_home_set": type_17862013815172309399,
 }
 
-func type_18369289839240265122_FromNu(v nu.Value) (out caldav.Calendar, err error) {
+func type_16952031748209517406_FromNu(v nu.Value) (out dto.Principal, err error) {
 	defer func() {
 		if err != nil {
-			err = fmt.Errorf("caldav.Calendar: %w", err)
+			err = fmt.Errorf("dto.Principal: %w", err)
 		}
 	}()
 	record, ok := v.Value.(nu.Record)
@@ -677,31 +851,26 @@ func type_18369289839240265122_FromNu(v nu.Value) (out caldav.Calendar, err erro
 		return out, err
 	}
 	val, _ = record["name"]
-	out.Name, err = type_15613163272824911089_FromNu(val)
-	if err != nil {
-		return out, err
-	}
-	val, _ = record["description"]
-	out.Description, err = type_15613163272824911089_FromNu(val)
+	out.Name, err = type_17862013815172309399_FromNu(val)
 	if err != nil {
 		return out, err
 	}
-	val, _ = record["max_resource_size"]
-	out.MaxResourceSize, err = type_15139881813094606131_FromNu(val)
+	val, _ = record["addresses"]
+	out.Addresses, err = type_11669970230249425419_FromNu(val)
 	if err != nil {
 		return out, err
 	}
-	val, _ = record["supported_component_set"]
-	out.SupportedComponentSet, err = type_11669970230249425419_FromNu(val)
+	val, _ = record["calendar_home_set"]
+	out.CalendarHomeSet, err = type_17862013815172309399_FromNu(val)
 	if err != nil {
 		return out, err
 	}
 	return out, nil
 }
-func type_18369289839240265122_ToNu(v caldav.Calendar) (out nu.Value, err error) {
+func type_16952031748209517406_ToNu(v dto.Principal) (out nu.Value, err error) {
 	defer func() {
 		if err != nil {
-			err = fmt.Errorf("caldav.Calendar: %w", err)
+			err = fmt.Errorf("dto.Principal: %w", err)
 		}
 	}()
 	rec := nu.Record{}
@@ -709,28 +878,72 @@ func type_18369289839240265122_ToNu(v caldav.Calendar) (out nu.Value, err error)
 	if err != nil {
 		return nu.Value{}, err
 	}
-	rec["name"], err = type_15613163272824911089_ToNu(v.Name)
-	if err != nil {
-		return nu.Value{}, err
-	}
-	rec["description"], err = type_15613163272824911089_ToNu(v.Description)
+	rec["name"], err = type_17862013815172309399_ToNu(v.Name)
 	if err != nil {
 		return nu.Value{}, err
 	}
-	rec["max_resource_size"], err = type_15139881813094606131_ToNu(v.MaxResourceSize)
+	rec["addresses"], err = type_11669970230249425419_ToNu(v.Addresses)
 	if err != nil {
 		return nu.Value{}, err
 	}
-	rec["supported_component_set"], err = type_11669970230249425419_ToNu(v.SupportedComponentSet)
+	rec["calendar_home_set"], err = type_17862013815172309399_ToNu(v.CalendarHomeSet)
 	if err != nil {
 		return nu.Value{}, err
 	}
 	return nu.Value{Value: rec}, nil
 }
 
-var type_5863190983406162214 = type_16589689216511618220
+var type_2493169154543297135 = types.String()
 
-func type_5863190983406162214_FromNu(v nu.Value) (out *time.Duration, err error) {
+func type_2493169154543297135_FromNu(v nu.Value) (out events.EventClass, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("events.EventClass: %w", err)
+		}
+	}()
+	casted, ok := v.Value.(string)
+	converted := events.EventClass(casted)
+	if !ok {
+		return converted, fmt.Errorf("expected string got %v", v.Value)
+	}
+	return converted, nil
+}
+func type_2493169154543297135_ToNu(v events.EventClass) (out nu.Value, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("events.EventClass: %w", err)
+		}
+	}()
+	return nu.ToValue(v), nil
+}
+
+var type_15385297846572725340 = types.String()
+
+func type_15385297846572725340_FromNu(v nu.Value) (out events.EventStatus, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("events.EventStatus: %w", err)
+		}
+	}()
+	casted, ok := v.Value.(string)
+	converted := events.EventStatus(casted)
+	if !ok {
+		return converted, fmt.Errorf("expected string got %v", v.Value)
+	}
+	return converted, nil
+}
+func type_15385297846572725340_ToNu(v events.EventStatus) (out nu.Value, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("events.EventStatus: %w", err)
+		}
+	}()
+	return nu.ToValue(v), nil
+}
+
+var type_5863190983406162214 = type_16589689216511618220
+
+func type_5863190983406162214_FromNu(v nu.Value) (out *time.Duration, err error) {
 	defer func() {
 		if err != nil {
 			err = fmt.Errorf("*time.Duration: %w", err)
@@ -757,12 +970,12 @@ func type_5863190983406162214_ToNu(v *time.Duration) (out nu.Value, err error) {
 	return type_16589689216511618220_ToNu(*v)
 }
 
-var type_11923325321682739420 = types.Table(type_1233005477764658533)
+var type_15564594043207847740 = types.Table(type_11240250125308434415)
 
-func type_11923325321682739420_FromNu(v nu.Value) (out dto.Timeline, err error) {
+func type_15564594043207847740_FromNu(v nu.Value) (out dto.FreeSlotList, err error) {
 	defer func() {
 		if err != nil {
-			err = fmt.Errorf("dto.Timeline: %w", err)
+			err = fmt.Errorf("dto.FreeSlotList: %w", err)
 		}
 	}()
 	if v.Value == nil {
@@ -772,24 +985,24 @@ func type_11923325321682739420_FromNu(v nu.Value) (out dto.Timeline, err error)
 	if !ok {
 		return nil, fmt.Errorf("expected []nu.Value got %T", v.Value)
 	}
-	out = make(dto.Timeline, len(arr))
+	out = make(dto.FreeSlotList, len(arr))
 	for i, e := range arr {
-		out[i], err = type_1233005477764658533_FromNu(e)
+		out[i], err = type_11240250125308434415_FromNu(e)
 		if err != nil {
 			return nil, err
 		}
 	}
 	return out, nil
 }
-func type_11923325321682739420_ToNu(v dto.Timeline) (out nu.Value, err error) {
+func type_15564594043207847740_ToNu(v dto.FreeSlotList) (out nu.Value, err error) {
 	defer func() {
 		if err != nil {
-			err = fmt.Errorf("dto.Timeline: %w", err)
+			err = fmt.Errorf("dto.FreeSlotList: %w", err)
 		}
 	}()
 	list := make([]nu.Value, len(v))
 	for i, e := range v {
-		list[i], err = type_1233005477764658533_ToNu(e)
+		list[i], err = type_11240250125308434415_ToNu(e)
 		if err != nil {
 			return nu.Value{}, err
 		}
@@ -797,12 +1010,36 @@ func type_11923325321682739420_ToNu(v dto.Timeline) (out nu.Value, err error) {
 	return nu.Value{Value: list}, nil
 }
 
-var type_3931126380996215332 = types.Table(type_5454485661162817076)
+var type_729807561129781588 = types.Bool()
 
-func type_3931126380996215332_FromNu(v nu.Value) (out []events.Datetime, err error) {
+func type_729807561129781588_FromNu(v nu.Value) (out bool, err error) {
 	defer func() {
 		if err != nil {
-			err = fmt.Errorf("[]events.Datetime: %w", err)
+			err = fmt.Errorf("bool: %w", err)
+		}
+	}()
+	casted, ok := v.Value.(bool)
+	converted := bool(casted)
+	if !ok {
+		return converted, fmt.Errorf("expected bool got %v", v.Value)
+	}
+	return converted, nil
+}
+func type_729807561129781588_ToNu(v bool) (out nu.Value, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("bool: %w", err)
+		}
+	}()
+	return nu.ToValue(v), nil
+}
+
+var type_9238984578611918813 = types.List(type_7406295723486674371)
+
+func type_9238984578611918813_FromNu(v nu.Value) (out []dto.RRule, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("[]dto.RRule: %w", err)
 		}
 	}()
 	if v.Value == nil {
@@ -812,24 +1049,24 @@ func type_3931126380996215332_FromNu(v nu.Value) (out []events.Datetime, err err
 	if !ok {
 		return nil, fmt.Errorf("expected []nu.Value got %T", v.Value)
 	}
-	out = make([]events.Datetime, len(arr))
+	out = make([]dto.RRule, len(arr))
 	for i, e := range arr {
-		out[i], err = type_5454485661162817076_FromNu(e)
+		out[i], err = type_7406295723486674371_FromNu(e)
 		if err != nil {
 			return nil, err
 		}
 	}
 	return out, nil
 }
-func type_3931126380996215332_ToNu(v []events.Datetime) (out nu.Value, err error) {
+func type_9238984578611918813_ToNu(v []dto.RRule) (out nu.Value, err error) {
 	defer func() {
 		if err != nil {
-			err = fmt.Errorf("[]events.Datetime: %w", err)
+			err = fmt.Errorf("[]dto.RRule: %w", err)
 		}
 	}()
 	list := make([]nu.Value, len(v))
 	for i, e := range v {
-		list[i], err = type_5454485661162817076_ToNu(e)
+		list[i], err = type_7406295723486674371_ToNu(e)
 		if err != nil {
 			return nu.Value{}, err
 		}
@@ -837,210 +1074,2118 @@ func type_3931126380996215332_ToNu(v []events.Datetime) (out nu.Value, err error
 	return nu.Value{Value: list}, nil
 }
 
-var type_1838685811995560013 = types.Table(type_18369289839240265122)
+var type_14293658896741725053 = types.Any()
 
-func type_1838685811995560013_FromNu(v nu.Value) (out dto.CalendarList, err error) {
+func type_14293658896741725053_FromNu(v nu.Value) (out map[string][]string, err error) {
 	defer func() {
 		if err != nil {
-			err = fmt.Errorf("dto.CalendarList: %w", err)
+			err = fmt.Errorf("map[string][]string: %w", err)
 		}
 	}()
 	if v.Value == nil {
 		return nil, nil
 	}
-	arr, ok := v.Value.([]nu.Value)
+	dict, ok := v.Value.(nu.Record)
 	if !ok {
-		return nil, fmt.Errorf("expected []nu.Value got %T", v.Value)
+		return nil, fmt.Errorf("expected nu.Record got %T", v.Value)
 	}
-	out = make(dto.CalendarList, len(arr))
-	for i, e := range arr {
-		out[i], err = type_18369289839240265122_FromNu(e)
+	out = make(map[string][]string, len(dict))
+	for k, v := range dict {
+		out[k], err = type_11669970230249425419_FromNu(v)
 		if err != nil {
 			return nil, err
 		}
 	}
 	return out, nil
 }
-func type_1838685811995560013_ToNu(v dto.CalendarList) (out nu.Value, err error) {
+func type_14293658896741725053_ToNu(v map[string][]string) (out nu.Value, err error) {
 	defer func() {
 		if err != nil {
-			err = fmt.Errorf("dto.CalendarList: %w", err)
+			err = fmt.Errorf("map[string][]string: %w", err)
 		}
 	}()
-	list := make([]nu.Value, len(v))
-	for i, e := range v {
-		list[i], err = type_18369289839240265122_ToNu(e)
+	dict := make(nu.Record, len(v))
+	for k, v := range v {
+		dict[k], err = type_11669970230249425419_ToNu(v)
 		if err != nil {
 			return nu.Value{}, err
 		}
 	}
-	return nu.Value{Value: list}, nil
+	return nu.Value{Value: dict}, nil
 }
 
-var type_15613163272824911089 = types.String()
+var type_15963329845892192617 = types.RecordDef{
+	"value":  type_15613163272824911089,
+	"params": type_14293658896741725053,
+}
 
-func type_15613163272824911089_FromNu(v nu.Value) (out string, err error) {
+func type_15963329845892192617_FromNu(v nu.Value) (out dto.PropValueDto, err error) {
 	defer func() {
 		if err != nil {
-			err = fmt.Errorf("string: %w", err)
+			err = fmt.Errorf("dto.PropValueDto: %w", err)
 		}
 	}()
-	casted, ok := v.Value.(string)
-	converted := string(casted)
+	record, ok := v.Value.(nu.Record)
 	if !ok {
-		return converted, fmt.Errorf("expected string got %v", v.Value)
+		return out, fmt.Errorf("expected nu.Record got %T", v.Value)
 	}
-	return converted, nil
+	var val nu.Value
+	val, _ = record["value"]
+	out.Value, err = type_15613163272824911089_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["params"]
+	out.Params, err = type_14293658896741725053_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	return out, nil
 }
-func type_15613163272824911089_ToNu(v string) (out nu.Value, err error) {
+func type_15963329845892192617_ToNu(v dto.PropValueDto) (out nu.Value, err error) {
 	defer func() {
 		if err != nil {
-			err = fmt.Errorf("string: %w", err)
+			err = fmt.Errorf("dto.PropValueDto: %w", err)
 		}
 	}()
-	return nu.ToValue(v), nil
+	rec := nu.Record{}
+	rec["value"], err = type_15613163272824911089_ToNu(v.Value)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["params"], err = type_14293658896741725053_ToNu(v.Params)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	return nu.Value{Value: rec}, nil
 }
 
-var type_17862013815172309399 = type_15613163272824911089
+var type_11923325321682739420 = types.Table(type_1233005477764658533)
 
-func type_17862013815172309399_FromNu(v nu.Value) (out *string, err error) {
+func type_11923325321682739420_FromNu(v nu.Value) (out dto.Timeline, err error) {
 	defer func() {
 		if err != nil {
-			err = fmt.Errorf("*string: %w", err)
+			err = fmt.Errorf("dto.Timeline: %w", err)
 		}
 	}()
 	if v.Value == nil {
 		return nil, nil
 	}
-	res, err := type_15613163272824911089_FromNu(v)
-	if err != nil {
-		return nil, err
+	arr, ok := v.Value.([]nu.Value)
+	if !ok {
+		return nil, fmt.Errorf("expected []nu.Value got %T", v.Value)
 	}
-	return &res, nil
-}
-func type_17862013815172309399_ToNu(v *string) (out nu.Value, err error) {
-	defer func() {
+	out = make(dto.Timeline, len(arr))
+	for i, e := range arr {
+		out[i], err = type_1233005477764658533_FromNu(e)
 		if err != nil {
-			err = fmt.Errorf("*string: %w", err)
+			return nil, err
 		}
-	}()
-	if v == nil {
-		return nu.Value{}, nil
 	}
-	return type_15613163272824911089_ToNu(*v)
+	return out, nil
 }
-
-var type_729807561129781588 = types.Bool()
-
-func type_729807561129781588_FromNu(v nu.Value) (out bool, err error) {
+func type_11923325321682739420_ToNu(v dto.Timeline) (out nu.Value, err error) {
 	defer func() {
 		if err != nil {
-			err = fmt.Errorf("bool: %w", err)
+			err = fmt.Errorf("dto.Timeline: %w", err)
 		}
 	}()
-	casted, ok := v.Value.(bool)
-	converted := bool(casted)
-	if !ok {
-		return converted, fmt.Errorf("expected bool got %v", v.Value)
-	}
-	return converted, nil
-}
-func type_729807561129781588_ToNu(v bool) (out nu.Value, err error) {
-	defer func() {
+	list := make([]nu.Value, len(v))
+	for i, e := range v {
+		list[i], err = type_1233005477764658533_ToNu(e)
 		if err != nil {
-			err = fmt.Errorf("bool: %w", err)
+			return nu.Value{}, err
 		}
-	}()
-	return nu.ToValue(v), nil
+	}
+	return nu.Value{Value: list}, nil
 }
 
-var type_17860233973098560385 = types.Float()
+var type_11240250125308434415 = types.RecordDef{
+	"start":    type_8047992331715851194,
+	"end":      type_8047992331715851194,
+	"duration": type_16589689216511618220,
+}
 
-func type_17860233973098560385_FromNu(v nu.Value) (out float64, err error) {
+func type_11240250125308434415_FromNu(v nu.Value) (out dto.FreeSlot, err error) {
 	defer func() {
 		if err != nil {
-			err = fmt.Errorf("float64: %w", err)
+			err = fmt.Errorf("dto.FreeSlot: %w", err)
 		}
 	}()
-	casted, ok := v.Value.(float64)
-	converted := float64(casted)
+	record, ok := v.Value.(nu.Record)
 	if !ok {
-		return converted, fmt.Errorf("expected float64 got %v", v.Value)
+		return out, fmt.Errorf("expected nu.Record got %T", v.Value)
 	}
-	return converted, nil
+	var val nu.Value
+	val, _ = record["start"]
+	out.Start, err = type_8047992331715851194_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["end"]
+	out.End, err = type_8047992331715851194_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["duration"]
+	out.Duration, err = type_16589689216511618220_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	return out, nil
 }
-func type_17860233973098560385_ToNu(v float64) (out nu.Value, err error) {
+func type_11240250125308434415_ToNu(v dto.FreeSlot) (out nu.Value, err error) {
 	defer func() {
 		if err != nil {
-			err = fmt.Errorf("float64: %w", err)
+			err = fmt.Errorf("dto.FreeSlot: %w", err)
 		}
 	}()
-	return nu.ToValue(v), nil
+	rec := nu.Record{}
+	rec["start"], err = type_8047992331715851194_ToNu(v.Start)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["end"], err = type_8047992331715851194_ToNu(v.End)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["duration"], err = type_16589689216511618220_ToNu(v.Duration)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	return nu.Value{Value: rec}, nil
 }
 
-var type_7057708295081751301 = types.String()
+var type_4819107696191819573 = types.RecordDef{
+	"path":           type_15613163272824911089,
+	"sync_token":     type_17862013815172309399,
+	"last_sync":      type_15050730807189225719,
+	"objects":        type_15139881813094606131,
+	"parse_failures": type_15139881813094606131,
+}
 
-func type_7057708295081751301_FromNu(v nu.Value) (out events.EventTransparency, err error) {
+func type_4819107696191819573_FromNu(v nu.Value) (out dto.CachedCalendar, err error) {
 	defer func() {
 		if err != nil {
-			err = fmt.Errorf("events.EventTransparency: %w", err)
+			err = fmt.Errorf("dto.CachedCalendar: %w", err)
+		}
+	}()
+	record, ok := v.Value.(nu.Record)
+	if !ok {
+		return out, fmt.Errorf("expected nu.Record got %T", v.Value)
+	}
+	var val nu.Value
+	val, _ = record["path"]
+	out.Path, err = type_15613163272824911089_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["sync_token"]
+	out.SyncToken, err = type_17862013815172309399_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["last_sync"]
+	out.LastSync, err = type_15050730807189225719_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["objects"]
+	out.Objects, err = type_15139881813094606131_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["parse_failures"]
+	out.ParseFailures, err = type_15139881813094606131_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	return out, nil
+}
+func type_4819107696191819573_ToNu(v dto.CachedCalendar) (out nu.Value, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("dto.CachedCalendar: %w", err)
+		}
+	}()
+	rec := nu.Record{}
+	rec["path"], err = type_15613163272824911089_ToNu(v.Path)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["sync_token"], err = type_17862013815172309399_ToNu(v.SyncToken)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["last_sync"], err = type_15050730807189225719_ToNu(v.LastSync)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["objects"], err = type_15139881813094606131_ToNu(v.Objects)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["parse_failures"], err = type_15139881813094606131_ToNu(v.ParseFailures)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	return nu.Value{Value: rec}, nil
+}
+
+var type_11669970230249425419 = types.List(type_15613163272824911089)
+
+func type_11669970230249425419_FromNu(v nu.Value) (out []string, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("[]string: %w", err)
+		}
+	}()
+	if v.Value == nil {
+		return nil, nil
+	}
+	arr, ok := v.Value.([]nu.Value)
+	if !ok {
+		return nil, fmt.Errorf("expected []nu.Value got %T", v.Value)
+	}
+	out = make([]string, len(arr))
+	for i, e := range arr {
+		out[i], err = type_15613163272824911089_FromNu(e)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+func type_11669970230249425419_ToNu(v []string) (out nu.Value, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("[]string: %w", err)
+		}
+	}()
+	list := make([]nu.Value, len(v))
+	for i, e := range v {
+		list[i], err = type_15613163272824911089_ToNu(e)
+		if err != nil {
+			return nu.Value{}, err
+		}
+	}
+	return nu.Value{Value: list}, nil
+}
+
+var type_17860233973098560385 = types.Float()
+
+func type_17860233973098560385_FromNu(v nu.Value) (out float64, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("float64: %w", err)
+		}
+	}()
+	casted, ok := v.Value.(float64)
+	converted := float64(casted)
+	if !ok {
+		return converted, fmt.Errorf("expected float64 got %v", v.Value)
+	}
+	return converted, nil
+}
+func type_17860233973098560385_ToNu(v float64) (out nu.Value, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("float64: %w", err)
+		}
+	}()
+	return nu.ToValue(v), nil
+}
+
+var type_6453951478216933494 = types.RecordDef{
+	"object_path":    type_17862013815172309399,
+	"calendar_path":  type_17862013815172309399,
+	"calendar_name":  type_17862013815172309399,
+	"start":          type_8047992331715851194,
+	"end":            type_8047992331715851194,
+	"all_day":        type_729807561129781588,
+	"recurrence_i_d": type_15050730807189225719,
+	"override":       type_729807561129781588,
+	"event":          types.Record(type_8814170927480347350),
+}
+
+func type_6453951478216933494_FromNu(v nu.Value) (out dto.Occurrence, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("dto.Occurrence: %w", err)
+		}
+	}()
+	record, ok := v.Value.(nu.Record)
+	if !ok {
+		return out, fmt.Errorf("expected nu.Record got %T", v.Value)
+	}
+	var val nu.Value
+	val, _ = record["object_path"]
+	out.ObjectPath, err = type_17862013815172309399_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["calendar_path"]
+	out.CalendarPath, err = type_17862013815172309399_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["calendar_name"]
+	out.CalendarName, err = type_17862013815172309399_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["start"]
+	out.Start, err = type_8047992331715851194_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["end"]
+	out.End, err = type_8047992331715851194_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, ok = record["all_day"]
+	if !ok {
+		out.AllDay = false
+	} else {
+		out.AllDay, err = type_729807561129781588_FromNu(val)
+		if err != nil {
+			return out, err
+		}
+	}
+	val, _ = record["recurrence_i_d"]
+	out.RecurrenceID, err = type_15050730807189225719_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, ok = record["override"]
+	if !ok {
+		out.Override = false
+	} else {
+		out.Override, err = type_729807561129781588_FromNu(val)
+		if err != nil {
+			return out, err
+		}
+	}
+	val, _ = record["event"]
+	out.Event, err = type_8814170927480347350_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	return out, nil
+}
+func type_6453951478216933494_ToNu(v dto.Occurrence) (out nu.Value, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("dto.Occurrence: %w", err)
+		}
+	}()
+	rec := nu.Record{}
+	rec["object_path"], err = type_17862013815172309399_ToNu(v.ObjectPath)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["calendar_path"], err = type_17862013815172309399_ToNu(v.CalendarPath)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["calendar_name"], err = type_17862013815172309399_ToNu(v.CalendarName)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["start"], err = type_8047992331715851194_ToNu(v.Start)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["end"], err = type_8047992331715851194_ToNu(v.End)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["all_day"], err = type_729807561129781588_ToNu(v.AllDay)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["recurrence_i_d"], err = type_15050730807189225719_ToNu(v.RecurrenceID)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["override"], err = type_729807561129781588_ToNu(v.Override)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["event"], err = type_8814170927480347350_ToNu(v.Event)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	return nu.Value{Value: rec}, nil
+}
+
+var type_1838685811995560013 = types.Table(type_1466475515312567685)
+
+func type_1838685811995560013_FromNu(v nu.Value) (out dto.CalendarList, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("dto.CalendarList: %w", err)
+		}
+	}()
+	if v.Value == nil {
+		return nil, nil
+	}
+	arr, ok := v.Value.([]nu.Value)
+	if !ok {
+		return nil, fmt.Errorf("expected []nu.Value got %T", v.Value)
+	}
+	out = make(dto.CalendarList, len(arr))
+	for i, e := range arr {
+		out[i], err = type_1466475515312567685_FromNu(e)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+func type_1838685811995560013_ToNu(v dto.CalendarList) (out nu.Value, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("dto.CalendarList: %w", err)
+		}
+	}()
+	list := make([]nu.Value, len(v))
+	for i, e := range v {
+		list[i], err = type_1466475515312567685_ToNu(e)
+		if err != nil {
+			return nu.Value{}, err
+		}
+	}
+	return nu.Value{Value: list}, nil
+}
+
+var type_13182519863719325967 = types.RecordDef{
+	"operation":     type_15613163272824911089,
+	"calendar_path": type_15613163272824911089,
+	"object_path":   type_15613163272824911089,
+	"queued_at":     type_8047992331715851194,
+	"outcome":       type_15613163272824911089,
+	"error":         type_17862013815172309399,
+}
+
+func type_13182519863719325967_FromNu(v nu.Value) (out dto.PushOutcome, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("dto.PushOutcome: %w", err)
+		}
+	}()
+	record, ok := v.Value.(nu.Record)
+	if !ok {
+		return out, fmt.Errorf("expected nu.Record got %T", v.Value)
+	}
+	var val nu.Value
+	val, _ = record["operation"]
+	out.Operation, err = type_15613163272824911089_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["calendar_path"]
+	out.CalendarPath, err = type_15613163272824911089_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["object_path"]
+	out.ObjectPath, err = type_15613163272824911089_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["queued_at"]
+	out.QueuedAt, err = type_8047992331715851194_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["outcome"]
+	out.Outcome, err = type_15613163272824911089_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["error"]
+	out.Error, err = type_17862013815172309399_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	return out, nil
+}
+func type_13182519863719325967_ToNu(v dto.PushOutcome) (out nu.Value, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("dto.PushOutcome: %w", err)
+		}
+	}()
+	rec := nu.Record{}
+	rec["operation"], err = type_15613163272824911089_ToNu(v.Operation)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["calendar_path"], err = type_15613163272824911089_ToNu(v.CalendarPath)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["object_path"], err = type_15613163272824911089_ToNu(v.ObjectPath)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["queued_at"], err = type_8047992331715851194_ToNu(v.QueuedAt)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["outcome"], err = type_15613163272824911089_ToNu(v.Outcome)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["error"], err = type_17862013815172309399_ToNu(v.Error)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	return nu.Value{Value: rec}, nil
+}
+
+var type_11687433542174887081 = types.RecordDef{
+	"path":               type_15613163272824911089,
+	"size":               type_15139881813094606131,
+	"calendars":          type_15139881813094606131,
+	"objects":            type_15139881813094606131,
+	"pending_operations": type_15139881813094606131,
+}
+
+func type_11687433542174887081_FromNu(v nu.Value) (out dto.CacheStatus, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("dto.CacheStatus: %w", err)
+		}
+	}()
+	record, ok := v.Value.(nu.Record)
+	if !ok {
+		return out, fmt.Errorf("expected nu.Record got %T", v.Value)
+	}
+	var val nu.Value
+	val, _ = record["path"]
+	out.Path, err = type_15613163272824911089_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["size"]
+	out.Size, err = type_15139881813094606131_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["calendars"]
+	out.Calendars, err = type_15139881813094606131_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["objects"]
+	out.Objects, err = type_15139881813094606131_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["pending_operations"]
+	out.PendingOperations, err = type_15139881813094606131_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	return out, nil
+}
+func type_11687433542174887081_ToNu(v dto.CacheStatus) (out nu.Value, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("dto.CacheStatus: %w", err)
+		}
+	}()
+	rec := nu.Record{}
+	rec["path"], err = type_15613163272824911089_ToNu(v.Path)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["size"], err = type_15139881813094606131_ToNu(v.Size)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["calendars"], err = type_15139881813094606131_ToNu(v.Calendars)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["objects"], err = type_15139881813094606131_ToNu(v.Objects)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["pending_operations"], err = type_15139881813094606131_ToNu(v.PendingOperations)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	return nu.Value{Value: rec}, nil
+}
+
+var type_7165613080059059381 = types.Table(type_8566253214031616901)
+
+func type_7165613080059059381_FromNu(v nu.Value) (out dto.HoursReportList, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("dto.HoursReportList: %w", err)
+		}
+	}()
+	if v.Value == nil {
+		return nil, nil
+	}
+	arr, ok := v.Value.([]nu.Value)
+	if !ok {
+		return nil, fmt.Errorf("expected []nu.Value got %T", v.Value)
+	}
+	out = make(dto.HoursReportList, len(arr))
+	for i, e := range arr {
+		out[i], err = type_8566253214031616901_FromNu(e)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+func type_7165613080059059381_ToNu(v dto.HoursReportList) (out nu.Value, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("dto.HoursReportList: %w", err)
+		}
+	}()
+	list := make([]nu.Value, len(v))
+	for i, e := range v {
+		list[i], err = type_8566253214031616901_ToNu(e)
+		if err != nil {
+			return nu.Value{}, err
+		}
+	}
+	return nu.Value{Value: list}, nil
+}
+
+var type_16843359552575150564 = types.Table(type_4541999656362150689)
+
+func type_16843359552575150564_FromNu(v nu.Value) (out dto.SyncFailureList, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("dto.SyncFailureList: %w", err)
+		}
+	}()
+	if v.Value == nil {
+		return nil, nil
+	}
+	arr, ok := v.Value.([]nu.Value)
+	if !ok {
+		return nil, fmt.Errorf("expected []nu.Value got %T", v.Value)
+	}
+	out = make(dto.SyncFailureList, len(arr))
+	for i, e := range arr {
+		out[i], err = type_4541999656362150689_FromNu(e)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+func type_16843359552575150564_ToNu(v dto.SyncFailureList) (out nu.Value, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("dto.SyncFailureList: %w", err)
+		}
+	}()
+	list := make([]nu.Value, len(v))
+	for i, e := range v {
+		list[i], err = type_4541999656362150689_ToNu(e)
+		if err != nil {
+			return nu.Value{}, err
+		}
+	}
+	return nu.Value{Value: list}, nil
+}
+
+var type_15613163272824911089 = types.String()
+
+func type_15613163272824911089_FromNu(v nu.Value) (out string, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("string: %w", err)
+		}
+	}()
+	casted, ok := v.Value.(string)
+	converted := string(casted)
+	if !ok {
+		return converted, fmt.Errorf("expected string got %v", v.Value)
+	}
+	return converted, nil
+}
+func type_15613163272824911089_ToNu(v string) (out nu.Value, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("string: %w", err)
+		}
+	}()
+	return nu.ToValue(v), nil
+}
+
+var type_8047992331715851194 = types.Date()
+
+func type_8047992331715851194_FromNu(v nu.Value) (out time.Time, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("time.Time: %w", err)
+		}
+	}()
+	out, ok := v.Value.(time.Time)
+	if !ok {
+		return out, fmt.Errorf("expected time.Time got %T", v.Value)
+	}
+	return
+}
+func type_8047992331715851194_ToNu(v time.Time) (out nu.Value, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("time.Time: %w", err)
+		}
+	}()
+	return nu.ToValue(v), nil
+}
+
+var type_16589689216511618220 = types.Duration()
+
+func type_16589689216511618220_FromNu(v nu.Value) (out time.Duration, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("time.Duration: %w", err)
+		}
+	}()
+	out, ok := v.Value.(time.Duration)
+	if !ok {
+		return out, fmt.Errorf("expected time.Duration got %T", v.Value)
+	}
+	return
+}
+func type_16589689216511618220_ToNu(v time.Duration) (out nu.Value, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("time.Duration: %w", err)
+		}
+	}()
+	return nu.ToValue(v), nil
+}
+
+var type_12588128689068210979 = types.Table(type_15963329845892192617)
+
+func type_12588128689068210979_FromNu(v nu.Value) (out []dto.PropValueDto, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("[]dto.PropValueDto: %w", err)
+		}
+	}()
+	if v.Value == nil {
+		return nil, nil
+	}
+	arr, ok := v.Value.([]nu.Value)
+	if !ok {
+		return nil, fmt.Errorf("expected []nu.Value got %T", v.Value)
+	}
+	out = make([]dto.PropValueDto, len(arr))
+	for i, e := range arr {
+		out[i], err = type_15963329845892192617_FromNu(e)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+func type_12588128689068210979_ToNu(v []dto.PropValueDto) (out nu.Value, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("[]dto.PropValueDto: %w", err)
+		}
+	}()
+	list := make([]nu.Value, len(v))
+	for i, e := range v {
+		list[i], err = type_15963329845892192617_ToNu(e)
+		if err != nil {
+			return nu.Value{}, err
+		}
+	}
+	return nu.Value{Value: list}, nil
+}
+
+var type_18439826349963270388 = types.RecordDef{
+	"object_path":    type_17862013815172309399,
+	"calendar_path":  type_17862013815172309399,
+	"calendar_name":  type_17862013815172309399,
+	"calendar_color": type_17862013815172309399,
+	"main":           types.Record(type_8814170927480347350),
+	"overrides":      type_601306316528950762,
+}
+
+func type_18439826349963270388_FromNu(v nu.Value) (out dto.EventObject, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("dto.EventObject: %w", err)
+		}
+	}()
+	record, ok := v.Value.(nu.Record)
+	if !ok {
+		return out, fmt.Errorf("expected nu.Record got %T", v.Value)
+	}
+	var val nu.Value
+	val, _ = record["object_path"]
+	out.ObjectPath, err = type_17862013815172309399_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["calendar_path"]
+	out.CalendarPath, err = type_17862013815172309399_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["calendar_name"]
+	out.CalendarName, err = type_17862013815172309399_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["calendar_color"]
+	out.CalendarColor, err = type_17862013815172309399_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["main"]
+	out.Main, err = type_8814170927480347350_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["overrides"]
+	out.Overrides, err = type_601306316528950762_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	return out, nil
+}
+func type_18439826349963270388_ToNu(v dto.EventObject) (out nu.Value, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("dto.EventObject: %w", err)
+		}
+	}()
+	rec := nu.Record{}
+	rec["object_path"], err = type_17862013815172309399_ToNu(v.ObjectPath)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["calendar_path"], err = type_17862013815172309399_ToNu(v.CalendarPath)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["calendar_name"], err = type_17862013815172309399_ToNu(v.CalendarName)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["calendar_color"], err = type_17862013815172309399_ToNu(v.CalendarColor)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["main"], err = type_8814170927480347350_ToNu(v.Main)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["overrides"], err = type_601306316528950762_ToNu(v.Overrides)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	return nu.Value{Value: rec}, nil
+}
+
+var type_10491132004141824964 = types.Table(type_6453951478216933494)
+
+func type_10491132004141824964_FromNu(v nu.Value) (out []dto.Occurrence, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("[]dto.Occurrence: %w", err)
+		}
+	}()
+	if v.Value == nil {
+		return nil, nil
+	}
+	arr, ok := v.Value.([]nu.Value)
+	if !ok {
+		return nil, fmt.Errorf("expected []nu.Value got %T", v.Value)
+	}
+	out = make([]dto.Occurrence, len(arr))
+	for i, e := range arr {
+		out[i], err = type_6453951478216933494_FromNu(e)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+func type_10491132004141824964_ToNu(v []dto.Occurrence) (out nu.Value, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("[]dto.Occurrence: %w", err)
+		}
+	}()
+	list := make([]nu.Value, len(v))
+	for i, e := range v {
+		list[i], err = type_6453951478216933494_ToNu(e)
+		if err != nil {
+			return nu.Value{}, err
+		}
+	}
+	return nu.Value{Value: list}, nil
+}
+
+var type_681218608244565547 = types.RecordDef{
+	"time":        type_15613163272824911089,
+	"all_day":     type_729807561129781588,
+	"summary":     type_17862013815172309399,
+	"location":    type_17862013815172309399,
+	"calendar":    type_17862013815172309399,
+	"start":       type_8047992331715851194,
+	"end":         type_8047992331715851194,
+	"object_path": type_17862013815172309399,
+}
+
+func type_681218608244565547_FromNu(v nu.Value) (out dto.AgendaEntry, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("dto.AgendaEntry: %w", err)
+		}
+	}()
+	record, ok := v.Value.(nu.Record)
+	if !ok {
+		return out, fmt.Errorf("expected nu.Record got %T", v.Value)
+	}
+	var val nu.Value
+	val, ok = record["time"]
+	if !ok {
+		out.Time = ""
+	} else {
+		out.Time, err = type_15613163272824911089_FromNu(val)
+		if err != nil {
+			return out, err
+		}
+	}
+	val, ok = record["all_day"]
+	if !ok {
+		out.AllDay = false
+	} else {
+		out.AllDay, err = type_729807561129781588_FromNu(val)
+		if err != nil {
+			return out, err
+		}
+	}
+	val, _ = record["summary"]
+	out.Summary, err = type_17862013815172309399_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["location"]
+	out.Location, err = type_17862013815172309399_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["calendar"]
+	out.Calendar, err = type_17862013815172309399_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["start"]
+	out.Start, err = type_8047992331715851194_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["end"]
+	out.End, err = type_8047992331715851194_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["object_path"]
+	out.ObjectPath, err = type_17862013815172309399_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	return out, nil
+}
+func type_681218608244565547_ToNu(v dto.AgendaEntry) (out nu.Value, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("dto.AgendaEntry: %w", err)
+		}
+	}()
+	rec := nu.Record{}
+	rec["time"], err = type_15613163272824911089_ToNu(v.Time)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["all_day"], err = type_729807561129781588_ToNu(v.AllDay)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["summary"], err = type_17862013815172309399_ToNu(v.Summary)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["location"], err = type_17862013815172309399_ToNu(v.Location)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["calendar"], err = type_17862013815172309399_ToNu(v.Calendar)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["start"], err = type_8047992331715851194_ToNu(v.Start)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["end"], err = type_8047992331715851194_ToNu(v.End)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["object_path"], err = type_17862013815172309399_ToNu(v.ObjectPath)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	return nu.Value{Value: rec}, nil
+}
+
+var type_12446113380399364270 = types.Table(type_18001548242738951129)
+
+func type_12446113380399364270_FromNu(v nu.Value) (out dto.Agenda, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("dto.Agenda: %w", err)
+		}
+	}()
+	if v.Value == nil {
+		return nil, nil
+	}
+	arr, ok := v.Value.([]nu.Value)
+	if !ok {
+		return nil, fmt.Errorf("expected []nu.Value got %T", v.Value)
+	}
+	out = make(dto.Agenda, len(arr))
+	for i, e := range arr {
+		out[i], err = type_18001548242738951129_FromNu(e)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+func type_12446113380399364270_ToNu(v dto.Agenda) (out nu.Value, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("dto.Agenda: %w", err)
+		}
+	}()
+	list := make([]nu.Value, len(v))
+	for i, e := range v {
+		list[i], err = type_18001548242738951129_ToNu(e)
+		if err != nil {
+			return nu.Value{}, err
+		}
+	}
+	return nu.Value{Value: list}, nil
+}
+
+var type_7057708295081751301 = types.String()
+
+func type_7057708295081751301_FromNu(v nu.Value) (out events.EventTransparency, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("events.EventTransparency: %w", err)
+		}
+	}()
+	casted, ok := v.Value.(string)
+	converted := events.EventTransparency(casted)
+	if !ok {
+		return converted, fmt.Errorf("expected string got %v", v.Value)
+	}
+	return converted, nil
+}
+func type_7057708295081751301_ToNu(v events.EventTransparency) (out nu.Value, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("events.EventTransparency: %w", err)
+		}
+	}()
+	return nu.ToValue(v), nil
+}
+
+var type_11305088692878341573 = types.Table(type_11123159514645021831)
+
+func type_11305088692878341573_FromNu(v nu.Value) (out []events.Period, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("[]events.Period: %w", err)
+		}
+	}()
+	if v.Value == nil {
+		return nil, nil
+	}
+	arr, ok := v.Value.([]nu.Value)
+	if !ok {
+		return nil, fmt.Errorf("expected []nu.Value got %T", v.Value)
+	}
+	out = make([]events.Period, len(arr))
+	for i, e := range arr {
+		out[i], err = type_11123159514645021831_FromNu(e)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+func type_11305088692878341573_ToNu(v []events.Period) (out nu.Value, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("[]events.Period: %w", err)
+		}
+	}()
+	list := make([]nu.Value, len(v))
+	for i, e := range v {
+		list[i], err = type_11123159514645021831_ToNu(e)
+		if err != nil {
+			return nu.Value{}, err
+		}
+	}
+	return nu.Value{Value: list}, nil
+}
+
+var type_15560982419391353847 = types.Int()
+
+func type_15560982419391353847_FromNu(v nu.Value) (out events.EventTriggerRelative, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("events.EventTriggerRelative: %w", err)
+		}
+	}()
+	casted, ok := v.Value.(int64)
+	converted := events.EventTriggerRelative(casted)
+	if !ok {
+		return converted, fmt.Errorf("expected int64 got %v", v.Value)
+	}
+	return converted, nil
+}
+func type_15560982419391353847_ToNu(v events.EventTriggerRelative) (out nu.Value, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("events.EventTriggerRelative: %w", err)
+		}
+	}()
+	return nu.ToValue(v), nil
+}
+
+var type_1466475515312567685 = types.RecordDef{
+	"path":                    type_15613163272824911089,
+	"name":                    type_15613163272824911089,
+	"description":             type_15613163272824911089,
+	"color":                   type_17862013815172309399,
+	"order":                   type_2584899110032584934,
+	"timezone":                type_17862013815172309399,
+	"max_resource_size":       type_15139881813094606131,
+	"supported_component_set": type_11669970230249425419,
+	"c_tag":                   type_17862013815172309399,
+	"sync_token":              type_17862013815172309399,
+	"privileges":              type_11669970230249425419,
+	"owner":                   type_17862013815172309399,
+	"resource_types":          type_11669970230249425419,
+}
+
+func type_1466475515312567685_FromNu(v nu.Value) (out dto.Calendar, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("dto.Calendar: %w", err)
+		}
+	}()
+	record, ok := v.Value.(nu.Record)
+	if !ok {
+		return out, fmt.Errorf("expected nu.Record got %T", v.Value)
+	}
+	var val nu.Value
+	val, _ = record["path"]
+	out.Path, err = type_15613163272824911089_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["name"]
+	out.Name, err = type_15613163272824911089_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["description"]
+	out.Description, err = type_15613163272824911089_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["color"]
+	out.Color, err = type_17862013815172309399_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["order"]
+	out.Order, err = type_2584899110032584934_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["timezone"]
+	out.Timezone, err = type_17862013815172309399_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["max_resource_size"]
+	out.MaxResourceSize, err = type_15139881813094606131_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["supported_component_set"]
+	out.SupportedComponentSet, err = type_11669970230249425419_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["c_tag"]
+	out.CTag, err = type_17862013815172309399_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["sync_token"]
+	out.SyncToken, err = type_17862013815172309399_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["privileges"]
+	out.Privileges, err = type_11669970230249425419_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["owner"]
+	out.Owner, err = type_17862013815172309399_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["resource_types"]
+	out.ResourceTypes, err = type_11669970230249425419_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	return out, nil
+}
+func type_1466475515312567685_ToNu(v dto.Calendar) (out nu.Value, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("dto.Calendar: %w", err)
+		}
+	}()
+	rec := nu.Record{}
+	rec["path"], err = type_15613163272824911089_ToNu(v.Path)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["name"], err = type_15613163272824911089_ToNu(v.Name)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["description"], err = type_15613163272824911089_ToNu(v.Description)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["color"], err = type_17862013815172309399_ToNu(v.Color)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["order"], err = type_2584899110032584934_ToNu(v.Order)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["timezone"], err = type_17862013815172309399_ToNu(v.Timezone)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["max_resource_size"], err = type_15139881813094606131_ToNu(v.MaxResourceSize)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["supported_component_set"], err = type_11669970230249425419_ToNu(v.SupportedComponentSet)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["c_tag"], err = type_17862013815172309399_ToNu(v.CTag)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["sync_token"], err = type_17862013815172309399_ToNu(v.SyncToken)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["privileges"], err = type_11669970230249425419_ToNu(v.Privileges)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["owner"], err = type_17862013815172309399_ToNu(v.Owner)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["resource_types"], err = type_11669970230249425419_ToNu(v.ResourceTypes)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	return nu.Value{Value: rec}, nil
+}
+
+var type_606227063665950724 = types.RecordDef{
+	"principal":         type_15613163272824911089,
+	"name":              type_17862013815172309399,
+	"source":            type_15613163272824911089,
+	"privileges":        type_11669970230249425419,
+	"denied_privileges": type_11669970230249425419,
+	"status":            type_17862013815172309399,
+	"protected":         type_729807561129781588,
+	"inherited":         type_17862013815172309399,
+}
+
+func type_606227063665950724_FromNu(v nu.Value) (out dto.AccessEntry, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("dto.AccessEntry: %w", err)
+		}
+	}()
+	record, ok := v.Value.(nu.Record)
+	if !ok {
+		return out, fmt.Errorf("expected nu.Record got %T", v.Value)
+	}
+	var val nu.Value
+	val, _ = record["principal"]
+	out.Principal, err = type_15613163272824911089_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["name"]
+	out.Name, err = type_17862013815172309399_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["source"]
+	out.Source, err = type_15613163272824911089_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["privileges"]
+	out.Privileges, err = type_11669970230249425419_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["denied_privileges"]
+	out.DeniedPrivileges, err = type_11669970230249425419_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["status"]
+	out.Status, err = type_17862013815172309399_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, ok = record["protected"]
+	if !ok {
+		out.Protected = false
+	} else {
+		out.Protected, err = type_729807561129781588_FromNu(val)
+		if err != nil {
+			return out, err
+		}
+	}
+	val, _ = record["inherited"]
+	out.Inherited, err = type_17862013815172309399_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	return out, nil
+}
+func type_606227063665950724_ToNu(v dto.AccessEntry) (out nu.Value, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("dto.AccessEntry: %w", err)
+		}
+	}()
+	rec := nu.Record{}
+	rec["principal"], err = type_15613163272824911089_ToNu(v.Principal)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["name"], err = type_17862013815172309399_ToNu(v.Name)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["source"], err = type_15613163272824911089_ToNu(v.Source)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["privileges"], err = type_11669970230249425419_ToNu(v.Privileges)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["denied_privileges"], err = type_11669970230249425419_ToNu(v.DeniedPrivileges)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["status"], err = type_17862013815172309399_ToNu(v.Status)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["protected"], err = type_729807561129781588_ToNu(v.Protected)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["inherited"], err = type_17862013815172309399_ToNu(v.Inherited)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	return nu.Value{Value: rec}, nil
+}
+
+var type_7163250051298988498 = types.Record(type_7161572108068222122)
+
+func type_7163250051298988498_FromNu(v nu.Value) (out *events.EventGeo, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("*events.EventGeo: %w", err)
+		}
+	}()
+	if v.Value == nil {
+		return nil, nil
+	}
+	res, err := type_7161572108068222122_FromNu(v)
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+func type_7163250051298988498_ToNu(v *events.EventGeo) (out nu.Value, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("*events.EventGeo: %w", err)
+		}
+	}()
+	if v == nil {
+		return nu.Value{}, nil
+	}
+	return type_7161572108068222122_ToNu(*v)
+}
+
+var type_11123159514645021831 = types.RecordDef{
+	"start": types.Record(type_5454485661162817076),
+	"end":   types.Record(type_5454485661162817076),
+}
+
+func type_11123159514645021831_FromNu(v nu.Value) (out events.Period, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("events.Period: %w", err)
+		}
+	}()
+	record, ok := v.Value.(nu.Record)
+	if !ok {
+		return out, fmt.Errorf("expected nu.Record got %T", v.Value)
+	}
+	var val nu.Value
+	val, _ = record["start"]
+	out.Start, err = type_5454485661162817076_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["end"]
+	out.End, err = type_5454485661162817076_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	return out, nil
+}
+func type_11123159514645021831_ToNu(v events.Period) (out nu.Value, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("events.Period: %w", err)
+		}
+	}()
+	rec := nu.Record{}
+	rec["start"], err = type_5454485661162817076_ToNu(v.Start)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["end"], err = type_5454485661162817076_ToNu(v.End)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	return nu.Value{Value: rec}, nil
+}
+
+var type_601306316528950762 = types.Table(type_8814170927480347350)
+
+func type_601306316528950762_FromNu(v nu.Value) (out []dto.Event, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("[]dto.Event: %w", err)
+		}
+	}()
+	if v.Value == nil {
+		return nil, nil
+	}
+	arr, ok := v.Value.([]nu.Value)
+	if !ok {
+		return nil, fmt.Errorf("expected []nu.Value got %T", v.Value)
+	}
+	out = make([]dto.Event, len(arr))
+	for i, e := range arr {
+		out[i], err = type_8814170927480347350_FromNu(e)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+func type_601306316528950762_ToNu(v []dto.Event) (out nu.Value, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("[]dto.Event: %w", err)
+		}
+	}()
+	list := make([]nu.Value, len(v))
+	for i, e := range v {
+		list[i], err = type_8814170927480347350_ToNu(e)
+		if err != nil {
+			return nu.Value{}, err
+		}
+	}
+	return nu.Value{Value: list}, nil
+}
+
+var type_2584899110032584934 = type_10890016574791629639
+
+func type_2584899110032584934_FromNu(v nu.Value) (out *int, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("*int: %w", err)
+		}
+	}()
+	if v.Value == nil {
+		return nil, nil
+	}
+	res, err := type_10890016574791629639_FromNu(v)
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+func type_2584899110032584934_ToNu(v *int) (out nu.Value, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("*int: %w", err)
+		}
+	}()
+	if v == nil {
+		return nu.Value{}, nil
+	}
+	return type_10890016574791629639_ToNu(*v)
+}
+
+var type_12604977785371100614 = types.Any()
+
+func type_12604977785371100614_FromNu(v nu.Value) (out map[string][]dto.PropValueDto, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("map[string][]dto.PropValueDto: %w", err)
+		}
+	}()
+	if v.Value == nil {
+		return nil, nil
+	}
+	dict, ok := v.Value.(nu.Record)
+	if !ok {
+		return nil, fmt.Errorf("expected nu.Record got %T", v.Value)
+	}
+	out = make(map[string][]dto.PropValueDto, len(dict))
+	for k, v := range dict {
+		out[k], err = type_12588128689068210979_FromNu(v)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+func type_12604977785371100614_ToNu(v map[string][]dto.PropValueDto) (out nu.Value, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("map[string][]dto.PropValueDto: %w", err)
+		}
+	}()
+	dict := make(nu.Record, len(v))
+	for k, v := range v {
+		dict[k], err = type_12588128689068210979_ToNu(v)
+		if err != nil {
+			return nu.Value{}, err
+		}
+	}
+	return nu.Value{Value: dict}, nil
+}
+
+var type_8814170927480347350 = types.RecordDef{
+	"kind":                       type_15613163272824911089,
+	"uid":                        type_17862013815172309399,
+	"summary":                    type_17862013815172309399,
+	"location":                   type_17862013815172309399,
+	"description":                type_17862013815172309399,
+	"categories":                 type_11669970230249425419,
+	"datetime_stamp":             type_12480522309550428545,
+	"created":                    type_12480522309550428545,
+	"last_modified":              type_12480522309550428545,
+	"class":                      type_9664538759823739797,
+	"geo":                        type_7163250051298988498,
+	"priority":                   type_2584899110032584934,
+	"sequence":                   type_2584899110032584934,
+	"status":                     type_784588192188755836,
+	"transparency":               type_8971279483973357571,
+	"url":                        type_5363327835607766502,
+	"comment":                    type_17862013815172309399,
+	"attach":                     type_5363327835607766502,
+	"contact":                    type_17862013815172309399,
+	"organizer":                  type_5363327835607766502,
+	"start":                      types.Record(type_5454485661162817076),
+	"end":                        types.Record(type_5454485661162817076),
+	"duration":                   type_5863190983406162214,
+	"due":                        type_12480522309550428545,
+	"start_from_due":             type_729807561129781588,
+	"recurrence_rule":            type_7406295723486674371,
+	"extra_recurrence_rules":     type_9238984578611918813,
+	"exception_rules":            type_9238984578611918813,
+	"recurrence_dates":           type_3931126380996215332,
+	"recurrence_periods":         type_11305088692878341573,
+	"recurrence_exception_dates": type_3931126380996215332,
+	"recurrence_instance":        type_12480522309550428545,
+	"this_and_future":            type_729807561129781588,
+	"trigger":                    type_9520111014888170891,
+	"other":                      type_12604977785371100614,
+}
+
+func type_8814170927480347350_FromNu(v nu.Value) (out dto.Event, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("dto.Event: %w", err)
+		}
+	}()
+	record, ok := v.Value.(nu.Record)
+	if !ok {
+		return out, fmt.Errorf("expected nu.Record got %T", v.Value)
+	}
+	var val nu.Value
+	val, ok = record["kind"]
+	if !ok {
+		out.Kind = "VEVENT"
+	} else {
+		out.Kind, err = type_15613163272824911089_FromNu(val)
+		if err != nil {
+			return out, err
+		}
+	}
+	val, _ = record["uid"]
+	out.Uid, err = type_17862013815172309399_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["summary"]
+	out.Summary, err = type_17862013815172309399_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["location"]
+	out.Location, err = type_17862013815172309399_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["description"]
+	out.Description, err = type_17862013815172309399_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["categories"]
+	out.Categories, err = type_11669970230249425419_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["datetime_stamp"]
+	out.DatetimeStamp, err = type_12480522309550428545_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["created"]
+	out.Created, err = type_12480522309550428545_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["last_modified"]
+	out.LastModified, err = type_12480522309550428545_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["class"]
+	out.Class, err = type_9664538759823739797_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["geo"]
+	out.Geo, err = type_7163250051298988498_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["priority"]
+	out.Priority, err = type_2584899110032584934_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["sequence"]
+	out.Sequence, err = type_2584899110032584934_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["status"]
+	out.Status, err = type_784588192188755836_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["transparency"]
+	out.Transparency, err = type_8971279483973357571_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["url"]
+	out.URL, err = type_5363327835607766502_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["comment"]
+	out.Comment, err = type_17862013815172309399_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["attach"]
+	out.Attach, err = type_5363327835607766502_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["contact"]
+	out.Contact, err = type_17862013815172309399_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["organizer"]
+	out.Organizer, err = type_5363327835607766502_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["start"]
+	out.Start, err = type_5454485661162817076_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["end"]
+	out.End, err = type_5454485661162817076_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["duration"]
+	out.Duration, err = type_5863190983406162214_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["due"]
+	out.Due, err = type_12480522309550428545_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, ok = record["start_from_due"]
+	if !ok {
+		out.StartFromDue = false
+	} else {
+		out.StartFromDue, err = type_729807561129781588_FromNu(val)
+		if err != nil {
+			return out, err
+		}
+	}
+	val, _ = record["recurrence_rule"]
+	out.RecurrenceRule, err = type_7406295723486674371_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["extra_recurrence_rules"]
+	out.ExtraRecurrenceRules, err = type_9238984578611918813_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["exception_rules"]
+	out.ExceptionRules, err = type_9238984578611918813_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["recurrence_dates"]
+	out.RecurrenceDates, err = type_3931126380996215332_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["recurrence_periods"]
+	out.RecurrencePeriods, err = type_11305088692878341573_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["recurrence_exception_dates"]
+	out.RecurrenceExceptionDates, err = type_3931126380996215332_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["recurrence_instance"]
+	out.RecurrenceInstance, err = type_12480522309550428545_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, ok = record["this_and_future"]
+	if !ok {
+		out.ThisAndFuture = false
+	} else {
+		out.ThisAndFuture, err = type_729807561129781588_FromNu(val)
+		if err != nil {
+			return out, err
+		}
+	}
+	val, _ = record["trigger"]
+	out.Trigger, err = type_9520111014888170891_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["other"]
+	out.Other, err = type_12604977785371100614_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	return out, nil
+}
+func type_8814170927480347350_ToNu(v dto.Event) (out nu.Value, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("dto.Event: %w", err)
+		}
+	}()
+	rec := nu.Record{}
+	rec["kind"], err = type_15613163272824911089_ToNu(v.Kind)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["uid"], err = type_17862013815172309399_ToNu(v.Uid)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["summary"], err = type_17862013815172309399_ToNu(v.Summary)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["location"], err = type_17862013815172309399_ToNu(v.Location)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["description"], err = type_17862013815172309399_ToNu(v.Description)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["categories"], err = type_11669970230249425419_ToNu(v.Categories)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["datetime_stamp"], err = type_12480522309550428545_ToNu(v.DatetimeStamp)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["created"], err = type_12480522309550428545_ToNu(v.Created)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["last_modified"], err = type_12480522309550428545_ToNu(v.LastModified)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["class"], err = type_9664538759823739797_ToNu(v.Class)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["geo"], err = type_7163250051298988498_ToNu(v.Geo)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["priority"], err = type_2584899110032584934_ToNu(v.Priority)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["sequence"], err = type_2584899110032584934_ToNu(v.Sequence)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["status"], err = type_784588192188755836_ToNu(v.Status)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["transparency"], err = type_8971279483973357571_ToNu(v.Transparency)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["url"], err = type_5363327835607766502_ToNu(v.URL)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["comment"], err = type_17862013815172309399_ToNu(v.Comment)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["attach"], err = type_5363327835607766502_ToNu(v.Attach)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["contact"], err = type_17862013815172309399_ToNu(v.Contact)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["organizer"], err = type_5363327835607766502_ToNu(v.Organizer)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["start"], err = type_5454485661162817076_ToNu(v.Start)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["end"], err = type_5454485661162817076_ToNu(v.End)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["duration"], err = type_5863190983406162214_ToNu(v.Duration)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["due"], err = type_12480522309550428545_ToNu(v.Due)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["start_from_due"], err = type_729807561129781588_ToNu(v.StartFromDue)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["recurrence_rule"], err = type_7406295723486674371_ToNu(v.RecurrenceRule)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["extra_recurrence_rules"], err = type_9238984578611918813_ToNu(v.ExtraRecurrenceRules)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["exception_rules"], err = type_9238984578611918813_ToNu(v.ExceptionRules)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["recurrence_dates"], err = type_3931126380996215332_ToNu(v.RecurrenceDates)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["recurrence_periods"], err = type_11305088692878341573_ToNu(v.RecurrencePeriods)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["recurrence_exception_dates"], err = type_3931126380996215332_ToNu(v.RecurrenceExceptionDates)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["recurrence_instance"], err = type_12480522309550428545_ToNu(v.RecurrenceInstance)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["this_and_future"], err = type_729807561129781588_ToNu(v.ThisAndFuture)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["trigger"], err = type_9520111014888170891_ToNu(v.Trigger)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["other"], err = type_12604977785371100614_ToNu(v.Other)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	return nu.Value{Value: rec}, nil
+}
+
+var type_9664538759823739797 = type_2493169154543297135
+
+func type_9664538759823739797_FromNu(v nu.Value) (out *events.EventClass, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("*events.EventClass: %w", err)
 		}
 	}()
-	casted, ok := v.Value.(string)
-	converted := events.EventTransparency(casted)
-	if !ok {
-		return converted, fmt.Errorf("expected string got %v", v.Value)
+	if v.Value == nil {
+		return nil, nil
 	}
-	return converted, nil
+	res, err := type_2493169154543297135_FromNu(v)
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
 }
-func type_7057708295081751301_ToNu(v events.EventTransparency) (out nu.Value, err error) {
+func type_9664538759823739797_ToNu(v *events.EventClass) (out nu.Value, err error) {
 	defer func() {
 		if err != nil {
-			err = fmt.Errorf("events.EventTransparency: %w", err)
+			err = fmt.Errorf("*events.EventClass: %w", err)
 		}
 	}()
-	return nu.ToValue(v), nil
+	if v == nil {
+		return nu.Value{}, nil
+	}
+	return type_2493169154543297135_ToNu(*v)
 }
 
-var type_15050730807189225719 = type_8047992331715851194
+var type_5363327835607766502 = types.String()
 
-func type_15050730807189225719_FromNu(v nu.Value) (out *time.Time, err error) {
+func type_5363327835607766502_FromNu(v nu.Value) (out *url.URL, err error) {
 	defer func() {
 		if err != nil {
-			err = fmt.Errorf("*time.Time: %w", err)
+			err = fmt.Errorf("*url.URL: %w", err)
 		}
 	}()
 	if v.Value == nil {
 		return nil, nil
 	}
-	res, err := type_8047992331715851194_FromNu(v)
+	parsed, err := url.Parse(v.Value.(string))
 	if err != nil {
 		return nil, err
 	}
-	return &res, nil
+	return parsed, nil
 }
-func type_15050730807189225719_ToNu(v *time.Time) (out nu.Value, err error) {
+func type_5363327835607766502_ToNu(v *url.URL) (out nu.Value, err error) {
 	defer func() {
 		if err != nil {
-			err = fmt.Errorf("*time.Time: %w", err)
+			err = fmt.Errorf("*url.URL: %w", err)
 		}
 	}()
 	if v == nil {
-		return nu.Value{}, nil
+		return nu.Value{Value: nil}, nil
 	}
-	return type_8047992331715851194_ToNu(*v)
+	return nu.ToValue(v.String()), nil
 }
 
-var type_13545470577293064413 = types.RecordDef{
-	"relative":    type_5863190983406162214,
-	"relative_to": type_15560982419391353847,
-	"absolute":    type_15050730807189225719,
+var type_8566253214031616901 = types.RecordDef{
+	"group":   type_15613163272824911089,
+	"total":   type_16589689216511618220,
+	"periods": type_11848278600427152947,
 }
 
-func type_13545470577293064413_FromNu(v nu.Value) (out events.EventTrigger, err error) {
+func type_8566253214031616901_FromNu(v nu.Value) (out dto.HoursReport, err error) {
 	defer func() {
 		if err != nil {
-			err = fmt.Errorf("events.EventTrigger: %w", err)
+			err = fmt.Errorf("dto.HoursReport: %w", err)
 		}
 	}()
 	record, ok := v.Value.(nu.Record)
@@ -1048,51 +3193,55 @@ func type_13545470577293064413_FromNu(v nu.Value) (out events.EventTrigger, err
 		return out, fmt.Errorf("expected nu.Record got %T", v.Value)
 	}
 	var val nu.Value
-	val, _ = record["relative"]
-	out.Relative, err = type_5863190983406162214_FromNu(val)
-	if err != nil {
-		return out, err
+	val, ok = record["group"]
+	if !ok {
+		out.Group = ""
+	} else {
+		out.Group, err = type_15613163272824911089_FromNu(val)
+		if err != nil {
+			return out, err
+		}
 	}
-	val, _ = record["relative_to"]
-	out.RelativeTo, err = type_15560982419391353847_FromNu(val)
+	val, _ = record["total"]
+	out.Total, err = type_16589689216511618220_FromNu(val)
 	if err != nil {
 		return out, err
 	}
-	val, _ = record["absolute"]
-	out.Absolute, err = type_15050730807189225719_FromNu(val)
+	val, _ = record["periods"]
+	out.Periods, err = type_11848278600427152947_FromNu(val)
 	if err != nil {
 		return out, err
 	}
 	return out, nil
 }
-func type_13545470577293064413_ToNu(v events.EventTrigger) (out nu.Value, err error) {
+func type_8566253214031616901_ToNu(v dto.HoursReport) (out nu.Value, err error) {
 	defer func() {
 		if err != nil {
-			err = fmt.Errorf("events.EventTrigger: %w", err)
+			err = fmt.Errorf("dto.HoursReport: %w", err)
 		}
 	}()
 	rec := nu.Record{}
-	rec["relative"], err = type_5863190983406162214_ToNu(v.Relative)
+	rec["group"], err = type_15613163272824911089_ToNu(v.Group)
 	if err != nil {
 		return nu.Value{}, err
 	}
-	rec["relative_to"], err = type_15560982419391353847_ToNu(v.RelativeTo)
+	rec["total"], err = type_16589689216511618220_ToNu(v.Total)
 	if err != nil {
 		return nu.Value{}, err
 	}
-	rec["absolute"], err = type_15050730807189225719_ToNu(v.Absolute)
+	rec["periods"], err = type_11848278600427152947_ToNu(v.Periods)
 	if err != nil {
 		return nu.Value{}, err
 	}
 	return nu.Value{Value: rec}, nil
 }
 
-var type_601306316528950762 = types.Table(type_8814170927480347350)
+var type_18413834526742637396 = types.Table(type_4819107696191819573)
 
-func type_601306316528950762_FromNu(v nu.Value) (out []dto.Event, err error) {
+func type_18413834526742637396_FromNu(v nu.Value) (out dto.CachedCalendarList, err error) {
 	defer func() {
 		if err != nil {
-			err = fmt.Errorf("[]dto.Event: %w", err)
+			err = fmt.Errorf("dto.CachedCalendarList: %w", err)
 		}
 	}()
 	if v.Value == nil {
@@ -1102,24 +3251,24 @@ func type_601306316528950762_FromNu(v nu.Value) (out []dto.Event, err error) {
 	if !ok {
 		return nil, fmt.Errorf("expected []nu.Value got %T", v.Value)
 	}
-	out = make([]dto.Event, len(arr))
+	out = make(dto.CachedCalendarList, len(arr))
 	for i, e := range arr {
-		out[i], err = type_8814170927480347350_FromNu(e)
+		out[i], err = type_4819107696191819573_FromNu(e)
 		if err != nil {
 			return nil, err
 		}
 	}
 	return out, nil
 }
-func type_601306316528950762_ToNu(v []dto.Event) (out nu.Value, err error) {
+func type_18413834526742637396_ToNu(v dto.CachedCalendarList) (out nu.Value, err error) {
 	defer func() {
 		if err != nil {
-			err = fmt.Errorf("[]dto.Event: %w", err)
+			err = fmt.Errorf("dto.CachedCalendarList: %w", err)
 		}
 	}()
 	list := make([]nu.Value, len(v))
 	for i, e := range v {
-		list[i], err = type_8814170927480347350_ToNu(e)
+		list[i], err = type_4819107696191819573_ToNu(e)
 		if err != nil {
 			return nu.Value{}, err
 		}
@@ -1127,143 +3276,50 @@ func type_601306316528950762_ToNu(v []dto.Event) (out nu.Value, err error) {
 	return nu.Value{Value: list}, nil
 }
 
-var type_8047992331715851194 = types.Date()
-
-func type_8047992331715851194_FromNu(v nu.Value) (out time.Time, err error) {
-	defer func() {
-		if err != nil {
-			err = fmt.Errorf("time.Time: %w", err)
-		}
-	}()
-	out, ok := v.Value.(time.Time)
-	if !ok {
-		return out, fmt.Errorf("expected time.Time got %T", v.Value)
-	}
-	return
-}
-func type_8047992331715851194_ToNu(v time.Time) (out nu.Value, err error) {
-	defer func() {
-		if err != nil {
-			err = fmt.Errorf("time.Time: %w", err)
-		}
-	}()
-	return nu.ToValue(v), nil
+var type_7161572108068222122 = types.RecordDef{
+	"latitude":  type_17860233973098560385,
+	"longitude": type_17860233973098560385,
 }
 
-var type_9664538759823739797 = type_2493169154543297135
-
-func type_9664538759823739797_FromNu(v nu.Value) (out *events.EventClass, err error) {
+func type_7161572108068222122_FromNu(v nu.Value) (out events.EventGeo, err error) {
 	defer func() {
 		if err != nil {
-			err = fmt.Errorf("*events.EventClass: %w", err)
+			err = fmt.Errorf("events.EventGeo: %w", err)
 		}
 	}()
-	if v.Value == nil {
-		return nil, nil
+	record, ok := v.Value.(nu.Record)
+	if !ok {
+		return out, fmt.Errorf("expected nu.Record got %T", v.Value)
 	}
-	res, err := type_2493169154543297135_FromNu(v)
+	var val nu.Value
+	val, _ = record["latitude"]
+	out.Latitude, err = type_17860233973098560385_FromNu(val)
 	if err != nil {
-		return nil, err
-	}
-	return &res, nil
-}
-func type_9664538759823739797_ToNu(v *events.EventClass) (out nu.Value, err error) {
-	defer func() {
-		if err != nil {
-			err = fmt.Errorf("*events.EventClass: %w", err)
-		}
-	}()
-	if v == nil {
-		return nu.Value{}, nil
-	}
-	return type_2493169154543297135_ToNu(*v)
-}
-
-var type_7163250051298988498 = types.Record(type_7161572108068222122)
-
-func type_7163250051298988498_FromNu(v nu.Value) (out *events.EventGeo, err error) {
-	defer func() {
-		if err != nil {
-			err = fmt.Errorf("*events.EventGeo: %w", err)
-		}
-	}()
-	if v.Value == nil {
-		return nil, nil
+		return out, err
 	}
-	res, err := type_7161572108068222122_FromNu(v)
+	val, _ = record["longitude"]
+	out.Longitude, err = type_17860233973098560385_FromNu(val)
 	if err != nil {
-		return nil, err
-	}
-	return &res, nil
-}
-func type_7163250051298988498_ToNu(v *events.EventGeo) (out nu.Value, err error) {
-	defer func() {
-		if err != nil {
-			err = fmt.Errorf("*events.EventGeo: %w", err)
-		}
-	}()
-	if v == nil {
-		return nu.Value{}, nil
+		return out, err
 	}
-	return type_7161572108068222122_ToNu(*v)
+	return out, nil
 }
-
-var type_2584899110032584934 = type_10890016574791629639
-
-func type_2584899110032584934_FromNu(v nu.Value) (out *int, err error) {
+func type_7161572108068222122_ToNu(v events.EventGeo) (out nu.Value, err error) {
 	defer func() {
 		if err != nil {
-			err = fmt.Errorf("*int: %w", err)
+			err = fmt.Errorf("events.EventGeo: %w", err)
 		}
 	}()
-	if v.Value == nil {
-		return nil, nil
-	}
-	res, err := type_10890016574791629639_FromNu(v)
+	rec := nu.Record{}
+	rec["latitude"], err = type_17860233973098560385_ToNu(v.Latitude)
 	if err != nil {
-		return nil, err
-	}
-	return &res, nil
-}
-func type_2584899110032584934_ToNu(v *int) (out nu.Value, err error) {
-	defer func() {
-		if err != nil {
-			err = fmt.Errorf("*int: %w", err)
-		}
-	}()
-	if v == nil {
-		return nu.Value{}, nil
-	}
-	return type_10890016574791629639_ToNu(*v)
-}
-
-var type_784588192188755836 = type_15385297846572725340
-
-func type_784588192188755836_FromNu(v nu.Value) (out *events.EventStatus, err error) {
-	defer func() {
-		if err != nil {
-			err = fmt.Errorf("*events.EventStatus: %w", err)
-		}
-	}()
-	if v.Value == nil {
-		return nil, nil
+		return nu.Value{}, err
 	}
-	res, err := type_15385297846572725340_FromNu(v)
+	rec["longitude"], err = type_17860233973098560385_ToNu(v.Longitude)
 	if err != nil {
-		return nil, err
-	}
-	return &res, nil
-}
-func type_784588192188755836_ToNu(v *events.EventStatus) (out nu.Value, err error) {
-	defer func() {
-		if err != nil {
-			err = fmt.Errorf("*events.EventStatus: %w", err)
-		}
-	}()
-	if v == nil {
-		return nu.Value{}, nil
+		return nu.Value{}, err
 	}
-	return type_15385297846572725340_ToNu(*v)
+	return nu.Value{Value: rec}, nil
 }
 
 var type_9520111014888170891 = types.Record(type_13545470577293064413)
@@ -1295,88 +3351,60 @@ func type_9520111014888170891_ToNu(v *events.EventTrigger) (out nu.Value, err er
 	return type_13545470577293064413_ToNu(*v)
 }
 
-var type_15139881813094606131 = types.Int()
-
-func type_15139881813094606131_FromNu(v nu.Value) (out int64, err error) {
-	defer func() {
-		if err != nil {
-			err = fmt.Errorf("int64: %w", err)
-		}
-	}()
-	casted, ok := v.Value.(int64)
-	converted := int64(casted)
-	if !ok {
-		return converted, fmt.Errorf("expected int64 got %v", v.Value)
-	}
-	return converted, nil
-}
-func type_15139881813094606131_ToNu(v int64) (out nu.Value, err error) {
-	defer func() {
-		if err != nil {
-			err = fmt.Errorf("int64: %w", err)
-		}
-	}()
-	return nu.ToValue(v), nil
-}
-
-var type_5454485661162817076 = types.RecordDef{
-	"stamp":    type_8047992331715851194,
-	"all_day":  type_729807561129781588,
-	"floating": type_729807561129781588,
+var type_18001548242738951129 = types.RecordDef{
+	"date":    type_8047992331715851194,
+	"weekday": type_15613163272824911089,
+	"events":  type_3158728863752183869,
 }
 
-func type_5454485661162817076_FromNu(v nu.Value) (out events.Datetime, err error) {
+func type_18001548242738951129_FromNu(v nu.Value) (out dto.AgendaDay, err error) {
 	defer func() {
 		if err != nil {
-			err = fmt.Errorf("events.Datetime: %w", err)
+			err = fmt.Errorf("dto.AgendaDay: %w", err)
 		}
 	}()
 	record, ok := v.Value.(nu.Record)
 	if !ok {
 		return out, fmt.Errorf("expected nu.Record got %T", v.Value)
 	}
-	var val nu.Value
-	val, _ = record["stamp"]
-	out.Stamp, err = type_8047992331715851194_FromNu(val)
-	if err != nil {
-		return out, err
-	}
-	val, ok = record["all_day"]
-	if !ok {
-		out.AllDay = false
-	} else {
-		out.AllDay, err = type_729807561129781588_FromNu(val)
-		if err != nil {
-			return out, err
-		}
+	var val nu.Value
+	val, _ = record["date"]
+	out.Date, err = type_8047992331715851194_FromNu(val)
+	if err != nil {
+		return out, err
 	}
-	val, ok = record["floating"]
+	val, ok = record["weekday"]
 	if !ok {
-		out.Floating = false
+		out.Weekday = ""
 	} else {
-		out.Floating, err = type_729807561129781588_FromNu(val)
+		out.Weekday, err = type_15613163272824911089_FromNu(val)
 		if err != nil {
 			return out, err
 		}
 	}
+	val, _ = record["events"]
+	out.Events, err = type_3158728863752183869_FromNu(val)
+	if err != nil {
+		return out, err
+	}
 	return out, nil
 }
-func type_5454485661162817076_ToNu(v events.Datetime) (out nu.Value, err error) {
+func type_18001548242738951129_ToNu(v dto.AgendaDay) (out nu.Value, err error) {
 	defer func() {
 		if err != nil {
-			err = fmt.Errorf("events.Datetime: %w", err)
+			err = fmt.Errorf("dto.AgendaDay: %w", err)
 		}
 	}()
 	rec := nu.Record{}
-	rec["stamp"], err = type_8047992331715851194_ToNu(v.Stamp)
+	rec["date"], err = type_8047992331715851194_ToNu(v.Date)
 	if err != nil {
 		return nu.Value{}, err
 	}
-	rec["all_day"], err = type_729807561129781588_ToNu(v.AllDay)
+	rec["weekday"], err = type_15613163272824911089_ToNu(v.Weekday)
 	if err != nil {
 		return nu.Value{}, err
 	}
-	rec["floating"], err = type_729807561129781588_ToNu(v.Floating)
+	rec["events"], err = type_3158728863752183869_ToNu(v.Events)
 	if err != nil {
 		return nu.Value{}, err
 	}
@@ -1407,65 +3435,12 @@ func type_10890016574791629639_ToNu(v int) (out nu.Value, err error) {
 	return nu.ToValue(v), nil
 }
 
-var type_15385297846572725340 = types.String()
-
-func type_15385297846572725340_FromNu(v nu.Value) (out events.EventStatus, err error) {
-	defer func() {
-		if err != nil {
-			err = fmt.Errorf("events.EventStatus: %w", err)
-		}
-	}()
-	casted, ok := v.Value.(string)
-	converted := events.EventStatus(casted)
-	if !ok {
-		return converted, fmt.Errorf("expected string got %v", v.Value)
-	}
-	return converted, nil
-}
-func type_15385297846572725340_ToNu(v events.EventStatus) (out nu.Value, err error) {
-	defer func() {
-		if err != nil {
-			err = fmt.Errorf("events.EventStatus: %w", err)
-		}
-	}()
-	return nu.ToValue(v), nil
-}
-
-var type_5363327835607766502 = types.String()
-
-func type_5363327835607766502_FromNu(v nu.Value) (out *url.URL, err error) {
-	defer func() {
-		if err != nil {
-			err = fmt.Errorf("*url.URL: %w", err)
-		}
-	}()
-	if v.Value == nil {
-		return nil, nil
-	}
-	parsed, err := url.Parse(v.Value.(string))
-	if err != nil {
-		return nil, err
-	}
-	return parsed, nil
-}
-func type_5363327835607766502_ToNu(v *url.URL) (out nu.Value, err error) {
-	defer func() {
-		if err != nil {
-			err = fmt.Errorf("*url.URL: %w", err)
-		}
-	}()
-	if v == nil {
-		return nu.Value{Value: nil}, nil
-	}
-	return nu.ToValue(v.String()), nil
-}
-
-var type_12588128689068210979 = types.Table(type_15963329845892192617)
+var type_3931126380996215332 = types.Table(type_5454485661162817076)
 
-func type_12588128689068210979_FromNu(v nu.Value) (out []dto.PropValueDto, err error) {
+func type_3931126380996215332_FromNu(v nu.Value) (out []events.Datetime, err error) {
 	defer func() {
 		if err != nil {
-			err = fmt.Errorf("[]dto.PropValueDto: %w", err)
+			err = fmt.Errorf("[]events.Datetime: %w", err)
 		}
 	}()
 	if v.Value == nil {
@@ -1475,24 +3450,24 @@ func type_12588128689068210979_FromNu(v nu.Value) (out []dto.PropValueDto, err e
 	if !ok {
 		return nil, fmt.Errorf("expected []nu.Value got %T", v.Value)
 	}
-	out = make([]dto.PropValueDto, len(arr))
+	out = make([]events.Datetime, len(arr))
 	for i, e := range arr {
-		out[i], err = type_15963329845892192617_FromNu(e)
+		out[i], err = type_5454485661162817076_FromNu(e)
 		if err != nil {
 			return nil, err
 		}
 	}
 	return out, nil
 }
-func type_12588128689068210979_ToNu(v []dto.PropValueDto) (out nu.Value, err error) {
+func type_3931126380996215332_ToNu(v []events.Datetime) (out nu.Value, err error) {
 	defer func() {
 		if err != nil {
-			err = fmt.Errorf("[]dto.PropValueDto: %w", err)
+			err = fmt.Errorf("[]events.Datetime: %w", err)
 		}
 	}()
 	list := make([]nu.Value, len(v))
 	for i, e := range v {
-		list[i], err = type_15963329845892192617_ToNu(e)
+		list[i], err = type_5454485661162817076_ToNu(e)
 		if err != nil {
 			return nu.Value{}, err
 		}
@@ -1500,56 +3475,19 @@ func type_12588128689068210979_ToNu(v []dto.PropValueDto) (out nu.Value, err err
 	return nu.Value{Value: list}, nil
 }
 
-var type_12604977785371100614 = types.Any()
-
-func type_12604977785371100614_FromNu(v nu.Value) (out map[string][]dto.PropValueDto, err error) {
-	defer func() {
-		if err != nil {
-			err = fmt.Errorf("map[string][]dto.PropValueDto: %w", err)
-		}
-	}()
-	if v.Value == nil {
-		return nil, nil
-	}
-	dict, ok := v.Value.(nu.Record)
-	if !ok {
-		return nil, fmt.Errorf("expected nu.Record got %T", v.Value)
-	}
-	out = make(map[string][]dto.PropValueDto, len(dict))
-	for k, v := range dict {
-		out[k], err = type_12588128689068210979_FromNu(v)
-		if err != nil {
-			return nil, err
-		}
-	}
-	return out, nil
-}
-func type_12604977785371100614_ToNu(v map[string][]dto.PropValueDto) (out nu.Value, err error) {
-	defer func() {
-		if err != nil {
-			err = fmt.Errorf("map[string][]dto.PropValueDto: %w", err)
-		}
-	}()
-	dict := make(nu.Record, len(v))
-	for k, v := range v {
-		dict[k], err = type_12588128689068210979_ToNu(v)
-		if err != nil {
-			return nu.Value{}, err
-		}
-	}
-	return nu.Value{Value: dict}, nil
-}
-
-var type_18439826349963270388 = types.RecordDef{
-	"object_path": type_17862013815172309399,
-	"main":        types.Record(type_8814170927480347350),
-	"overrides":   type_601306316528950762,
+var type_1233005477764658533 = types.RecordDef{
+	"now":                    type_8047992331715851194,
+	"duration":               type_16589689216511618220,
+	"active_events":          type_601306316528950762,
+	"busy":                   type_17860233973098560385,
+	"warnings":               type_11669970230249425419,
+	"impossible_transitions": type_2442914427180427065,
 }
 
-func type_18439826349963270388_FromNu(v nu.Value) (out dto.EventObject, err error) {
+func type_1233005477764658533_FromNu(v nu.Value) (out dto.TimeSegment, err error) {
 	defer func() {
 		if err != nil {
-			err = fmt.Errorf("dto.EventObject: %w", err)
+			err = fmt.Errorf("dto.TimeSegment: %w", err)
 		}
 	}()
 	record, ok := v.Value.(nu.Record)
@@ -1557,51 +3495,78 @@ func type_18439826349963270388_FromNu(v nu.Value) (out dto.EventObject, err erro
 		return out, fmt.Errorf("expected nu.Record got %T", v.Value)
 	}
 	var val nu.Value
-	val, _ = record["object_path"]
-	out.ObjectPath, err = type_17862013815172309399_FromNu(val)
+	val, _ = record["now"]
+	out.Now, err = type_8047992331715851194_FromNu(val)
 	if err != nil {
 		return out, err
 	}
-	val, _ = record["main"]
-	out.Main, err = type_8814170927480347350_FromNu(val)
+	val, _ = record["duration"]
+	out.Duration, err = type_16589689216511618220_FromNu(val)
 	if err != nil {
 		return out, err
 	}
-	val, _ = record["overrides"]
-	out.Overrides, err = type_601306316528950762_FromNu(val)
+	val, _ = record["active_events"]
+	out.ActiveEvents, err = type_601306316528950762_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["busy"]
+	out.Busy, err = type_17860233973098560385_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["warnings"]
+	out.Warnings, err = type_11669970230249425419_FromNu(val)
+	if err != nil {
+		return out, err
+	}
+	val, _ = record["impossible_transitions"]
+	out.ImpossibleTransitions, err = type_2442914427180427065_FromNu(val)
 	if err != nil {
 		return out, err
 	}
 	return out, nil
 }
-func type_18439826349963270388_ToNu(v dto.EventObject) (out nu.Value, err error) {
+func type_1233005477764658533_ToNu(v dto.TimeSegment) (out nu.Value, err error) {
 	defer func() {
 		if err != nil {
-			err = fmt.Errorf("dto.EventObject: %w", err)
+			err = fmt.Errorf("dto.TimeSegment: %w", err)
 		}
 	}()
 	rec := nu.Record{}
-	rec["object_path"], err = type_17862013815172309399_ToNu(v.ObjectPath)
+	rec["now"], err = type_8047992331715851194_ToNu(v.Now)
 	if err != nil {
 		return nu.Value{}, err
 	}
-	rec["main"], err = type_8814170927480347350_ToNu(v.Main)
+	rec["duration"], err = type_16589689216511618220_ToNu(v.Duration)
 	if err != nil {
 		return nu.Value{}, err
 	}
-	rec["overrides"], err = type_601306316528950762_ToNu(v.Overrides)
+	rec["active_events"], err = type_601306316528950762_ToNu(v.ActiveEvents)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["busy"], err = type_17860233973098560385_ToNu(v.Busy)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["warnings"], err = type_11669970230249425419_ToNu(v.Warnings)
+	if err != nil {
+		return nu.Value{}, err
+	}
+	rec["impossible_transitions"], err = type_2442914427180427065_ToNu(v.ImpossibleTransitions)
 	if err != nil {
 		return nu.Value{}, err
 	}
 	return nu.Value{Value: rec}, nil
 }
 
-var type_9049281093675579929 = types.Table(type_18439826349963270388)
+var type_10575170654620110947 = types.Table(type_12917729306268329132)
 
-func type_9049281093675579929_FromNu(v nu.Value) (out dto.EventObjectList, err error) {
+func type_10575170654620110947_FromNu(v nu.Value) (out dto.ConflictList, err error) {
 	defer func() {
 		if err != nil {
-			err = fmt.Errorf("dto.EventObjectList: %w", err)
+			err = fmt.Errorf("dto.ConflictList: %w", err)
 		}
 	}()
 	if v.Value == nil {
@@ -1611,24 +3576,24 @@ func type_9049281093675579929_FromNu(v nu.Value) (out dto.EventObjectList, err e
 	if !ok {
 		return nil, fmt.Errorf("expected []nu.Value got %T", v.Value)
 	}
-	out = make(dto.EventObjectList, len(arr))
+	out = make(dto.ConflictList, len(arr))
 	for i, e := range arr {
-		out[i], err = type_18439826349963270388_FromNu(e)
+		out[i], err = type_12917729306268329132_FromNu(e)
 		if err != nil {
 			return nil, err
 		}
 	}
 	return out, nil
 }
-func type_9049281093675579929_ToNu(v dto.EventObjectList) (out nu.Value, err error) {
+func type_10575170654620110947_ToNu(v dto.ConflictList) (out nu.Value, err error) {
 	defer func() {
 		if err != nil {
-			err = fmt.Errorf("dto.EventObjectList: %w", err)
+			err = fmt.Errorf("dto.ConflictList: %w", err)
 		}
 	}()
 	list := make([]nu.Value, len(v))
 	for i, e := range v {
-		list[i], err = type_18439826349963270388_ToNu(e)
+		list[i], err = type_12917729306268329132_ToNu(e)
 		if err != nil {
 			return nu.Value{}, err
 		}
@@ -1636,12 +3601,70 @@ func type_9049281093675579929_ToNu(v dto.EventObjectList) (out nu.Value, err err
 	return nu.Value{Value: list}, nil
 }
 
-var type_11669970230249425419 = types.List(type_15613163272824911089)
+var type_12480522309550428545 = types.Record(type_5454485661162817076)
 
-func type_11669970230249425419_FromNu(v nu.Value) (out []string, err error) {
+func type_12480522309550428545_FromNu(v nu.Value) (out *events.Datetime, err error) {
 	defer func() {
 		if err != nil {
-			err = fmt.Errorf("[]string: %w", err)
+			err = fmt.Errorf("*events.Datetime: %w", err)
+		}
+	}()
+	if v.Value == nil {
+		return nil, nil
+	}
+	res, err := type_5454485661162817076_FromNu(v)
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+func type_12480522309550428545_ToNu(v *events.Datetime) (out nu.Value, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("*events.Datetime: %w", err)
+		}
+	}()
+	if v == nil {
+		return nu.Value{}, nil
+	}
+	return type_5454485661162817076_ToNu(*v)
+}
+
+var type_8971279483973357571 = type_7057708295081751301
+
+func type_8971279483973357571_FromNu(v nu.Value) (out *events.EventTransparency, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("*events.EventTransparency: %w", err)
+		}
+	}()
+	if v.Value == nil {
+		return nil, nil
+	}
+	res, err := type_7057708295081751301_FromNu(v)
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+func type_8971279483973357571_ToNu(v *events.EventTransparency) (out nu.Value, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("*events.EventTransparency: %w", err)
+		}
+	}()
+	if v == nil {
+		return nu.Value{}, nil
+	}
+	return type_7057708295081751301_ToNu(*v)
+}
+
+var type_16415337096189786003 = types.Table(type_6453951478216933494)
+
+func type_16415337096189786003_FromNu(v nu.Value) (out dto.OccurrenceList, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("dto.OccurrenceList: %w", err)
 		}
 	}()
 	if v.Value == nil {
@@ -1651,24 +3674,24 @@ func type_11669970230249425419_FromNu(v nu.Value) (out []string, err error) {
 	if !ok {
 		return nil, fmt.Errorf("expected []nu.Value got %T", v.Value)
 	}
-	out = make([]string, len(arr))
+	out = make(dto.OccurrenceList, len(arr))
 	for i, e := range arr {
-		out[i], err = type_15613163272824911089_FromNu(e)
+		out[i], err = type_6453951478216933494_FromNu(e)
 		if err != nil {
 			return nil, err
 		}
 	}
 	return out, nil
 }
-func type_11669970230249425419_ToNu(v []string) (out nu.Value, err error) {
+func type_16415337096189786003_ToNu(v dto.OccurrenceList) (out nu.Value, err error) {
 	defer func() {
 		if err != nil {
-			err = fmt.Errorf("[]string: %w", err)
+			err = fmt.Errorf("dto.OccurrenceList: %w", err)
 		}
 	}()
 	list := make([]nu.Value, len(v))
 	for i, e := range v {
-		list[i], err = type_15613163272824911089_ToNu(e)
+		list[i], err = type_6453951478216933494_ToNu(e)
 		if err != nil {
 			return nu.Value{}, err
 		}
@@ -1676,18 +3699,54 @@ func type_11669970230249425419_ToNu(v []string) (out nu.Value, err error) {
 	return nu.Value{Value: list}, nil
 }
 
-var EventObjectType = type_18439826349963270388
-var EventObjectFromNu = type_18439826349963270388_FromNu
-var EventObjectToNu = type_18439826349963270388_ToNu
+var OccurrenceListType = type_16415337096189786003
+var OccurrenceListFromNu = type_16415337096189786003_FromNu
+var OccurrenceListToNu = type_16415337096189786003_ToNu
+var FreeSlotListType = type_15564594043207847740
+var FreeSlotListFromNu = type_15564594043207847740_FromNu
+var FreeSlotListToNu = type_15564594043207847740_ToNu
+var HoursReportListType = type_7165613080059059381
+var HoursReportListFromNu = type_7165613080059059381_FromNu
+var HoursReportListToNu = type_7165613080059059381_ToNu
+var AgendaType = type_12446113380399364270
+var AgendaFromNu = type_12446113380399364270_FromNu
+var AgendaToNu = type_12446113380399364270_ToNu
+var AccessEntryListType = type_14982353511810887690
+var AccessEntryListFromNu = type_14982353511810887690_FromNu
+var AccessEntryListToNu = type_14982353511810887690_ToNu
 var EventType = type_8814170927480347350
 var EventFromNu = type_8814170927480347350_FromNu
 var EventToNu = type_8814170927480347350_ToNu
-var TimelineType = type_11923325321682739420
-var TimelineFromNu = type_11923325321682739420_FromNu
-var TimelineToNu = type_11923325321682739420_ToNu
+var CacheStatusType = type_11687433542174887081
+var CacheStatusFromNu = type_11687433542174887081_FromNu
+var CacheStatusToNu = type_11687433542174887081_ToNu
+var PrincipalListType = type_4344875875351294888
+var PrincipalListFromNu = type_4344875875351294888_FromNu
+var PrincipalListToNu = type_4344875875351294888_ToNu
+var TimeSegmentType = type_1233005477764658533
+var TimeSegmentFromNu = type_1233005477764658533_FromNu
+var TimeSegmentToNu = type_1233005477764658533_ToNu
+var ConflictListType = type_10575170654620110947
+var ConflictListFromNu = type_10575170654620110947_FromNu
+var ConflictListToNu = type_10575170654620110947_ToNu
+var SyncFailureListType = type_16843359552575150564
+var SyncFailureListFromNu = type_16843359552575150564_FromNu
+var SyncFailureListToNu = type_16843359552575150564_ToNu
 var CalendarListType = type_1838685811995560013
 var CalendarListFromNu = type_1838685811995560013_FromNu
 var CalendarListToNu = type_1838685811995560013_ToNu
+var PushOutcomeListType = type_223612926626247449
+var PushOutcomeListFromNu = type_223612926626247449_FromNu
+var PushOutcomeListToNu = type_223612926626247449_ToNu
+var CachedCalendarListType = type_18413834526742637396
+var CachedCalendarListFromNu = type_18413834526742637396_FromNu
+var CachedCalendarListToNu = type_18413834526742637396_ToNu
 var EventObjectListType = type_9049281093675579929
 var EventObjectListFromNu = type_9049281093675579929_FromNu
 var EventObjectListToNu = type_9049281093675579929_ToNu
+var EventObjectType = type_18439826349963270388
+var EventObjectFromNu = type_18439826349963270388_FromNu
+var EventObjectToNu = type_18439826349963270388_ToNu
+var TimelineType = type_11923325321682739420
+var TimelineFromNu = type_11923325321682739420_FromNu
+var TimelineToNu = type_11923325321682739420_ToNu
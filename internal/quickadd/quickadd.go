@@ -0,0 +1,744 @@
+// Package quickadd parses short English phrases like "Lunch with Sam tomorrow
+// 12:30 for 1h @Cafe #social" into events.
+//
+// A phrase is made of words, the ones that describe when the event takes
+// place are removed and the rest is its summary:
+//
+//   - dates: today, tomorrow, yesterday, (next) monday, in 3 days, in 2 weeks,
+//     2025-11-14, nov 14 (2025), 14 november
+//   - times: 9am, 9:30pm, 5p.m., 12:30, noon, midnight, at 9, optionally as a
+//     range like 9-10am, 9am to 11am or from 13:00 until 14:30. Hours from 1
+//     to 7 without am or pm are in the afternoon (at 7 is 19:00), and ranges
+//     like 3-5 need a colon, am or pm, or a leading at or from to be times.
+//   - durations: for 1h, for 1h30m, for 90 minutes, for 2 days, all day
+//   - recurrences: every day, every 2 weeks, every monday, every mon and wed,
+//     every weekday, daily, weekly, monthly, yearly
+//   - @location, or @"a location with spaces"
+//   - #category
+//
+// Words in double quotes are always part of the summary.
+package quickadd
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/LQR471814/nu_plugin_caldav/internal/dto"
+	"github.com/LQR471814/nu_plugin_caldav/internal/events"
+	"github.com/emersion/go-ical"
+	"github.com/teambition/rrule-go"
+)
+
+// DefaultDuration is the length of timed events without an end or duration.
+const DefaultDuration = time.Hour
+
+var (
+	ErrNoSummary = errors.New("phrase has no summary")
+	ErrNoTime    = errors.New("phrase has no date, time or recurrence")
+)
+
+// token is a word of a phrase.
+type token struct {
+	text string
+	// quoted words are never interpreted
+	quoted bool
+}
+
+// word returns the lowercase text of a token without trailing punctuation.
+func (t token) word() string {
+	if t.quoted {
+		return ""
+	}
+	return strings.ToLower(strings.TrimRight(t.text, ",.;"))
+}
+
+// tokenize splits a phrase into words, quoted text (including the location
+// after @) is kept as a single word.
+func tokenize(phrase string) (out []token, err error) {
+	runes := []rune(phrase)
+	for i := 0; i < len(runes); {
+		if runes[i] == ' ' || runes[i] == '\t' || runes[i] == '\n' {
+			i++
+			continue
+		}
+		prefix := ""
+		start := i
+		if runes[i] == '@' && i+1 < len(runes) && runes[i+1] == '"' {
+			prefix = "@"
+			i++
+		}
+		if runes[i] == '"' {
+			end := i + 1
+			for end < len(runes) && runes[end] != '"' {
+				end++
+			}
+			if end == len(runes) {
+				return nil, fmt.Errorf("unterminated quote at %d", start)
+			}
+			text := string(runes[i+1 : end])
+			out = append(out, token{text: prefix + text, quoted: prefix == ""})
+			i = end + 1
+			continue
+		}
+		end := i
+		for end < len(runes) && runes[end] != ' ' && runes[end] != '\t' && runes[end] != '\n' {
+			end++
+		}
+		out = append(out, token{text: string(runes[i:end])})
+		i = end
+	}
+	return
+}
+
+// clock is a time of day.
+type clock struct {
+	hour, minute int
+	// meridiem is "am", "pm" or "" if the time does not have one
+	meridiem string
+	// padded is set for hours written with a leading zero like 07:00
+	padded bool
+}
+
+// resolve returns the 24 hour time of a clock.
+func (c clock) resolve() (hour, minute int) {
+	hour = c.hour
+	switch c.meridiem {
+	case "am":
+		if hour == 12 {
+			hour = 0
+		}
+	case "pm":
+		if hour < 12 {
+			hour += 12
+		}
+	}
+	return hour, c.minute
+}
+
+var clockPattern = regexp.MustCompile(`^(\d{1,2})(?::(\d{2}))?\s*(am|pm|a|p)?$`)
+
+// parseClock parses a time of day, bare numbers are only accepted if bare is
+// set as they are ambiguous otherwise.
+func parseClock(s string, bare bool) (c clock, ok bool) {
+	switch s {
+	case "noon":
+		return clock{hour: 12}, true
+	case "midnight":
+		return clock{hour: 0}, true
+	}
+	m := clockPattern.FindStringSubmatch(s)
+	if m == nil || (m[2] == "" && m[3] == "" && !bare) {
+		return clock{}, false
+	}
+	c.hour, _ = strconv.Atoi(m[1])
+	c.padded = len(m[1]) == 2 && m[1][0] == '0'
+	if m[2] != "" {
+		c.minute, _ = strconv.Atoi(m[2])
+	}
+	switch m[3] {
+	case "a", "am":
+		c.meridiem = "am"
+	case "p", "pm":
+		c.meridiem = "pm"
+	}
+	if c.minute > 59 || (c.meridiem != "" && (c.hour == 0 || c.hour > 12)) || c.hour > 23 {
+		return clock{}, false
+	}
+	return c, true
+}
+
+// meridiems normalizes the spellings of am and pm.
+var meridiems = strings.NewReplacer("a.m", "am", "p.m", "pm")
+
+// inferAfternoon puts the hours from 1 to 7 of a time without a meridiem in
+// the afternoon, as events rarely start that early (ex. dinner at 7). Hours
+// with a leading zero like 07:00 are 24 hour times.
+func inferAfternoon(c clock) clock {
+	if c.meridiem == "" && !c.padded && c.hour >= 1 && c.hour <= 7 {
+		c.meridiem = "pm"
+	}
+	return c
+}
+
+// explicitClock reports whether the text of a time cannot be a plain number,
+// as it has minutes or a meridiem.
+func explicitClock(text string) bool {
+	return strings.ContainsAny(text, ":apm")
+}
+
+// parseClockRange parses a range of times in a single word like 9-10am.
+func parseClockRange(s string) (from, to clock, ok bool) {
+	a, b, found := strings.Cut(s, "-")
+	if !found {
+		return
+	}
+	to, ok = parseClock(b, true)
+	if !ok {
+		return
+	}
+	from, ok = parseClock(a, true)
+	return
+}
+
+// inferMeridiem gives the start of a range the meridiem of its end if it
+// does not have one, 9-10pm is 21:00-22:00 while 11-1pm is 11:00-13:00.
+func inferMeridiem(from, to clock) clock {
+	if from.meridiem != "" || to.meridiem == "" || from.hour > 12 {
+		return from
+	}
+	from.meridiem = to.meridiem
+	fh, fm := from.resolve()
+	th, tm := to.resolve()
+	if fh*60+fm > th*60+tm && to.meridiem == "pm" {
+		from.meridiem = "am"
+	}
+	return from
+}
+
+var durationPattern = regexp.MustCompile(`(\d+(?:\.\d+)?)\s*([a-z]+)`)
+
+var durationUnits = map[string]time.Duration{
+	"m": time.Minute, "min": time.Minute, "mins": time.Minute,
+	"minute": time.Minute, "minutes": time.Minute,
+	"h": time.Hour, "hr": time.Hour, "hrs": time.Hour,
+	"hour": time.Hour, "hours": time.Hour,
+	"d": 24 * time.Hour, "day": 24 * time.Hour, "days": 24 * time.Hour,
+	"w": 7 * 24 * time.Hour, "week": 7 * 24 * time.Hour, "weeks": 7 * 24 * time.Hour,
+}
+
+// parseDuration parses durations like 1h30m, 90min or 1.5 hours.
+func parseDuration(s string) (dur time.Duration, ok bool) {
+	matches := durationPattern.FindAllStringSubmatchIndex(s, -1)
+	if matches == nil {
+		return 0, false
+	}
+	end := 0
+	for _, m := range matches {
+		// the parts must cover the whole text
+		if strings.TrimSpace(s[end:m[0]]) != "" {
+			return 0, false
+		}
+		end = m[1]
+		unit, known := durationUnits[s[m[4]:m[5]]]
+		if !known {
+			return 0, false
+		}
+		n, err := strconv.ParseFloat(s[m[2]:m[3]], 64)
+		if err != nil {
+			return 0, false
+		}
+		dur += time.Duration(n * float64(unit))
+	}
+	if strings.TrimSpace(s[end:]) != "" || dur <= 0 {
+		return 0, false
+	}
+	return dur, true
+}
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "sunday": time.Sunday, "sundays": time.Sunday,
+	"mon": time.Monday, "monday": time.Monday, "mondays": time.Monday,
+	"tue": time.Tuesday, "tues": time.Tuesday, "tuesday": time.Tuesday, "tuesdays": time.Tuesday,
+	"wed": time.Wednesday, "wednesday": time.Wednesday, "wednesdays": time.Wednesday,
+	"thu": time.Thursday, "thur": time.Thursday, "thurs": time.Thursday,
+	"thursday": time.Thursday, "thursdays": time.Thursday,
+	"fri": time.Friday, "friday": time.Friday, "fridays": time.Friday,
+	"sat": time.Saturday, "saturday": time.Saturday, "saturdays": time.Saturday,
+}
+
+// ambiguousWeekdays are the abbreviations of weekdays that are also common
+// words (ex. in the sun, SAT prep), they are only dates after on, this or
+// next, or if the phrase has no other date.
+var ambiguousWeekdays = map[string]bool{"sun": true, "sat": true, "wed": true}
+
+var rruleWeekdays = [...]rrule.Weekday{
+	time.Sunday:    rrule.SU,
+	time.Monday:    rrule.MO,
+	time.Tuesday:   rrule.TU,
+	time.Wednesday: rrule.WE,
+	time.Thursday:  rrule.TH,
+	time.Friday:    rrule.FR,
+	time.Saturday:  rrule.SA,
+}
+
+var months = map[string]time.Month{}
+
+func init() {
+	for m := time.January; m <= time.December; m++ {
+		name := strings.ToLower(m.String())
+		months[name] = m
+		months[name[:3]] = m
+	}
+	months["sept"] = time.September
+}
+
+var dayPattern = regexp.MustCompile(`^(\d{1,2})(?:st|nd|rd|th)?$`)
+
+// parseDay parses a day of the month like 14 or 14th.
+func parseDay(s string) (day int, ok bool) {
+	m := dayPattern.FindStringSubmatch(s)
+	if m == nil {
+		return 0, false
+	}
+	day, _ = strconv.Atoi(m[1])
+	return day, day >= 1 && day <= 31
+}
+
+var frequencies = map[string]rrule.Frequency{
+	"day": rrule.DAILY, "days": rrule.DAILY,
+	"week": rrule.WEEKLY, "weeks": rrule.WEEKLY,
+	"month": rrule.MONTHLY, "months": rrule.MONTHLY,
+	"year": rrule.YEARLY, "years": rrule.YEARLY,
+}
+
+// parser holds what has been understood of a phrase so far.
+type parser struct {
+	tokens []token
+	// today is the start of the current day in the location of the phrase
+	today time.Time
+
+	date       *time.Time
+	start, end *clock
+	duration   *time.Duration
+	allDay     bool
+	rule       *rrule.ROption
+
+	summary    []string
+	location   *string
+	categories []string
+}
+
+// word returns the word at i, "" if it is past the end of the phrase.
+func (p *parser) word(i int) string {
+	if i >= len(p.tokens) {
+		return ""
+	}
+	return p.tokens[i].word()
+}
+
+// setDate sets the date of the event, a phrase can only have one.
+func (p *parser) setDate(date time.Time, text string) error {
+	if p.date != nil {
+		return fmt.Errorf("more than one date: %q", text)
+	}
+	p.date = &date
+	return nil
+}
+
+// nextWeekday returns the first day with the given weekday after today, or
+// today itself if inclusive is set.
+func (p *parser) nextWeekday(day time.Weekday, inclusive bool) time.Time {
+	offset := (int(day) - int(p.today.Weekday()) + 7) % 7
+	if offset == 0 && !inclusive {
+		offset = 7
+	}
+	return p.today.AddDate(0, 0, offset)
+}
+
+// hasOtherDate reports whether the phrase has a date or recurrence other than
+// the word at i, ambiguous weekdays are not counted.
+func (p *parser) hasOtherDate(i int) bool {
+	for j := range p.tokens {
+		w := p.word(j)
+		if j == i || ambiguousWeekdays[w] {
+			continue
+		}
+		if _, ok := weekdays[w]; ok {
+			return true
+		}
+		switch w {
+		case "every", "daily", "weekly", "monthly", "yearly", "annually":
+			return true
+		}
+		// match on a copy as matchDate sets the date
+		q := *p
+		q.date = nil
+		if n, err := q.matchDate(j); n > 0 && err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// matchDate parses a date at i, it returns the number of words it is made of
+// (0 if there is no date at i).
+func (p *parser) matchDate(i int) (n int, err error) {
+	w := p.word(i)
+	switch w {
+	case "today", "tonight":
+		return 1, p.setDate(p.today, w)
+	case "tomorrow":
+		return 1, p.setDate(p.today.AddDate(0, 0, 1), w)
+	case "yesterday":
+		return 1, p.setDate(p.today.AddDate(0, 0, -1), w)
+	case "on", "this", "next":
+		if day, ok := weekdays[p.word(i+1)]; ok {
+			return 2, p.setDate(p.nextWeekday(day, w != "next"), w+" "+p.word(i+1))
+		}
+		if w == "on" {
+			n, err = p.matchDate(i + 1)
+			if n > 0 {
+				n++
+			}
+			return
+		}
+		return 0, nil
+	case "in":
+		count, err := strconv.Atoi(p.word(i + 1))
+		if err != nil || count < 0 {
+			return 0, nil
+		}
+		text := fmt.Sprint(w, " ", count, " ", p.word(i+2))
+		switch p.word(i + 2) {
+		case "day", "days":
+			return 3, p.setDate(p.today.AddDate(0, 0, count), text)
+		case "week", "weeks":
+			return 3, p.setDate(p.today.AddDate(0, 0, 7*count), text)
+		case "month", "months":
+			return 3, p.setDate(p.today.AddDate(0, count, 0), text)
+		}
+		return 0, nil
+	}
+	if day, ok := weekdays[w]; ok {
+		if ambiguousWeekdays[w] && p.hasOtherDate(i) {
+			return 0, nil
+		}
+		return 1, p.setDate(p.nextWeekday(day, true), w)
+	}
+	if d, err := time.ParseInLocation(time.DateOnly, w, p.today.Location()); err == nil {
+		return 1, p.setDate(d, w)
+	}
+
+	// month names before or after the day
+	month, ok := months[w]
+	day, dayOk := parseDay(p.word(i + 1))
+	n = 2
+	if !ok || !dayOk {
+		day, dayOk = parseDay(w)
+		month, ok = months[p.word(i+1)]
+	}
+	if !ok || !dayOk {
+		return 0, nil
+	}
+	year := p.today.Year()
+	explicitYear := false
+	if y, err := strconv.Atoi(p.word(i + 2)); err == nil && y >= 1000 && y <= 9999 {
+		year = y
+		explicitYear = true
+		n = 3
+	}
+	date := time.Date(year, month, day, 0, 0, 0, 0, p.today.Location())
+	if date.Day() != day {
+		return 0, fmt.Errorf("invalid date: %s %d", month, day)
+	}
+	// dates without a year are the next ones
+	if !explicitYear && date.Before(p.today) {
+		date = date.AddDate(1, 0, 0)
+	}
+	return n, p.setDate(date, w+" "+p.word(i+1))
+}
+
+// setTimes sets the start (and end) time of the event, a phrase can only have
+// one.
+func (p *parser) setTimes(from clock, to *clock) error {
+	if p.start != nil {
+		return errors.New("more than one time")
+	}
+	if to != nil {
+		end := inferAfternoon(*to)
+		to = &end
+		from = inferMeridiem(from, end)
+	}
+	from = inferAfternoon(from)
+	p.start = &from
+	p.end = to
+	return nil
+}
+
+// matchTime parses a time (or range of times) at i.
+func (p *parser) matchTime(i int) (n int, err error) {
+	w := p.word(i)
+	bare := false
+	if w == "at" || w == "from" {
+		bare = true
+		i++
+		n++
+		w = p.word(i)
+	}
+	// 9 am is a single time
+	joined := meridiems.Replace(w)
+	extra := 0
+	switch meridiems.Replace(p.word(i + 1)) {
+	case "am", "pm":
+		joined += meridiems.Replace(p.word(i + 1))
+		extra = 1
+	}
+
+	// ranges of plain numbers like 3-5 are usually not times (ex. chapters
+	// 3-5)
+	if from, to, ok := parseClockRange(joined); ok && (bare || explicitClock(joined)) {
+		return n + 1 + extra, p.setTimes(from, &to)
+	}
+	from, ok := parseClock(joined, bare)
+	if !ok {
+		return 0, nil
+	}
+	n += 1 + extra
+	i += 1 + extra
+
+	switch p.word(i) {
+	case "-", "to", "until", "till":
+		end := meridiems.Replace(p.word(i + 1))
+		extra = 0
+		switch meridiems.Replace(p.word(i + 2)) {
+		case "am", "pm":
+			end += meridiems.Replace(p.word(i + 2))
+			extra = 1
+		}
+		if to, ok := parseClock(end, true); ok {
+			return n + 2 + extra, p.setTimes(from, &to)
+		}
+	}
+	return n, p.setTimes(from, nil)
+}
+
+// matchDuration parses a duration at i.
+func (p *parser) matchDuration(i int) (n int, err error) {
+	w := p.word(i)
+	if w == "all" && p.word(i+1) == "day" {
+		p.allDay = true
+		return 2, nil
+	}
+	if w != "for" {
+		return 0, nil
+	}
+	// the duration is either a single word or a number and a unit
+	dur, ok := parseDuration(p.word(i + 1))
+	n = 2
+	if !ok {
+		dur, ok = parseDuration(p.word(i+1) + p.word(i+2))
+		n = 3
+	}
+	if !ok {
+		return 0, nil
+	}
+	if p.duration != nil {
+		return 0, errors.New("more than one duration")
+	}
+	p.duration = &dur
+	return n, nil
+}
+
+// setRule sets the recurrence of the event, a phrase can only have one.
+func (p *parser) setRule(rule rrule.ROption) error {
+	if p.rule != nil {
+		return errors.New("more than one recurrence")
+	}
+	p.rule = &rule
+	return nil
+}
+
+// matchRecurrence parses a recurrence at i.
+func (p *parser) matchRecurrence(i int) (n int, err error) {
+	w := p.word(i)
+	switch w {
+	case "daily":
+		return 1, p.setRule(rrule.ROption{Freq: rrule.DAILY})
+	case "weekly":
+		return 1, p.setRule(rrule.ROption{Freq: rrule.WEEKLY})
+	case "monthly":
+		return 1, p.setRule(rrule.ROption{Freq: rrule.MONTHLY})
+	case "yearly", "annually":
+		return 1, p.setRule(rrule.ROption{Freq: rrule.YEARLY})
+	case "every":
+	default:
+		return 0, nil
+	}
+
+	next := p.word(i + 1)
+	switch next {
+	case "weekday", "weekdays":
+		return 2, p.setRule(rrule.ROption{
+			Freq:      rrule.WEEKLY,
+			Byweekday: []rrule.Weekday{rrule.MO, rrule.TU, rrule.WE, rrule.TH, rrule.FR},
+		})
+	}
+	if freq, ok := frequencies[next]; ok {
+		return 2, p.setRule(rrule.ROption{Freq: freq})
+	}
+	if interval, err := strconv.Atoi(next); err == nil && interval > 0 {
+		if freq, ok := frequencies[p.word(i+2)]; ok {
+			return 3, p.setRule(rrule.ROption{Freq: freq, Interval: interval})
+		}
+		return 0, nil
+	}
+
+	// every monday, wednesday and friday
+	var days []rrule.Weekday
+	n = 1
+	for {
+		day, ok := weekdays[p.word(i+n)]
+		if !ok {
+			break
+		}
+		days = append(days, rruleWeekdays[day])
+		n++
+		if p.word(i+n) == "and" || p.word(i+n) == "&" {
+			if _, ok := weekdays[p.word(i+n+1)]; ok {
+				n++
+			}
+		}
+	}
+	if len(days) == 0 {
+		return 0, nil
+	}
+	return n, p.setRule(rrule.ROption{Freq: rrule.WEEKLY, Byweekday: days})
+}
+
+// parse interprets the words of the phrase.
+func (p *parser) parse() error {
+	matchers := []func(i int) (int, error){
+		p.matchRecurrence,
+		p.matchDuration,
+		p.matchDate,
+		p.matchTime,
+	}
+	for i := 0; i < len(p.tokens); {
+		t := p.tokens[i]
+		if !t.quoted && len(t.text) > 1 {
+			switch t.text[0] {
+			case '@':
+				if p.location != nil {
+					return fmt.Errorf("more than one location: %q", t.text)
+				}
+				location := t.text[1:]
+				p.location = &location
+				i++
+				continue
+			case '#':
+				p.categories = append(p.categories, strings.TrimRight(t.text[1:], ",.;"))
+				i++
+				continue
+			}
+		}
+		matched := false
+		for _, match := range matchers {
+			n, err := match(i)
+			if err != nil {
+				return err
+			}
+			if n > 0 {
+				i += n
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			p.summary = append(p.summary, t.text)
+			i++
+		}
+	}
+	return nil
+}
+
+// firstDate returns the date of the event, the first day of its recurrence
+// if it does not have one.
+func (p *parser) firstDate() time.Time {
+	if p.date != nil {
+		return *p.date
+	}
+	if p.rule == nil || len(p.rule.Byweekday) == 0 {
+		return p.today
+	}
+	first := p.today.AddDate(0, 0, 7)
+	for _, day := range p.rule.Byweekday {
+		d := p.nextWeekday(time.Weekday((day.Day()+1)%7), true)
+		if d.Before(first) {
+			first = d
+		}
+	}
+	return first
+}
+
+// Parse parses a phrase into an event, relative dates are resolved from now
+// and times are in loc.
+func Parse(phrase string, now time.Time, loc *time.Location) (out dto.Event, err error) {
+	tokens, err := tokenize(phrase)
+	if err != nil {
+		return
+	}
+	now = now.In(loc)
+	p := parser{
+		tokens: tokens,
+		today:  time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc),
+	}
+	err = p.parse()
+	if err != nil {
+		return
+	}
+	if len(p.summary) == 0 {
+		err = ErrNoSummary
+		return
+	}
+	if p.date == nil && p.start == nil && p.rule == nil && !p.allDay {
+		err = ErrNoTime
+		return
+	}
+
+	summary := strings.TrimRight(strings.Join(p.summary, " "), ",;")
+	out.Kind = ical.CompEvent
+	out.Summary = &summary
+	out.Location = p.location
+	out.Categories = p.categories
+	if p.rule != nil {
+		var rule *rrule.RRule
+		rule, err = rrule.NewRRule(*p.rule)
+		if err != nil {
+			return out, fmt.Errorf("recurrence: %w", err)
+		}
+		out.RecurrenceRule.RRule = rule
+	}
+
+	date := p.firstDate()
+	if p.start == nil || p.allDay {
+		if p.start != nil {
+			return out, errors.New("an all day event cannot have a time")
+		}
+		days := 1
+		if p.duration != nil {
+			if *p.duration%(24*time.Hour) != 0 {
+				return out, fmt.Errorf("the duration of an all day event must be whole days, got %v", *p.duration)
+			}
+			days = int(*p.duration / (24 * time.Hour))
+		}
+		// all-day dates are floating, their stamp is the date in UTC
+		start := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+		out.Start = events.Datetime{Stamp: start, AllDay: true, Floating: true}
+		out.End = events.Datetime{Stamp: start.AddDate(0, 0, days), AllDay: true, Floating: true}
+		return
+	}
+
+	hour, minute := p.start.resolve()
+	start := time.Date(date.Year(), date.Month(), date.Day(), hour, minute, 0, 0, loc)
+	end := start.Add(DefaultDuration)
+	switch {
+	case p.end != nil && p.duration != nil:
+		return out, errors.New("an event cannot have both an end time and a duration")
+	case p.end != nil:
+		hour, minute = p.end.resolve()
+		end = time.Date(date.Year(), date.Month(), date.Day(), hour, minute, 0, 0, loc)
+		// ranges like 22:00-01:00 end on the next day
+		if !end.After(start) {
+			end = end.AddDate(0, 0, 1)
+		}
+	case p.duration != nil:
+		end = start.Add(*p.duration)
+	}
+	out.Start = events.Datetime{Stamp: start}
+	out.End = events.Datetime{Stamp: end}
+	return
+}
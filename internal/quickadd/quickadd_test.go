@@ -0,0 +1,241 @@
+package quickadd
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/teambition/rrule-go"
+)
+
+var losAngeles = func() *time.Location {
+	loc, err := time.LoadLocation("America/Los_Angeles")
+	if err != nil {
+		panic(err)
+	}
+	return loc
+}()
+
+// now is a wednesday morning.
+var now = time.Date(2025, 10, 22, 10, 0, 0, 0, losAngeles)
+
+func at(month time.Month, day, hour, minute int) time.Time {
+	return time.Date(2025, month, day, hour, minute, 0, 0, losAngeles)
+}
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		phrase     string
+		summary    string
+		location   string
+		categories []string
+		start, end time.Time
+		allDay     bool
+		rrule      string
+	}{
+		{
+			phrase:   "Lunch with Sam tomorrow 12:30 for 1h @Cafe",
+			summary:  "Lunch with Sam",
+			location: "Cafe",
+			start:    at(10, 23, 12, 30),
+			end:      at(10, 23, 13, 30),
+		},
+		{
+			phrase:     `Standup every weekday at 9:15am for 15min @"Room 4" #work`,
+			summary:    "Standup",
+			location:   "Room 4",
+			categories: []string{"work"},
+			start:      at(10, 22, 9, 15),
+			end:        at(10, 22, 9, 30),
+			rrule:      "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR",
+		},
+		{
+			phrase:  "Gym every Monday and Thursday 6pm",
+			summary: "Gym",
+			start:   at(10, 23, 18, 0),
+			end:     at(10, 23, 19, 0),
+			rrule:   "FREQ=WEEKLY;BYDAY=MO,TH",
+		},
+		{
+			phrase:  "Review next wednesday 9-10:30am",
+			summary: "Review",
+			start:   at(10, 29, 9, 0),
+			end:     at(10, 29, 10, 30),
+		},
+		{
+			phrase:  "Call with Alex on friday from 11 to 1pm",
+			summary: "Call with Alex",
+			start:   at(10, 24, 11, 0),
+			end:     at(10, 24, 13, 0),
+		},
+		{
+			phrase:  "Release party 22:00-01:00 today",
+			summary: "Release party",
+			start:   at(10, 22, 22, 0),
+			end:     at(10, 23, 1, 0),
+		},
+		{
+			phrase:     "Conference nov 3 for 2 days #travel #work",
+			summary:    "Conference",
+			categories: []string{"travel", "work"},
+			start:      time.Date(2025, 11, 3, 0, 0, 0, 0, time.UTC),
+			end:        time.Date(2025, 11, 5, 0, 0, 0, 0, time.UTC),
+			allDay:     true,
+		},
+		{
+			// dates without a year that already passed are next year's
+			phrase:  "Birthday 3rd march yearly",
+			summary: "Birthday",
+			start:   time.Date(2026, 3, 3, 0, 0, 0, 0, time.UTC),
+			end:     time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC),
+			allDay:  true,
+			rrule:   "FREQ=YEARLY",
+		},
+		{
+			phrase:  `"Meet at noon" planning in 2 weeks at noon`,
+			summary: "Meet at noon planning",
+			start:   at(11, 5, 12, 0),
+			end:     at(11, 5, 13, 0),
+		},
+		{
+			phrase:  "Dinner at Joe's 2025-12-24 7:30pm",
+			summary: "Dinner at Joe's",
+			start:   at(12, 24, 19, 30),
+			end:     at(12, 24, 20, 30),
+		},
+		{
+			phrase:  "Rent every 1 month all day",
+			summary: "Rent",
+			start:   time.Date(2025, 10, 22, 0, 0, 0, 0, time.UTC),
+			end:     time.Date(2025, 10, 23, 0, 0, 0, 0, time.UTC),
+			allDay:  true,
+			rrule:   "FREQ=MONTHLY;INTERVAL=1",
+		},
+		{
+			// ranges without a colon, meridiem, at or from are not times
+			phrase:  "Read chapters 3-5 tomorrow",
+			summary: "Read chapters 3-5",
+			start:   time.Date(2025, 10, 23, 0, 0, 0, 0, time.UTC),
+			end:     time.Date(2025, 10, 24, 0, 0, 0, 0, time.UTC),
+			allDay:  true,
+		},
+		{
+			phrase:  "Meet 6-9am",
+			summary: "Meet",
+			start:   at(10, 22, 6, 0),
+			end:     at(10, 22, 9, 0),
+		},
+		{
+			phrase:  "Picnic in the sun tomorrow",
+			summary: "Picnic in the sun",
+			start:   time.Date(2025, 10, 23, 0, 0, 0, 0, time.UTC),
+			end:     time.Date(2025, 10, 24, 0, 0, 0, 0, time.UTC),
+			allDay:  true,
+		},
+		{
+			phrase:  "SAT prep saturday 9am",
+			summary: "SAT prep",
+			start:   at(10, 25, 9, 0),
+			end:     at(10, 25, 10, 0),
+		},
+		{
+			phrase:  "Brunch on sun 11am",
+			summary: "Brunch",
+			start:   at(10, 26, 11, 0),
+			end:     at(10, 26, 12, 0),
+		},
+		{
+			// bare hours from 1 to 7 are in the afternoon
+			phrase:  "Dinner at 7",
+			summary: "Dinner",
+			start:   at(10, 22, 19, 0),
+			end:     at(10, 22, 20, 0),
+		},
+		{
+			phrase:  "Call mom 5p.m. tomorrow",
+			summary: "Call mom",
+			start:   at(10, 23, 17, 0),
+			end:     at(10, 23, 18, 0),
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.phrase, func(t *testing.T) {
+			e, err := Parse(c.phrase, now, losAngeles)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if e.Summary == nil || *e.Summary != c.summary {
+				t.Errorf("expected summary %q, got %v", c.summary, e.Summary)
+			}
+			location := ""
+			if e.Location != nil {
+				location = *e.Location
+			}
+			if location != c.location {
+				t.Errorf("expected location %q, got %q", c.location, location)
+			}
+			if !slices.Equal(e.Categories, c.categories) {
+				t.Errorf("expected categories %v, got %v", c.categories, e.Categories)
+			}
+			if !e.Start.Stamp.Equal(c.start) || !e.End.Stamp.Equal(c.end) {
+				t.Errorf("expected %v-%v, got %v-%v", c.start, c.end, e.Start.Stamp, e.End.Stamp)
+			}
+			if e.Start.AllDay != c.allDay || e.End.AllDay != c.allDay {
+				t.Errorf("expected all day to be %v, got %+v-%+v", c.allDay, e.Start, e.End)
+			}
+			rule := ""
+			if e.RecurrenceRule.RRule != nil {
+				rule = e.RecurrenceRule.OrigOptions.RRuleString()
+			}
+			if rule != c.rrule {
+				t.Errorf("expected rrule %q, got %q", c.rrule, rule)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	cases := []struct {
+		phrase string
+		err    error
+	}{
+		{phrase: "tomorrow 9am"},
+		{phrase: "Something @somewhere", err: ErrNoTime},
+		{phrase: "Lunch today tomorrow"},
+		{phrase: "Lunch 9am 10am"},
+		{phrase: "Lunch 9am for 1h all day"},
+		{phrase: "Trip today for 3h all day"},
+		{phrase: "Lunch 9-10am for 1h"},
+		{phrase: `Lunch "today 9am`},
+		{phrase: "Lunch feb 30"},
+	}
+	for _, c := range cases {
+		_, err := Parse(c.phrase, now, losAngeles)
+		if err == nil {
+			t.Errorf("%q: expected an error", c.phrase)
+			continue
+		}
+		if c.err != nil && !errors.Is(err, c.err) {
+			t.Errorf("%q: expected %v, got %v", c.phrase, c.err, err)
+		}
+	}
+	if _, err := Parse("tomorrow 9am", now, losAngeles); !errors.Is(err, ErrNoSummary) {
+		t.Errorf("expected ErrNoSummary, got %v", err)
+	}
+}
+
+func TestParseRecurrenceStartsOnFirstOccurrence(t *testing.T) {
+	e, err := Parse("Yoga every tue, sat at 7am", now, losAngeles)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the first tuesday or saturday after wednesday
+	if !e.Start.Stamp.Equal(at(10, 25, 7, 0)) {
+		t.Fatalf("unexpected start %v", e.Start.Stamp)
+	}
+	if days := e.RecurrenceRule.OrigOptions.Byweekday; !slices.Equal(days, []rrule.Weekday{rrule.TU, rrule.SA}) {
+		t.Fatalf("unexpected weekdays %v", days)
+	}
+}
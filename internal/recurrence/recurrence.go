@@ -0,0 +1,267 @@
+// Package recurrence expands the occurrences of recurring events as defined by
+// RFC 5545 (and the EXRULE property of RFC 2445).
+package recurrence
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/teambition/rrule-go"
+)
+
+// Period is an occurrence added with an RDATE period, it has its own end
+// rather than the duration of the event.
+type Period struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Set is the recurrence set of an event.
+type Set struct {
+	// Start is the DTSTART of the event, it should be in the event's own
+	// location so that occurrences keep their wall clock time across DST
+	// transitions.
+	Start time.Time
+	// Days and Duration are the length of each occurrence, Days are nominal
+	// (they keep the wall clock time) and Duration is exact.
+	Days     int
+	Duration time.Duration
+	// AllDay compares the dates of occurrences rather than their times.
+	AllDay bool
+	// RRules and ExRules are the options of the RRULE and EXRULE properties,
+	// their DTSTART is always the event's start.
+	RRules   []rrule.ROption
+	ExRules  []rrule.ROption
+	RDates   []time.Time
+	RPeriods []Period
+	ExDates  []time.Time
+}
+
+// Override is a recurrence instance that is overridden by another VEVENT.
+type Override struct {
+	RecurrenceID time.Time
+	// ThisAndFuture is set if the override also applies to all the later
+	// instances (RANGE=THISANDFUTURE), they are shifted and resized the same
+	// way as the overridden instance.
+	ThisAndFuture bool
+	Start         time.Time
+	End           time.Time
+}
+
+// Instance is a single occurrence of a recurring event.
+type Instance struct {
+	RecurrenceID time.Time
+	Start        time.Time
+	End          time.Time
+	// Override is the index of the override the instance comes from, -1 if it
+	// is an occurrence of the main event.
+	Override int
+}
+
+// end returns the end of an occurrence of the event.
+func (s Set) end(start time.Time) time.Time {
+	return start.AddDate(0, 0, s.Days).Add(s.Duration)
+}
+
+// key identifies an occurrence, all-day occurrences are identified by their
+// date.
+func (s Set) key(t time.Time) int64 {
+	if s.AllDay {
+		t = t.In(s.Start.Location())
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC).Unix()
+	}
+	return t.Unix()
+}
+
+// shift moves t by the difference between from and to, whole days are moved
+// nominally so that shifted instances keep their wall clock time.
+func shift(t, from, to time.Time) time.Time {
+	loc := t.Location()
+	from = from.In(loc)
+	to = to.In(loc)
+	a := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	b := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	days := int(b.Sub(a) / (24 * time.Hour))
+	return t.AddDate(0, 0, days).Add(to.Sub(from.AddDate(0, 0, days)))
+}
+
+func abs(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
+
+// occurrence is the start of an occurrence, with its own end if it comes
+// from an RDATE period.
+type occurrence struct {
+	start time.Time
+	end   *time.Time
+}
+
+// between returns the occurrences of the set whose start is within [from, to]
+// sorted by their start.
+func (s Set) between(from, to time.Time) (out []occurrence, err error) {
+	seen := make(map[int64]bool)
+	add := func(o occurrence) {
+		if o.start.Before(from) || o.start.After(to) {
+			return
+		}
+		k := s.key(o.start)
+		if seen[k] {
+			return
+		}
+		seen[k] = true
+		out = append(out, o)
+	}
+
+	excluded := make(map[int64]bool)
+	for _, d := range s.ExDates {
+		excluded[s.key(d)] = true
+	}
+	for i, opts := range s.ExRules {
+		opts.Dtstart = s.Start
+		rule, err := rrule.NewRRule(opts)
+		if err != nil {
+			return nil, fmt.Errorf("exception rule %d: %w", i, err)
+		}
+		for _, t := range rule.Between(from, to, true) {
+			excluded[s.key(t)] = true
+		}
+	}
+	for k := range excluded {
+		seen[k] = true
+	}
+
+	// the start of the event is always its first occurrence
+	add(occurrence{start: s.Start})
+	for i, opts := range s.RRules {
+		opts.Dtstart = s.Start
+		rule, err := rrule.NewRRule(opts)
+		if err != nil {
+			return nil, fmt.Errorf("recurrence rule %d: %w", i, err)
+		}
+		for _, t := range rule.Between(from, to, true) {
+			add(occurrence{start: t})
+		}
+	}
+	for _, d := range s.RDates {
+		add(occurrence{start: d})
+	}
+	for _, p := range s.RPeriods {
+		end := p.End
+		add(occurrence{start: p.Start, end: &end})
+	}
+
+	slices.SortFunc(out, func(a, b occurrence) int {
+		return a.start.Compare(b.start)
+	})
+	return
+}
+
+// Contains reports whether an occurrence of the set starts at t, excluded
+// occurrences are not part of the set.
+func (s Set) Contains(t time.Time) (bool, error) {
+	occurrences, err := s.between(t.Add(-48*time.Hour), t.Add(48*time.Hour))
+	if err != nil {
+		return false, err
+	}
+	for _, occ := range occurrences {
+		if s.key(occ.start) == s.key(t) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Expand returns the instances of the set that overlap [from, to] sorted by
+// their start, with the given overrides applied. Overrides that do not
+// match an occurrence of the set are still returned as instances.
+func (s Set) Expand(overrides []Override, from, to time.Time) (out []Instance, err error) {
+	if to.Before(from) {
+		return nil, fmt.Errorf("expand: end %v is before start %v", to, from)
+	}
+
+	// occurrences that start outside of the window may still overlap it, or
+	// be moved into it by an override
+	margin := abs(s.end(s.Start).Sub(s.Start)) + 48*time.Hour
+	for _, p := range s.RPeriods {
+		margin = max(margin, abs(p.End.Sub(p.Start)))
+	}
+	for _, o := range overrides {
+		margin = max(margin, abs(o.Start.Sub(o.RecurrenceID))+abs(o.End.Sub(o.Start)))
+	}
+	occurrences, err := s.between(from.Add(-margin), to.Add(margin))
+	if err != nil {
+		return
+	}
+
+	exact := make(map[int64]int)
+	var future []int
+	for i, o := range overrides {
+		exact[s.key(o.RecurrenceID)] = i
+		if o.ThisAndFuture {
+			future = append(future, i)
+		}
+	}
+	slices.SortFunc(future, func(a, b int) int {
+		return overrides[a].RecurrenceID.Compare(overrides[b].RecurrenceID)
+	})
+
+	matched := make(map[int]bool)
+	for _, occ := range occurrences {
+		inst := Instance{RecurrenceID: occ.start, Start: occ.start, Override: -1}
+		if occ.end != nil {
+			inst.End = *occ.end
+		} else {
+			inst.End = s.end(occ.start)
+		}
+
+		if i, ok := exact[s.key(occ.start)]; ok {
+			matched[i] = true
+			inst.Start = overrides[i].Start
+			inst.End = overrides[i].End
+			inst.Override = i
+		} else {
+			// the latest THISANDFUTURE override before the occurrence applies
+			for j := len(future) - 1; j >= 0; j-- {
+				o := overrides[future[j]]
+				if o.RecurrenceID.After(occ.start) {
+					continue
+				}
+				inst.Start = shift(occ.start, o.RecurrenceID, o.Start)
+				inst.End = inst.Start.Add(o.End.Sub(o.Start))
+				inst.Override = future[j]
+				break
+			}
+		}
+		out = append(out, inst)
+	}
+	for i, o := range overrides {
+		if matched[i] {
+			continue
+		}
+		out = append(out, Instance{
+			RecurrenceID: o.RecurrenceID,
+			Start:        o.Start,
+			End:          o.End,
+			Override:     i,
+		})
+	}
+
+	out = slices.DeleteFunc(out, func(inst Instance) bool {
+		if inst.Start.After(to) {
+			return true
+		}
+		// instances without a duration are kept if they start in the window
+		return !inst.End.After(from) && inst.Start.Before(from)
+	})
+	slices.SortStableFunc(out, func(a, b Instance) int {
+		if c := a.Start.Compare(b.Start); c != 0 {
+			return c
+		}
+		return a.RecurrenceID.Compare(b.RecurrenceID)
+	})
+	return
+}
@@ -0,0 +1,415 @@
+package recurrence
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/teambition/rrule-go"
+)
+
+var newYork = func() *time.Location {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		panic(err)
+	}
+	return loc
+}()
+
+// at parses a local time in New York.
+func at(t *testing.T, s string) time.Time {
+	t.Helper()
+	parsed, err := time.ParseInLocation("20060102T150405", s, newYork)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return parsed
+}
+
+func rules(t *testing.T, values []string) []rrule.ROption {
+	t.Helper()
+	out := make([]rrule.ROption, len(values))
+	for i, v := range values {
+		opts, err := rrule.StrToROptionInLocation(v, newYork)
+		if err != nil {
+			t.Fatal(err)
+		}
+		out[i] = *opts
+	}
+	return out
+}
+
+func times(t *testing.T, values []string) []time.Time {
+	t.Helper()
+	out := make([]time.Time, len(values))
+	for i, v := range values {
+		out[i] = at(t, v)
+	}
+	return out
+}
+
+// TestExpandConformance checks the examples of RFC 5545 section 3.8.5.3, all
+// starting in America/New_York.
+func TestExpandConformance(t *testing.T) {
+	cases := []struct {
+		name    string
+		start   string
+		rrules  []string
+		exrules []string
+		rdates  []string
+		exdates []string
+		// to defaults to the start of 2001
+		to string
+		// expected is a prefix of the instances if count is larger
+		expected []string
+		count    int
+		last     string
+	}{
+		{
+			name:   "daily for 10 occurrences",
+			start:  "19970902T090000",
+			rrules: []string{"FREQ=DAILY;COUNT=10"},
+			expected: []string{
+				"19970902T090000", "19970903T090000", "19970904T090000",
+				"19970905T090000", "19970906T090000", "19970907T090000",
+				"19970908T090000", "19970909T090000", "19970910T090000",
+				"19970911T090000",
+			},
+		},
+		{
+			name:     "daily until December 24 across the end of DST",
+			start:    "19970902T090000",
+			rrules:   []string{"FREQ=DAILY;UNTIL=19971224T000000Z"},
+			expected: []string{"19970902T090000", "19970903T090000"},
+			count:    113,
+			last:     "19971223T090000",
+		},
+		{
+			name:   "every 10 days, 5 occurrences",
+			start:  "19970902T090000",
+			rrules: []string{"FREQ=DAILY;INTERVAL=10;COUNT=5"},
+			expected: []string{
+				"19970902T090000", "19970912T090000", "19970922T090000",
+				"19971002T090000", "19971012T090000",
+			},
+		},
+		{
+			name:   "weekly for 10 occurrences",
+			start:  "19970902T090000",
+			rrules: []string{"FREQ=WEEKLY;COUNT=10"},
+			expected: []string{
+				"19970902T090000", "19970909T090000", "19970916T090000",
+				"19970923T090000", "19970930T090000", "19971007T090000",
+				"19971014T090000", "19971021T090000", "19971028T090000",
+				"19971104T090000",
+			},
+		},
+		{
+			name:   "weekly on Tuesday and Thursday for five weeks",
+			start:  "19970902T090000",
+			rrules: []string{"FREQ=WEEKLY;UNTIL=19971007T000000Z;WKST=SU;BYDAY=TU,TH"},
+			expected: []string{
+				"19970902T090000", "19970904T090000", "19970909T090000",
+				"19970911T090000", "19970916T090000", "19970918T090000",
+				"19970923T090000", "19970925T090000", "19970930T090000",
+				"19971002T090000",
+			},
+		},
+		{
+			name:   "every other week on Tuesday and Sunday, week starting on Monday",
+			start:  "19970805T090000",
+			rrules: []string{"FREQ=WEEKLY;INTERVAL=2;COUNT=4;BYDAY=TU,SU;WKST=MO"},
+			expected: []string{
+				"19970805T090000", "19970810T090000", "19970819T090000",
+				"19970824T090000",
+			},
+		},
+		{
+			name:   "every other week on Tuesday and Sunday, week starting on Sunday",
+			start:  "19970805T090000",
+			rrules: []string{"FREQ=WEEKLY;INTERVAL=2;COUNT=4;BYDAY=TU,SU;WKST=SU"},
+			expected: []string{
+				"19970805T090000", "19970817T090000", "19970819T090000",
+				"19970831T090000",
+			},
+		},
+		{
+			name:   "monthly on the first Friday for 10 occurrences",
+			start:  "19970905T090000",
+			rrules: []string{"FREQ=MONTHLY;COUNT=10;BYDAY=1FR"},
+			expected: []string{
+				"19970905T090000", "19971003T090000", "19971107T090000",
+				"19971205T090000", "19980102T090000", "19980206T090000",
+				"19980306T090000", "19980403T090000", "19980501T090000",
+				"19980605T090000",
+			},
+		},
+		{
+			name:   "monthly on the second-to-last Monday for 6 months",
+			start:  "19970922T090000",
+			rrules: []string{"FREQ=MONTHLY;COUNT=6;BYDAY=-2MO"},
+			expected: []string{
+				"19970922T090000", "19971020T090000", "19971117T090000",
+				"19971222T090000", "19980119T090000", "19980216T090000",
+			},
+		},
+		{
+			name:   "last work day of the month",
+			start:  "19970930T090000",
+			rrules: []string{"FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1"},
+			to:     "19980401T000000",
+			expected: []string{
+				"19970930T090000", "19971031T090000", "19971128T090000",
+				"19971231T090000", "19980130T090000", "19980227T090000",
+				"19980331T090000",
+			},
+		},
+		{
+			name:   "yearly in June and July for 10 occurrences",
+			start:  "19970610T090000",
+			rrules: []string{"FREQ=YEARLY;COUNT=10;BYMONTH=6,7"},
+			to:     "20020101T000000",
+			expected: []string{
+				"19970610T090000", "19970710T090000", "19980610T090000",
+				"19980710T090000", "19990610T090000", "19990710T090000",
+				"20000610T090000", "20000710T090000", "20010610T090000",
+				"20010710T090000",
+			},
+		},
+		{
+			name:    "every Friday the 13th, excluding the start",
+			start:   "19970902T090000",
+			rrules:  []string{"FREQ=MONTHLY;BYDAY=FR;BYMONTHDAY=13"},
+			exdates: []string{"19970902T090000"},
+			expected: []string{
+				"19980213T090000", "19980313T090000", "19981113T090000",
+				"19990813T090000", "20001013T090000",
+			},
+		},
+		{
+			name:   "US presidential election day",
+			start:  "19961105T090000",
+			rrules: []string{"FREQ=YEARLY;INTERVAL=4;BYMONTH=11;BYDAY=TU;BYMONTHDAY=2,3,4,5,6,7,8"},
+			to:     "20050101T000000",
+			expected: []string{
+				"19961105T090000", "20001107T090000", "20041102T090000",
+			},
+		},
+		{
+			name:   "multiple recurrence rules",
+			start:  "19970901T090000",
+			rrules: []string{"FREQ=WEEKLY;BYDAY=MO;COUNT=2", "FREQ=WEEKLY;BYDAY=WE;COUNT=2"},
+			expected: []string{
+				"19970901T090000", "19970903T090000", "19970908T090000",
+				"19970910T090000",
+			},
+		},
+		{
+			name:    "exception rule",
+			start:   "19970902T090000",
+			rrules:  []string{"FREQ=DAILY;COUNT=10"},
+			exrules: []string{"FREQ=WEEKLY;BYDAY=SA,SU"},
+			expected: []string{
+				"19970902T090000", "19970903T090000", "19970904T090000",
+				"19970905T090000", "19970908T090000", "19970909T090000",
+				"19970910T090000", "19970911T090000",
+			},
+		},
+		{
+			name:   "recurrence dates",
+			start:  "19970902T090000",
+			rdates: []string{"19970910T140000", "19970902T090000"},
+			expected: []string{
+				"19970902T090000", "19970910T140000",
+			},
+		},
+		{
+			name:   "UNTIL in the local time of the start",
+			start:  "19970902T090000",
+			rrules: []string{"FREQ=DAILY;UNTIL=19970905T090000"},
+			expected: []string{
+				"19970902T090000", "19970903T090000", "19970904T090000",
+				"19970905T090000",
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			set := Set{
+				Start:    at(t, c.start),
+				Duration: time.Hour,
+				RRules:   rules(t, c.rrules),
+				ExRules:  rules(t, c.exrules),
+				RDates:   times(t, c.rdates),
+				ExDates:  times(t, c.exdates),
+			}
+			to := "20010101T000000"
+			if c.to != "" {
+				to = c.to
+			}
+			instances, err := set.Expand(nil, at(t, "19960101T000000"), at(t, to))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			count := c.count
+			if count == 0 {
+				count = len(c.expected)
+			}
+			if len(instances) != count {
+				t.Fatalf("expected %d instances, got %d: %v", count, len(instances), instances)
+			}
+			for i, e := range times(t, c.expected) {
+				if !instances[i].Start.Equal(e) {
+					t.Fatalf("instance %d: expected %v, got %v", i, e, instances[i].Start)
+				}
+			}
+			if c.last != "" && !instances[len(instances)-1].Start.Equal(at(t, c.last)) {
+				t.Fatalf("expected last instance %s, got %v", c.last, instances[len(instances)-1].Start)
+			}
+			for _, inst := range instances {
+				if inst.End.Sub(inst.Start) != time.Hour || inst.Override != -1 {
+					t.Fatalf("unexpected instance %+v", inst)
+				}
+			}
+		})
+	}
+}
+
+func TestExpandRecurrencePeriods(t *testing.T) {
+	// RDATE;VALUE=PERIOD:19960403T020000Z/19960403T040000Z,19960404T010000Z/PT3H
+	start := time.Date(1996, 4, 2, 1, 0, 0, 0, time.UTC)
+	set := Set{
+		Start:    start,
+		Duration: time.Hour,
+		RPeriods: []Period{
+			{time.Date(1996, 4, 3, 2, 0, 0, 0, time.UTC), time.Date(1996, 4, 3, 4, 0, 0, 0, time.UTC)},
+			{time.Date(1996, 4, 4, 1, 0, 0, 0, time.UTC), time.Date(1996, 4, 4, 4, 0, 0, 0, time.UTC)},
+		},
+	}
+	instances, err := set.Expand(nil, start, start.AddDate(0, 0, 7))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []time.Duration{time.Hour, 2 * time.Hour, 3 * time.Hour}
+	if len(instances) != len(expected) {
+		t.Fatalf("unexpected instances %+v", instances)
+	}
+	for i, d := range expected {
+		if instances[i].End.Sub(instances[i].Start) != d {
+			t.Fatalf("instance %d: expected a duration of %v, got %+v", i, d, instances[i])
+		}
+	}
+}
+
+func TestExpandThisAndFuture(t *testing.T) {
+	set := Set{
+		Start:    at(t, "19970902T090000"),
+		Duration: time.Hour,
+		RRules:   rules(t, []string{"FREQ=WEEKLY;COUNT=10"}),
+	}
+	overrides := []Override{
+		// from September 30 on, the meeting starts an hour later and lasts
+		// half an hour
+		{
+			RecurrenceID:  at(t, "19970930T090000"),
+			ThisAndFuture: true,
+			Start:         at(t, "19970930T100000"),
+			End:           at(t, "19970930T103000"),
+		},
+		// a single later instance is moved to the next day
+		{
+			RecurrenceID: at(t, "19971014T090000"),
+			Start:        at(t, "19971015T090000"),
+			End:          at(t, "19971015T100000"),
+		},
+	}
+	instances, err := set.Expand(overrides, at(t, "19970920T000000"), at(t, "19971101T000000"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []struct {
+		start    string
+		dur      time.Duration
+		override int
+	}{
+		{"19970923T090000", time.Hour, -1},
+		{"19970930T100000", 30 * time.Minute, 0},
+		{"19971007T100000", 30 * time.Minute, 0},
+		{"19971015T090000", time.Hour, 1},
+		{"19971021T100000", 30 * time.Minute, 0},
+		// the shift keeps the wall clock time after DST ends
+		{"19971028T100000", 30 * time.Minute, 0},
+	}
+	if len(instances) != len(expected) {
+		t.Fatalf("unexpected instances %+v", instances)
+	}
+	for i, e := range expected {
+		inst := instances[i]
+		if !inst.Start.Equal(at(t, e.start)) || inst.End.Sub(inst.Start) != e.dur || inst.Override != e.override {
+			t.Fatalf("instance %d: expected %+v, got %+v", i, e, inst)
+		}
+	}
+}
+
+func TestExpandOverridesOutsideOfWindow(t *testing.T) {
+	set := Set{
+		Start:    at(t, "20250601T090000"),
+		Duration: 2 * time.Hour,
+		RRules:   rules(t, []string{"FREQ=DAILY;COUNT=30"}),
+	}
+	overrides := []Override{
+		// moved into the window from the day before
+		{
+			RecurrenceID: at(t, "20250609T090000"),
+			Start:        at(t, "20250610T200000"),
+			End:          at(t, "20250610T210000"),
+		},
+		// moved out of the window
+		{
+			RecurrenceID: at(t, "20250610T090000"),
+			Start:        at(t, "20250620T090000"),
+			End:          at(t, "20250620T100000"),
+		},
+	}
+	// the window starts during the instance of June 10
+	instances, err := set.Expand(overrides, at(t, "20250610T100000"), at(t, "20250611T000000"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(instances) != 1 || instances[0].Override != 0 {
+		t.Fatalf("unexpected instances %+v", instances)
+	}
+
+	instances, err = set.Expand(overrides[:1], at(t, "20250610T100000"), at(t, "20250611T000000"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(instances) != 2 ||
+		!instances[0].Start.Equal(at(t, "20250610T090000")) ||
+		instances[1].Override != 0 {
+		t.Fatalf("expected the instance overlapping the window, got %+v", instances)
+	}
+}
+
+func TestExpandAllDay(t *testing.T) {
+	set := Set{
+		Start:   at(t, "20250307T000000"),
+		Days:    1,
+		AllDay:  true,
+		RRules:  rules(t, []string{"FREQ=DAILY;COUNT=4"}),
+		ExDates: []time.Time{time.Date(2025, 3, 8, 0, 0, 0, 0, newYork)},
+	}
+	instances, err := set.Expand(nil, at(t, "20250301T000000"), at(t, "20250401T000000"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(instances) != 3 {
+		t.Fatalf("unexpected instances %+v", instances)
+	}
+	// March 9 is 23 hours long
+	if d := instances[1].End.Sub(instances[1].Start); d != 23*time.Hour {
+		t.Fatalf("unexpected duration %v of %+v", d, instances[1])
+	}
+}
@@ -4,8 +4,12 @@ import (
 	"fmt"
 	"io"
 	"reflect"
+	"time"
 
+	"github.com/LQR471814/nu_plugin_caldav/internal/events"
 	"github.com/ainvaltin/nu-plugin"
+	"github.com/ainvaltin/nu-plugin/syntaxshape"
+	"github.com/emersion/go-ical"
 )
 
 func tryCast[T any](val nu.Value) (T, error) {
@@ -64,3 +68,93 @@ func recvListInput[E any](call *nu.ExecCommand, mapping func(v nu.Value) (E, err
 		reflect.TypeOf(call.Input),
 	))
 }
+
+// todosFlag and journalsFlag include todos and journals along with events.
+var (
+	todosFlag = nu.Flag{
+		Long:    "todos",
+		Default: &falseNu,
+		Desc:    "Include todos, spanning from their start to their due (or at either if they only have one).",
+	}
+	journalsFlag = nu.Flag{
+		Long:    "journals",
+		Default: &falseNu,
+		Desc:    "Include journals as markers at their start.",
+	}
+)
+
+// kindsFlags reads the --todos and --journals flags into the component kinds
+// to include, events are always included.
+func kindsFlags(call *nu.ExecCommand) []string {
+	kinds := []string{ical.CompEvent}
+	v, ok := call.FlagValue("todos")
+	if ok && v.Value.(bool) {
+		kinds = append(kinds, ical.CompToDo)
+	}
+	v, ok = call.FlagValue("journals")
+	if ok && v.Value.(bool) {
+		kinds = append(kinds, ical.CompJournal)
+	}
+	return kinds
+}
+
+// floatingTimezoneFlag sets the timezone that floating times (times without a
+// timezone, including all-day dates) are resolved in.
+var floatingTimezoneFlag = nu.Flag{
+	Long:  "timezone",
+	Short: 't',
+	Shape: syntaxshape.String(),
+	Desc:  "The timezone (ex. America/New_York) floating times and all-day events are resolved in, defaults to the system timezone.",
+}
+
+// displayTimezoneFlag is floatingTimezoneFlag for the commands that render
+// times, which are also displayed in the timezone.
+var displayTimezoneFlag = nu.Flag{
+	Long:  "timezone",
+	Short: 't',
+	Shape: syntaxshape.String(),
+	Desc:  "The timezone (ex. America/New_York) times are displayed in, floating times and all-day events are also resolved in it, defaults to the system timezone.",
+}
+
+// stringFlag reads a string flag, val is nil if the flag is not set.
+func stringFlag(call *nu.ExecCommand, name string) (val *string, err error) {
+	v, ok := call.FlagValue(name)
+	if !ok {
+		return
+	}
+	s, err := tryCast[string](v)
+	if err != nil {
+		return
+	}
+	val = &s
+	return
+}
+
+// timezoneFlag reads the --timezone flag.
+func timezoneFlag(call *nu.ExecCommand) (loc *time.Location, err error) {
+	loc = time.Local
+	name, err := stringFlag(call, "timezone")
+	if err != nil || name == nil {
+		return
+	}
+	loc, err = events.LoadTimezone(*name, nil)
+	return
+}
+
+// durationFlag reads a duration flag, it returns fallback if the flag is not
+// set.
+func durationFlag(call *nu.ExecCommand, name string, fallback time.Duration) (dur time.Duration, err error) {
+	dur = fallback
+	v, ok := call.FlagValue(name)
+	if !ok {
+		return
+	}
+	dur, err = tryCast[time.Duration](v)
+	if err != nil {
+		return
+	}
+	if dur < 0 {
+		err = fmt.Errorf("--%s cannot be negative, got %v", name, dur)
+	}
+	return
+}
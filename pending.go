@@ -0,0 +1,133 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/LQR471814/nu_plugin_caldav/internal/db"
+	"github.com/emersion/go-webdav/caldav"
+)
+
+const (
+	pendingPut    = "put"
+	pendingDelete = "delete"
+)
+
+// pendingBase is the version of an object on the server that a new pending
+// operation is based on.
+type pendingBase struct {
+	calendarPath string
+	// etag is the ETag of the object on the server (if known)
+	etag sql.NullString
+	// unpushed is true if the object was created offline and has not been
+	// pushed to the server yet
+	unpushed bool
+}
+
+// supersedePending finds the base of a new pending operation on the given
+// object, removing the existing pending operation on the object (if any) as it
+// is superseded by the new operation.
+func supersedePending(ctx context.Context, txqry *db.Queries, objectPath string) (base pendingBase, err error) {
+	op, err := txqry.ReadObjectPendingOperation(ctx, objectPath)
+	if err == nil {
+		base = pendingBase{
+			calendarPath: op.CalendarPath,
+			etag:         op.Etag,
+			unpushed:     op.Kind == pendingPut && !op.Etag.Valid,
+		}
+		err = txqry.DeletePendingOperation(ctx, op.ID)
+		return
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return
+	}
+
+	cached, err := txqry.ReadEvent(ctx, objectPath)
+	if err == nil {
+		base = pendingBase{
+			calendarPath: cached.CalendarPath,
+			etag:         cached.Etag,
+		}
+		return
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return
+	}
+	err = nil
+	base = pendingBase{calendarPath: path.Dir(objectPath) + "/"}
+	return
+}
+
+// queuePut records a write of the given iCalendar data to be replayed by
+// `caldav push`.
+func queuePut(ctx context.Context, txqry *db.Queries, calendarPath, objectPath, ics string, now time.Time) (err error) {
+	base, err := supersedePending(ctx, txqry, objectPath)
+	if err != nil {
+		return
+	}
+	err = txqry.PutPendingOperation(ctx, db.PutPendingOperationParams{
+		Kind:         pendingPut,
+		CalendarPath: calendarPath,
+		ObjectPath:   objectPath,
+		Etag:         base.etag,
+		Ics:          sql.NullString{String: ics, Valid: true},
+		CreatedAt:    now.Unix(),
+	})
+	return
+}
+
+// queueDelete records a deletion of the given object to be replayed by
+// `caldav push`.
+func queueDelete(ctx context.Context, txqry *db.Queries, objectPath string, now time.Time) (err error) {
+	base, err := supersedePending(ctx, txqry, objectPath)
+	if err != nil {
+		return
+	}
+	// the object only exists locally, so dropping its pending put is enough
+	if base.unpushed {
+		return
+	}
+	err = txqry.PutPendingOperation(ctx, db.PutPendingOperationParams{
+		Kind:         pendingDelete,
+		CalendarPath: base.calendarPath,
+		ObjectPath:   objectPath,
+		Etag:         base.etag,
+		CreatedAt:    now.Unix(),
+	})
+	return
+}
+
+// readCachedObjects reads the given objects from the cache with their pending
+// operations applied.
+func readCachedObjects(ctx context.Context, qry *db.Queries, paths []string) (objects []caldav.CalendarObject, err error) {
+	objects = make([]caldav.CalendarObject, len(paths))
+	for i, p := range paths {
+		var obj caldav.CalendarObject
+		op, err := qry.ReadObjectPendingOperation(ctx, p)
+		switch {
+		case err == nil && op.Kind == pendingDelete:
+			return nil, fmt.Errorf("object %q has a pending delete", p)
+		case err == nil:
+			obj, err = decodeCachedObject(p, op.Etag, op.Ics.String)
+		case errors.Is(err, sql.ErrNoRows):
+			var cached db.ReadEventRow
+			cached, err = qry.ReadEvent(ctx, p)
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, fmt.Errorf("object %q is not cached", p)
+			}
+			if err != nil {
+				return nil, err
+			}
+			obj, err = decodeCachedObject(p, cached.Etag, cached.Ics)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read cached object %q: %w", p, err)
+		}
+		objects[i] = obj
+	}
+	return
+}
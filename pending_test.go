@@ -0,0 +1,194 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/LQR471814/nu_plugin_caldav/internal/dav"
+	"github.com/LQR471814/nu_plugin_caldav/internal/db"
+)
+
+func openTestCache(t *testing.T) (*sql.DB, *db.Queries) {
+	driver, qry, err := db.OpenFile(context.Background(), filepath.Join(t.TempDir(), "state.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { driver.Close() })
+	return driver, qry
+}
+
+func TestQueueDeleteOfUnpushedObjectDropsIt(t *testing.T) {
+	ctx := context.Background()
+	_, qry := openTestCache(t)
+	now := time.Now()
+
+	err := queuePut(ctx, qry, "/cal/", "/cal/new", "BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = queueDelete(ctx, qry, "/cal/new", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ops, err := qry.ReadPendingOperations(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ops) != 0 {
+		t.Fatalf("expected no pending operations, got %+v", ops)
+	}
+}
+
+func TestQueuePutKeepsBaseETag(t *testing.T) {
+	ctx := context.Background()
+	_, qry := openTestCache(t)
+	now := time.Now()
+
+	err := qry.PutEvent(ctx, db.PutEventParams{
+		Path:         "/cal/event",
+		CalendarPath: "/cal/",
+		Etag:         sql.NullString{String: "v1", Valid: true},
+		Ics:          "BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for range 2 {
+		err = queuePut(ctx, qry, "/cal/", "/cal/event", "BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n", now)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ops, err := qry.ReadPendingOperations(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ops) != 1 {
+		t.Fatalf("expected a single pending operation, got %+v", ops)
+	}
+	if ops[0].Etag.String != "v1" {
+		t.Fatalf("expected base etag v1, got %q", ops[0].Etag.String)
+	}
+}
+
+func TestPushOperationReportsConflict(t *testing.T) {
+	ctx := context.Background()
+	driver, qry := openTestCache(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-Match") != `"v2"` {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			return
+		}
+		w.Header().Set("ETag", `"v3"`)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+	client, err := dav.NewClient(server.Client(), server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	op := db.PendingOperation{
+		ID:           1,
+		Kind:         pendingPut,
+		CalendarPath: "/cal/",
+		ObjectPath:   "/cal/event",
+		Etag:         sql.NullString{String: "v1", Valid: true},
+		Ics:          sql.NullString{String: "BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n", Valid: true},
+	}
+	err = pushOperation(ctx, client, driver, qry, op, false)
+	if err == nil {
+		t.Fatal("expected conflict")
+	}
+	if !errors.Is(err, dav.ErrPreconditionFailed) {
+		t.Fatalf("expected ErrPreconditionFailed, got %v", err)
+	}
+
+	op.Etag.String = "v2"
+	err = pushOperation(ctx, client, driver, qry, op, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cached, err := qry.ReadEvent(ctx, "/cal/event")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cached.Etag.String != "v3" {
+		t.Fatalf("expected cache to be updated with etag v3, got %q", cached.Etag.String)
+	}
+}
+
+func TestPushOperationsStopAtFailure(t *testing.T) {
+	ctx := context.Background()
+	driver, qry := openTestCache(t)
+	now := time.Now()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/cal/conflict":
+			w.WriteHeader(http.StatusPreconditionFailed)
+		case "/cal/broken":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.Header().Set("ETag", `"v1"`)
+			w.WriteHeader(http.StatusCreated)
+		}
+	}))
+	defer server.Close()
+	client, err := dav.NewClient(server.Client(), server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, p := range []string{"/cal/first", "/cal/conflict", "/cal/broken", "/cal/last"} {
+		err = queuePut(ctx, qry, "/cal/", p, "BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n", now)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	ops, err := qry.ReadPendingOperations(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	outcomes, err := pushOperations(ctx, client, driver, qry, ops, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]string{
+		"/cal/first":    outcomePushed,
+		"/cal/conflict": outcomeConflict,
+		"/cal/broken":   outcomeFailed,
+		"/cal/last":     outcomeSkipped,
+	}
+	for _, outcome := range outcomes {
+		if outcome.Outcome != expected[outcome.ObjectPath] {
+			t.Errorf("expected %s to be %s, got %+v", outcome.ObjectPath, expected[outcome.ObjectPath], outcome)
+		}
+	}
+
+	// only the pushed operation is removed from the queue, along with being
+	// cached
+	remaining, err := qry.ReadPendingOperations(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(remaining) != 3 {
+		t.Fatalf("expected 3 pending operations left, got %+v", remaining)
+	}
+	if _, err := qry.ReadEvent(ctx, "/cal/first"); err != nil {
+		t.Fatalf("expected the pushed object to be cached: %v", err)
+	}
+	if _, err := qry.ReadEvent(ctx, "/cal/last"); !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("expected the skipped object to not be cached, got %v", err)
+	}
+}
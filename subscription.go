@@ -0,0 +1,200 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/LQR471814/nu_plugin_caldav/internal/dav"
+	"github.com/LQR471814/nu_plugin_caldav/internal/db"
+	"github.com/LQR471814/nu_plugin_caldav/internal/events"
+	"github.com/emersion/go-ical"
+	"github.com/emersion/go-webdav/caldav"
+)
+
+// isSubscriptionURL returns true if the calendar path is the URL of an
+// iCalendar feed rather than the path of a CalDAV collection.
+func isSubscriptionURL(p string) bool {
+	for _, scheme := range []string{"http://", "https://", "webcal://", "webcals://"} {
+		if strings.HasPrefix(strings.ToLower(p), scheme) {
+			return true
+		}
+	}
+	return false
+}
+
+// newFeedClient returns the client used to fetch feeds, it does not use the
+// credentials of the CalDAV server as feeds are usually hosted elsewhere.
+func newFeedClient() *http.Client {
+	return &http.Client{Timeout: 30 * time.Second}
+}
+
+// feedResponse is the response to a conditional request for a feed.
+type feedResponse struct {
+	// feed is nil if the feed has not been modified
+	feed         *ical.Calendar
+	etag         string
+	lastModified string
+}
+
+// fetchFeed requests an iCalendar feed, the feed is only returned if it does
+// not match the given validators.
+func fetchFeed(ctx context.Context, client *http.Client, feedURL, etag, lastModified string) (out feedResponse, err error) {
+	u := feedURL
+	switch {
+	case strings.HasPrefix(strings.ToLower(u), "webcals://"):
+		u = "https://" + u[len("webcals://"):]
+	case strings.HasPrefix(strings.ToLower(u), "webcal://"):
+		u = "https://" + u[len("webcal://"):]
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return
+	}
+	req.Header.Set("Accept", "text/calendar")
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	if lastModified != "" {
+		req.Header.Set("If-Modified-Since", lastModified)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return
+	}
+	defer resp.Body.Close()
+
+	out.etag = etag
+	out.lastModified = lastModified
+	if resp.StatusCode == http.StatusNotModified {
+		return
+	}
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		err = &dav.HTTPError{
+			Code:    resp.StatusCode,
+			Message: strings.TrimSpace(string(msg)),
+		}
+		return
+	}
+	out.feed, err = ical.NewDecoder(resp.Body).Decode()
+	if err != nil {
+		err = fmt.Errorf("decode feed: %w", err)
+		return
+	}
+	out.etag = resp.Header.Get("ETag")
+	out.lastModified = resp.Header.Get("Last-Modified")
+	return
+}
+
+// feedObjectPath returns the path of the object containing the events with
+// the given UID in a feed.
+func feedObjectPath(feedURL, uid string) string {
+	return feedURL + "#" + uid
+}
+
+// splitFeed splits a feed into calendar objects, one for each UID (the main
+// event, todo or journal and its recurrence overrides), as CalDAV servers
+// would store them.
+func splitFeed(feedURL string, feed *ical.Calendar) (objects []caldav.CalendarObject) {
+	var timezones []*ical.Component
+	var uids []string
+	byUID := map[string]*ical.Calendar{}
+	for i, child := range feed.Children {
+		switch child.Name {
+		case ical.CompTimezone:
+			timezones = append(timezones, child)
+		case ical.CompEvent, ical.CompToDo, ical.CompJournal:
+			uid, err := child.Props.Text(ical.PropUID)
+			if err != nil || uid == "" {
+				uid = fmt.Sprintf("event-%d", i)
+			}
+			cal, ok := byUID[uid]
+			if !ok {
+				cal = events.NewCalendar()
+				byUID[uid] = cal
+				uids = append(uids, uid)
+			}
+			cal.Children = append(cal.Children, child)
+		}
+	}
+	for _, uid := range uids {
+		cal := byUID[uid]
+		cal.Children = append(slices.Clone(timezones), cal.Children...)
+		objects = append(objects, caldav.CalendarObject{
+			Path: feedObjectPath(feedURL, uid),
+			Data: cal,
+		})
+	}
+	return
+}
+
+// fetchFeedObjects requests a feed without going through the cache, the name
+// of the calendar is read from the feed's X-WR-CALNAME.
+func fetchFeedObjects(ctx context.Context, client *http.Client, feedURL string) (calendar calendarIdentity, objects []caldav.CalendarObject, err error) {
+	resp, err := fetchFeed(ctx, client, feedURL, "", "")
+	if err != nil {
+		return
+	}
+	var props dav.CalendarProperties
+	props.DisplayName, _ = resp.feed.Props.Text("X-WR-CALNAME")
+	calendar = newCalendarIdentity(feedURL, props)
+	objects = splitFeed(feedURL, resp.feed)
+	return
+}
+
+// syncSubscription refreshes the events of a subscription if its feed has
+// been modified.
+func (m syncManager) syncSubscription() (err error) {
+	sub, err := m.qry.ReadSubscription(m.ctx, m.calendarPath)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = fmt.Errorf("not subscribed to %q, use `caldav subscribe` first", m.calendarPath)
+		return
+	}
+	if err != nil {
+		return
+	}
+	resp, err := fetchFeed(m.ctx, m.feedClient, sub.Url, sub.Etag.String, sub.LastModified.String)
+	if err != nil {
+		return
+	}
+
+	m.writeMu.Lock()
+	defer m.writeMu.Unlock()
+
+	tx, err := m.driver.BeginTx(m.ctx, nil)
+	if err != nil {
+		return
+	}
+	defer tx.Rollback()
+	txqry := m.qry.WithTx(tx)
+
+	if resp.feed != nil {
+		err = m.store(txqry, syncResult{
+			replace: true,
+			updated: splitFeed(sub.Url, resp.feed),
+			props:   dav.CalendarProperties{DisplayName: sub.Name},
+		})
+		if err != nil {
+			return
+		}
+	}
+	err = txqry.PutSubscriptionValidators(m.ctx, db.PutSubscriptionValidatorsParams{
+		Etag:         sql.NullString{String: resp.etag, Valid: resp.etag != ""},
+		LastModified: sql.NullString{String: resp.lastModified, Valid: resp.lastModified != ""},
+		RefreshedAt:  sql.NullInt64{Int64: time.Now().Unix(), Valid: true},
+		Url:          sub.Url,
+	})
+	if err != nil {
+		return
+	}
+	err = tx.Commit()
+	return
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/LQR471814/nu_plugin_caldav/internal/db"
+	"github.com/emersion/go-ical"
+)
+
+const feedIcs = "BEGIN:VCALENDAR\r\n" +
+	"VERSION:2.0\r\n" +
+	"PRODID:-//test//test//EN\r\n" +
+	"X-WR-CALNAME:Holidays\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:weekly\r\n" +
+	"DTSTAMP:20250101T000000Z\r\n" +
+	"DTSTART:20250101T100000Z\r\n" +
+	"DTEND:20250101T110000Z\r\n" +
+	"RRULE:FREQ=WEEKLY;COUNT=4\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:once\r\n" +
+	"DTSTAMP:20250101T000000Z\r\n" +
+	"DTSTART:20250102T100000Z\r\n" +
+	"DTEND:20250102T110000Z\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:weekly\r\n" +
+	"DTSTAMP:20250101T000000Z\r\n" +
+	"RECURRENCE-ID:20250108T100000Z\r\n" +
+	"DTSTART:20250108T120000Z\r\n" +
+	"DTEND:20250108T130000Z\r\n" +
+	"END:VEVENT\r\n" +
+	"END:VCALENDAR\r\n"
+
+func TestSplitFeed(t *testing.T) {
+	feed, err := ical.NewDecoder(strings.NewReader(feedIcs)).Decode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	objects := splitFeed("https://example.com/feed.ics", feed)
+	if len(objects) != 2 {
+		t.Fatalf("expected 2 objects, got %d", len(objects))
+	}
+	if objects[0].Path != "https://example.com/feed.ics#weekly" {
+		t.Fatalf("unexpected object path %q", objects[0].Path)
+	}
+	if len(objects[0].Data.Children) != 2 {
+		t.Fatalf("expected the override to be grouped with its event, got %d components", len(objects[0].Data.Children))
+	}
+
+	tasks := "BEGIN:VCALENDAR\r\n" +
+		"VERSION:2.0\r\n" +
+		"PRODID:-//test//test//EN\r\n" +
+		"BEGIN:VTODO\r\n" +
+		"UID:task\r\n" +
+		"DTSTAMP:20250101T000000Z\r\n" +
+		"DUE:20250102T100000Z\r\n" +
+		"END:VTODO\r\n" +
+		"BEGIN:VJOURNAL\r\n" +
+		"UID:entry\r\n" +
+		"DTSTAMP:20250101T000000Z\r\n" +
+		"DTSTART:20250102T100000Z\r\n" +
+		"END:VJOURNAL\r\n" +
+		"END:VCALENDAR\r\n"
+	feed, err = ical.NewDecoder(strings.NewReader(tasks)).Decode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	objects = splitFeed("https://example.com/tasks.ics", feed)
+	if len(objects) != 2 || objects[0].Data.Children[0].Name != ical.CompToDo ||
+		objects[1].Data.Children[0].Name != ical.CompJournal {
+		t.Fatalf("expected the todo and the journal to be kept, got %+v", objects)
+	}
+}
+
+func TestSyncSubscriptionUsesConditionalRequests(t *testing.T) {
+	ctx := context.Background()
+	driver, qry := openTestCache(t)
+
+	var notModified atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Content-Type", "text/calendar")
+		w.Write([]byte(feedIcs))
+	}))
+	defer server.Close()
+
+	url := server.URL + "/feed.ics"
+	err := qry.PutSubscription(ctx, db.PutSubscriptionParams{
+		Url:  url,
+		Name: "Holidays",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for range 2 {
+		err = syncCalendars(ctx, nil, nil, driver, qry, []string{url}, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		calendars, err := qry.ReadCachedCalendars(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(calendars) != 1 || calendars[0].ObjectCount != 2 {
+			t.Fatalf("expected 1 calendar with 2 objects, got %+v", calendars)
+		}
+	}
+	if notModified.Load() != 1 {
+		t.Fatalf("expected the second sync to be a conditional request")
+	}
+
+	props, err := qry.ReadCalendarProperties(ctx, url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if props.DisplayName.String != "Holidays" {
+		t.Fatalf("unexpected calendar name %q", props.DisplayName.String)
+	}
+	sub, err := qry.ReadSubscription(ctx, url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sub.Etag.String != `"v1"` || !sub.RefreshedAt.Valid {
+		t.Fatalf("unexpected validators %+v", sub)
+	}
+}
+
+func TestSyncUnknownSubscription(t *testing.T) {
+	ctx := context.Background()
+	driver, qry := openTestCache(t)
+	err := syncCalendars(ctx, nil, nil, driver, qry, []string{"https://example.com/feed.ics"}, 1)
+	if err == nil || !strings.Contains(err.Error(), "caldav subscribe") {
+		t.Fatalf("expected an error asking to subscribe, got %v", err)
+	}
+}
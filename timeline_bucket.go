@@ -0,0 +1,219 @@
+package main
+
+import (
+	"slices"
+	"time"
+
+	"github.com/LQR471814/nu_plugin_caldav/internal/dto"
+)
+
+// segmentSink receives the segments of a timeline in order, flush is called
+// once after the last one.
+type segmentSink interface {
+	add(segment dto.TimeSegment) error
+	flush() error
+}
+
+// eventKey identifies an event across the segments of a timeline, which hold
+// copies of it, by its UID and the instance it is of. Events without a UID
+// are identified by their summary, start and end instead.
+type eventKey struct {
+	uid      string
+	summary  string
+	instance int64
+	end      int64
+}
+
+func newEventKey(e dto.Event) eventKey {
+	key := eventKey{instance: e.Start.Stamp.UnixNano()}
+	if e.RecurrenceInstance != nil {
+		key.instance = e.RecurrenceInstance.Stamp.UnixNano()
+	}
+	if e.Uid != nil {
+		key.uid = *e.Uid
+		return key
+	}
+	if e.Summary != nil {
+		key.summary = *e.Summary
+	}
+	key.end = e.End.Stamp.UnixNano()
+	return key
+}
+
+// addEvents adds the events that are not in a list yet to it, seen holds the
+// keys of the events of the list.
+func addEvents(list []dto.Event, seen map[eventKey]bool, events []dto.Event) []dto.Event {
+	for _, e := range events {
+		key := newEventKey(e)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		list = append(list, e)
+	}
+	return list
+}
+
+// busyMerger collapses consecutive busy segments into one.
+type busyMerger struct {
+	emit    func(dto.TimeSegment) error
+	current *dto.TimeSegment
+	// seen are the keys of the active events of the current segment
+	seen map[eventKey]bool
+}
+
+func (m *busyMerger) add(segment dto.TimeSegment) error {
+	if m.current != nil && m.current.Busy > 0 && segment.Busy > 0 {
+		m.current.Duration += segment.Duration
+		m.current.ActiveEvents = addEvents(m.current.ActiveEvents, m.seen, segment.ActiveEvents)
+		m.current.Warnings = append(m.current.Warnings, segment.Warnings...)
+		m.current.ImpossibleTransitions = append(m.current.ImpossibleTransitions, segment.ImpossibleTransitions...)
+		return nil
+	}
+	err := m.flush()
+	if err != nil {
+		return err
+	}
+	m.seen = map[eventKey]bool{}
+	segment.ActiveEvents = addEvents(nil, m.seen, segment.ActiveEvents)
+	segment.Warnings = slices.Clone(segment.Warnings)
+	segment.ImpossibleTransitions = slices.Clone(segment.ImpossibleTransitions)
+	m.current = &segment
+	return nil
+}
+
+func (m *busyMerger) flush() error {
+	if m.current == nil {
+		return nil
+	}
+	segment := *m.current
+	m.current = nil
+	return m.emit(segment)
+}
+
+// bucketer resamples segments into fixed size slots from the start of the
+// timeline, the last slot is cut short by the end of the timeline. Slots of
+// whole days keep their wall clock time across DST transitions in the
+// location of the start.
+type bucketer struct {
+	emit  func(dto.TimeSegment) error
+	start time.Time
+	end   time.Time
+	size  time.Duration
+
+	current *dto.TimeSegment
+	// seen are the keys of the active events of the current slot
+	seen map[eventKey]bool
+	// busy is the time of the current slot during which it is busy
+	busy time.Duration
+	done bool
+}
+
+func newBucketer(start, end time.Time, size time.Duration, emit func(dto.TimeSegment) error) *bucketer {
+	return &bucketer{
+		emit:  emit,
+		start: start,
+		end:   end,
+		size:  size,
+	}
+}
+
+// slotEnd returns the end of the slot that starts at t.
+func (b *bucketer) slotEnd(t time.Time) time.Time {
+	if b.size%(24*time.Hour) == 0 {
+		return t.AddDate(0, 0, int(b.size/(24*time.Hour)))
+	}
+	return t.Add(b.size)
+}
+
+// next emits the current slot and opens the one after it, it returns false
+// once the end of the timeline is reached.
+func (b *bucketer) next() (bool, error) {
+	if b.done {
+		return false, nil
+	}
+	now := b.start
+	if b.current != nil {
+		now = b.current.Now.Add(b.current.Duration)
+		if b.current.Duration > 0 {
+			b.current.Busy = float64(b.busy) / float64(b.current.Duration)
+		}
+		err := b.emit(*b.current)
+		if err != nil {
+			return false, err
+		}
+		if !now.Before(b.end) {
+			b.done = true
+			return false, nil
+		}
+	}
+	slotEnd := b.slotEnd(now)
+	if slotEnd.After(b.end) {
+		slotEnd = b.end
+	}
+	b.current = &dto.TimeSegment{Now: now, Duration: slotEnd.Sub(now)}
+	b.seen = map[eventKey]bool{}
+	b.busy = 0
+	return true, nil
+}
+
+func (b *bucketer) add(segment dto.TimeSegment) error {
+	if b.done {
+		return nil
+	}
+	if b.current == nil {
+		_, err := b.next()
+		if err != nil {
+			return err
+		}
+	}
+	// a segment that starts on the boundary of a slot belongs to the next
+	// one, unless the slot is the last
+	for {
+		slotEnd := b.current.Now.Add(b.current.Duration)
+		if segment.Now.Before(slotEnd) || !slotEnd.Before(b.end) {
+			break
+		}
+		ok, err := b.next()
+		if err != nil || !ok {
+			return err
+		}
+	}
+	t := segment.Now
+	segmentEnd := segment.Now.Add(segment.Duration)
+	b.current.Warnings = append(b.current.Warnings, segment.Warnings...)
+	b.current.ImpossibleTransitions = append(b.current.ImpossibleTransitions, segment.ImpossibleTransitions...)
+	for {
+		slotEnd := b.current.Now.Add(b.current.Duration)
+		pieceEnd := segmentEnd
+		if slotEnd.Before(pieceEnd) {
+			pieceEnd = slotEnd
+		}
+		piece := pieceEnd.Sub(t)
+		if piece > 0 || segment.Duration == 0 {
+			b.current.ActiveEvents = addEvents(b.current.ActiveEvents, b.seen, segment.ActiveEvents)
+			b.busy += time.Duration(float64(piece) * segment.Busy)
+			if b.current.Duration == 0 {
+				b.current.Busy = max(b.current.Busy, segment.Busy)
+			}
+		}
+		t = t.Add(piece)
+		if t.Before(slotEnd) || !t.Before(segmentEnd) {
+			return nil
+		}
+		ok, err := b.next()
+		if err != nil || !ok {
+			return err
+		}
+	}
+}
+
+// flush emits the remaining slots, including the ones without any segment.
+func (b *bucketer) flush() error {
+	for {
+		ok, err := b.next()
+		if err != nil || !ok {
+			return err
+		}
+	}
+}
@@ -0,0 +1,238 @@
+package main
+
+import (
+	"container/heap"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/LQR471814/nu_plugin_caldav/internal/dto"
+	"github.com/emersion/go-ical"
+)
+
+// eventSource yields events in the order of their start.
+type eventSource interface {
+	// peek returns the next event without consuming it, ok is false once
+	// there are no events left. Events that end before they start are
+	// skipped with a warning.
+	peek() (e dto.Event, ok bool)
+	// pop consumes the next event.
+	pop()
+	// warnings returns (and clears) the problems found with the events since
+	// it was last called.
+	warnings() []string
+}
+
+// endsBeforeStart returns the warning for an event that ends before it
+// starts, sources skip such events.
+func endsBeforeStart(e dto.Event) (warning string, invalid bool) {
+	if !e.End.Stamp.Before(e.Start.Stamp) {
+		return "", false
+	}
+	return fmt.Sprintf(
+		"skipped %s: its end %v is before its start %v",
+		describeEvent(e), e.End.Stamp, e.Start.Stamp,
+	), true
+}
+
+// sliceSource yields the events of a sorted slice.
+type sliceSource struct {
+	events  []dto.Event
+	i       int
+	pending []string
+}
+
+func (s *sliceSource) peek() (dto.Event, bool) {
+	for s.i < len(s.events) {
+		msg, invalid := endsBeforeStart(s.events[s.i])
+		if !invalid {
+			return s.events[s.i], true
+		}
+		s.pending = append(s.pending, msg)
+		s.i++
+	}
+	return dto.Event{}, false
+}
+
+func (s *sliceSource) pop() {
+	s.i++
+}
+
+func (s *sliceSource) warnings() []string {
+	out := s.pending
+	s.pending = nil
+	return out
+}
+
+// expandChunk is the length of the windows recurring events are lazily
+// expanded in.
+const expandChunk = 30 * 24 * time.Hour
+
+// occurrenceIter lazily expands the occurrences of an event object, one chunk
+// of the window at a time.
+type occurrenceIter struct {
+	index  int
+	object dto.EventObject
+	// chunkStart is the start of the next chunk to expand
+	chunkStart time.Time
+	end        time.Time
+	chunk      time.Duration
+	first      bool
+	done       bool
+	buffered   []dto.Occurrence
+}
+
+func recurs(e dto.Event) bool {
+	return e.RecurrenceRule.RRule != nil ||
+		len(e.ExtraRecurrenceRules) > 0 ||
+		len(e.RecurrenceDates) > 0 ||
+		len(e.RecurrencePeriods) > 0
+}
+
+func newOccurrenceIter(index int, object dto.EventObject, start, end time.Time) *occurrenceIter {
+	chunk := expandChunk
+	if !recurs(object.Main) {
+		chunk = end.Sub(start)
+	}
+	return &occurrenceIter{
+		index:      index,
+		object:     object,
+		chunkStart: start,
+		end:        end,
+		chunk:      chunk,
+		first:      true,
+	}
+}
+
+// fill expands chunks until an occurrence is buffered or the whole window has
+// been expanded. Occurrences belong to the chunk they start in, except for
+// the ones that start before the window but overlap it which belong to the
+// first chunk.
+func (it *occurrenceIter) fill() error {
+	for len(it.buffered) == 0 && !it.done {
+		chunkEnd := it.chunkStart.Add(it.chunk)
+		last := !chunkEnd.Before(it.end)
+		if last {
+			chunkEnd = it.end
+		}
+		occurrences, err := expandObject(it.object, it.chunkStart, chunkEnd)
+		if err != nil {
+			return fmt.Errorf("expand event object %d: %w", it.index, err)
+		}
+		for _, occ := range occurrences {
+			if !it.first && occ.Start.Before(it.chunkStart) {
+				continue
+			}
+			if !last && !occ.Start.Before(chunkEnd) {
+				continue
+			}
+			it.buffered = append(it.buffered, occ)
+		}
+		it.first = false
+		it.chunkStart = chunkEnd
+		it.done = last
+	}
+	return nil
+}
+
+// occurrenceHeap orders iterators by the start of their next occurrence.
+type occurrenceHeap []*occurrenceIter
+
+func (h occurrenceHeap) Len() int { return len(h) }
+func (h occurrenceHeap) Less(i, j int) bool {
+	return h[i].buffered[0].Start.Before(h[j].buffered[0].Start)
+}
+func (h occurrenceHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+func (h *occurrenceHeap) Push(x any)   { *h = append(*h, x.(*occurrenceIter)) }
+func (h *occurrenceHeap) Pop() any {
+	old := *h
+	it := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return it
+}
+
+// filterKinds returns the event objects whose main event is one of the given
+// kinds, objects without a kind are events. Todos and journals without a
+// time (start or due) are dropped as they cannot be placed in time.
+func filterKinds(objects []dto.EventObject, kinds ...string) []dto.EventObject {
+	var out []dto.EventObject
+	for _, obj := range objects {
+		kind := obj.Main.Kind
+		if kind == "" {
+			kind = ical.CompEvent
+		}
+		if !slices.Contains(kinds, kind) {
+			continue
+		}
+		if kind != ical.CompEvent && obj.Main.Start.Stamp.IsZero() {
+			continue
+		}
+		out = append(out, obj)
+	}
+	return out
+}
+
+// heapSource yields the occurrences of many event objects in order, expanding
+// them lazily. Objects that cannot be expanded and occurrences that end before
+// they start are skipped with a warning.
+type heapSource struct {
+	iters   occurrenceHeap
+	pending []string
+}
+
+func newHeapSource(objects []dto.EventObject, start, end time.Time) *heapSource {
+	src := &heapSource{}
+	for i, obj := range objects {
+		it := newOccurrenceIter(i, obj, start, end)
+		err := it.fill()
+		if err != nil {
+			src.pending = append(src.pending, err.Error())
+			continue
+		}
+		if len(it.buffered) > 0 {
+			src.iters = append(src.iters, it)
+		}
+	}
+	heap.Init(&src.iters)
+	return src
+}
+
+func (s *heapSource) peek() (dto.Event, bool) {
+	occ, ok := s.peekOccurrence()
+	return occ.Event, ok
+}
+
+// peekOccurrence is peek along with the object the event is an occurrence of.
+func (s *heapSource) peekOccurrence() (dto.Occurrence, bool) {
+	for len(s.iters) > 0 {
+		occ := s.iters[0].buffered[0]
+		msg, invalid := endsBeforeStart(occ.Event)
+		if !invalid {
+			return occ, true
+		}
+		s.pending = append(s.pending, msg)
+		s.pop()
+	}
+	return dto.Occurrence{}, false
+}
+
+func (s *heapSource) pop() {
+	it := s.iters[0]
+	it.buffered = it.buffered[1:]
+	err := it.fill()
+	if err != nil {
+		s.pending = append(s.pending, err.Error())
+		it.buffered = nil
+	}
+	if len(it.buffered) == 0 {
+		heap.Pop(&s.iters)
+		return
+	}
+	heap.Fix(&s.iters, 0)
+}
+
+func (s *heapSource) warnings() []string {
+	out := s.pending
+	s.pending = nil
+	return out
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	"github.com/LQR471814/nu_plugin_caldav/internal/dto"
+	"github.com/LQR471814/nu_plugin_caldav/internal/events"
+)
+
+// travelCategory is the category of the travel events inserted into a
+// timeline.
+const travelCategory = "TRAVEL"
+
+// travelOptions configure the time needed to travel between the locations of
+// events.
+type travelOptions struct {
+	// buffer is the travel time between different locations, it is used
+	// when the distance between them is unknown.
+	buffer time.Duration
+	// speed is the travel speed in km/h used to derive the travel time from
+	// the distance between the GEOs of events, 0 if it is not set.
+	speed float64
+}
+
+// geoDistance returns the great-circle distance between two points in km.
+func geoDistance(a, b events.EventGeo) float64 {
+	const earthRadius = 6371.0
+	rad := func(deg float64) float64 { return deg * math.Pi / 180 }
+	dLat := rad(b.Latitude - a.Latitude)
+	dLon := rad(b.Longitude - a.Longitude)
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(rad(a.Latitude))*math.Cos(rad(b.Latitude))*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadius * math.Asin(math.Sqrt(h))
+}
+
+// hasLocation reports whether an event takes place somewhere.
+func hasLocation(e dto.Event) bool {
+	return e.Geo != nil || (e.Location != nil && strings.TrimSpace(*e.Location) != "")
+}
+
+// travelTime returns the time needed to get from the location of an event to
+// the location of another, it is 0 if they are at the same location or their
+// locations cannot be compared.
+func (opts travelOptions) travelTime(from, to dto.Event) time.Duration {
+	if from.Geo != nil && to.Geo != nil {
+		km := geoDistance(*from.Geo, *to.Geo)
+		// GEOs of the same building differ slightly
+		if km < 0.05 {
+			return 0
+		}
+		if opts.speed > 0 {
+			hours := km / opts.speed
+			return time.Duration(math.Ceil(hours*60)) * time.Minute
+		}
+		return opts.buffer
+	}
+	if from.Location == nil || to.Location == nil {
+		return 0
+	}
+	if strings.EqualFold(strings.TrimSpace(*from.Location), strings.TrimSpace(*to.Location)) {
+		return 0
+	}
+	return opts.buffer
+}
+
+// transitionSource is implemented by the sources that report impossible
+// transitions between events.
+type transitionSource interface {
+	// impossibleTransitions returns (and clears) the transitions found since
+	// it was last called.
+	impossibleTransitions() []dto.Transition
+}
+
+// travelSource inserts travel events before the events whose location differs
+// from the location of the previous event, between the two events. Transitions
+// that do not leave enough time to travel are reported as impossible
+// transitions.
+type travelSource struct {
+	src  eventSource
+	opts travelOptions
+
+	// prev is the last busy event with a location
+	prev *dto.Event
+	// lastStart is the start of the last event, travel events cannot start
+	// before it to keep the events in order
+	lastStart time.Time
+	// planned is set once the travel before the next event is known
+	planned bool
+	travel  *dto.Event
+	// transitions are the impossible transitions not reported yet
+	transitions []dto.Transition
+}
+
+func (s *travelSource) plan(e dto.Event) {
+	s.planned = true
+	if s.prev == nil || !isBusy(e) || e.Start.AllDay || !hasLocation(e) {
+		return
+	}
+	need := s.opts.travelTime(*s.prev, e)
+	if need <= 0 {
+		return
+	}
+	prevEnd := s.prev.End.Stamp
+	if e.Start.Stamp.Before(prevEnd) {
+		s.addTransition(e, need)
+		return
+	}
+	from := e.Start.Stamp.Add(-need)
+	if from.Before(prevEnd) {
+		s.addTransition(e, need)
+		from = prevEnd
+	}
+	if from.Before(s.lastStart) {
+		from = s.lastStart
+	}
+	if !from.Before(e.Start.Stamp) {
+		return
+	}
+
+	summary := "Travel"
+	if e.Location != nil {
+		summary = "Travel to " + *e.Location
+	}
+	s.travel = &dto.Event{
+		Summary:    &summary,
+		Location:   e.Location,
+		Geo:        e.Geo,
+		Categories: []string{travelCategory},
+		Start:      events.Datetime{Stamp: from},
+		End:        events.Datetime{Stamp: e.Start.Stamp},
+	}
+}
+
+// addTransition reports the transition from the previous event to e as
+// impossible.
+func (s *travelSource) addTransition(e dto.Event, need time.Duration) {
+	s.transitions = append(s.transitions, dto.Transition{
+		From:      *s.prev,
+		To:        e,
+		Travel:    need,
+		Available: e.Start.Stamp.Sub(s.prev.End.Stamp),
+	})
+}
+
+func (s *travelSource) peek() (dto.Event, bool) {
+	if s.travel != nil {
+		return *s.travel, true
+	}
+	e, ok := s.src.peek()
+	if !ok {
+		return dto.Event{}, false
+	}
+	if !s.planned {
+		s.plan(e)
+		if s.travel != nil {
+			return *s.travel, true
+		}
+	}
+	return e, true
+}
+
+func (s *travelSource) pop() {
+	if s.travel != nil {
+		s.lastStart = s.travel.Start.Stamp
+		s.travel = nil
+		return
+	}
+	e, ok := s.src.peek()
+	if !ok {
+		return
+	}
+	if isBusy(e) && !e.Start.AllDay && hasLocation(e) {
+		s.prev = &e
+	}
+	s.lastStart = e.Start.Stamp
+	s.planned = false
+	s.src.pop()
+}
+
+func (s *travelSource) warnings() []string {
+	return s.src.warnings()
+}
+
+func (s *travelSource) impossibleTransitions() []dto.Transition {
+	out := s.transitions
+	s.transitions = nil
+	return out
+}